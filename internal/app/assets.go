@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// assetsPathPrefix is the route under which content assets are served
+const assetsPathPrefix = "/assets/"
+
+// newAssetHandler serves the non-markdown files under dir, e.g. images that
+// resources reference, at assetsPathPrefix. Files are opened through an
+// os.Root, so neither ".." segments nor symlinks can reach outside dir.
+// Markdown files and directories are answered with 404; resources are read
+// through MCP instead.
+func newAssetHandler(dir string) (http.Handler, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open assets directory: %w", err)
+	}
+	fsys := root.FS()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, assetsPathPrefix)
+		if !fs.ValidPath(name) || name == "." || strings.EqualFold(path.Ext(name), ".md") {
+			http.NotFound(w, r)
+			return
+		}
+
+		info, err := fs.Stat(fsys, name)
+		if err != nil || info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFileFS(w, r, fsys, name)
+	}), nil
+}
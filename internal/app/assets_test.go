@@ -0,0 +1,78 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// pngHeader is the signature that starts every PNG file
+var pngHeader = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+func newTestAssetHandler(t *testing.T) http.Handler {
+	t.Helper()
+	tmp := t.TempDir()
+	dir := filepath.Join(tmp, "mcp-resources")
+	_ = os.MkdirAll(filepath.Join(dir, "guides"), 0755)
+	_ = os.WriteFile(filepath.Join(dir, "guides", "diagram.png"), pngHeader, 0644)
+	_ = os.WriteFile(filepath.Join(dir, "guides", "setup.md"), []byte("# Setup"), 0644)
+	_ = os.WriteFile(filepath.Join(tmp, "secret.txt"), []byte("secret"), 0644)
+	_ = os.Symlink(filepath.Join(tmp, "secret.txt"), filepath.Join(dir, "link.txt"))
+
+	handler, err := newAssetHandler(dir)
+	if err != nil {
+		t.Fatalf("newAssetHandler failed: %v", err)
+	}
+	return handler
+}
+
+func TestAssetHandler_ServesPNG(t *testing.T) {
+	handler := newTestAssetHandler(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/assets/guides/diagram.png", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("Expected Content-Type image/png, got %q", got)
+	}
+	if rec.Body.String() != string(pngHeader) {
+		t.Errorf("Unexpected body %q", rec.Body.String())
+	}
+}
+
+func TestAssetHandler_NotFound(t *testing.T) {
+	handler := newTestAssetHandler(t)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "Traversal", path: "/assets/../secret.txt"},
+		{name: "Encoded Traversal", path: "/assets/%2e%2e/secret.txt"},
+		{name: "Symlink Outside Root", path: "/assets/link.txt"},
+		{name: "Markdown", path: "/assets/guides/setup.md"},
+		{name: "Directory", path: "/assets/guides"},
+		{name: "Root", path: "/assets/"},
+		{name: "Missing", path: "/assets/missing.png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("Expected 404 for %s, got %d", tt.path, rec.Code)
+			}
+		})
+	}
+}
+
+func TestNewAssetHandler_MissingDir(t *testing.T) {
+	if _, err := newAssetHandler(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing assets directory")
+	}
+}
@@ -8,14 +8,66 @@ func RegisterFlags(flags *pflag.FlagSet) {
 	flags.StringP("transport", "t", "", "Transport type: stdio or sse (default: stdio)")
 	flags.StringP("host", "H", "", "Host for SSE transport (default: 0.0.0.0)")
 	flags.IntP("port", "p", 0, "Port for SSE transport (default: 8080)")
+	flags.String("base-path", "", "URL path prefix for all HTTP endpoints, e.g. /mcp/docs (SSE mode only)")
+	flags.String("search-backend", "", "Name of the registered search backend (default: bleve)")
+	flags.String("search-ranking", "", "Search ranking algorithm: tf-idf or bm25 (default: tf-idf)")
 	flags.IntP("search-max-results", "m", 0, "Maximum search results (default: 10)")
+	flags.Int("search-max-terms", 0, "Maximum number of query terms; longer queries are truncated, 0 disables the limit (default: 32)")
 	flags.Float64("search-keywords-boost", 0, "Boost for keywords matches (default: 3.0)")
 	flags.Float64("search-name-boost", 0, "Boost for name matches (default: 2.0)")
 	flags.Float64("search-content-boost", 0, "Boost for content matches (default: 1.0)")
+	flags.Float64("search-headings-boost", 0, "Boost for matches in level-one and level-two markdown headings (default: 2.0)")
+	flags.StringSlice("search-fields", nil, "Frontmatter fields to index for searching and filtering (comma-separated)")
+	flags.Float64("search-fields-boost", 0, "Boost for frontmatter field matches (default: 1.0)")
+	flags.StringSlice("search-synonyms", nil, "Query term synonyms as term=synonym1|synonym2 (comma-separated)")
+	flags.StringSlice("search-index-mime-types", nil, "Only index resources of these MIME types (comma-separated; default: all)")
+	flags.StringSlice("search-exclude-mime-types", nil, "Never index resources of these MIME types; they remain readable (comma-separated)")
+	flags.Duration("search-timeout", 0, "Maximum duration of a search, e.g. 5s; 0 disables the limit (default: 0)")
+	flags.Bool("search-suggestions", false, "Suggest alternative query terms when a search finds nothing (default: false)")
+	flags.String("search-output-style", "", "Search tool output format: markdown or compact (default: markdown)")
+	flags.Bool("search-low-memory", false, "Do not keep document bodies in the search index; snippets are built from re-read content (default: false)")
+	flags.Bool("index-prompts", false, "Include prompt bodies in the search index (default: false)")
+	flags.Bool("prompts-strict", false, "Fail startup when a prompt template references undeclared arguments (default: false)")
+	flags.Bool("prompts-missing-key-error", false, "Fail prompt rendering when a referenced key is missing (default: false)")
+	flags.Bool("prompts-trim-output", false, "Trim surrounding whitespace and collapse blank lines in rendered prompts (default: false)")
+	flags.String("prompts-engine", "", "Prompt placeholder syntax: go ({{.arg}}), simple ({arg}), or mustache ({{arg}}) (default: go)")
+	flags.String("prompts-default-locale", "", "Locale served for localized prompts when a request names none or an unavailable one (default: en)")
+	flags.StringSlice("prompts-allowed-functions", nil, "Template functions prompts may call, e.g. eq,printf (comma-separated; default: all)")
 	flags.StringP("uri-scheme", "s", "", "URI scheme for resources (default: acdc)")
+	flags.String("uri-template", "", "Template for resource URIs using {scheme} and {path} placeholders (default: {scheme}://{path})")
 	flags.Bool("cross-ref", false, "Transform relative markdown links to resource URIs (default: false)")
+	flags.StringSlice("cross-ref-index-files", nil, "File names a relative link to a directory resolves to, in order (default: index.md,README.md)")
+	flags.Bool("cross-ref-preserve-original", false, "Keep the original target of rewritten links in the link title (default: false)")
+	flags.String("image-base-url", "", "Base URL that relative image references in resources are rewritten to, e.g. https://cdn.example.com/docs")
+	flags.String("tool-prefix", "", "Namespace prefix for built-in tool names, e.g. 'docs' registers 'docs_search'")
+	flags.Bool("metadata-tools-only", false, "Register only the tools listed in mcp-metadata.yaml; an empty tools list registers none (default: false)")
+	flags.Bool("follow-symlinks", false, "Follow symlinked directories when discovering content (default: false)")
+	flags.Bool("detect-encoding", false, "Detect UTF-16 and Latin-1 content files and transcode them to UTF-8 (default: false)")
+	flags.Bool("derive-metadata", false, "Serve markdown without frontmatter, deriving name and description from the first heading and paragraph (default: false)")
+	flags.String("default-mime-type", "", "MIME type of resources that do not set mime_type in their frontmatter (default: text/markdown)")
+	flags.String("trailing-newline", "", "How the end of markdown content is normalized: preserve, single, or none (default: preserve)")
+	flags.StringArray("redact-pattern", nil, "Regular expression whose matches are masked in resource content (repeatable)")
+	flags.Bool("deprecation-banner", true, "Prepend a deprecation notice to the content of deprecated resources (default: true)")
+	flags.Bool("detect-duplicates", false, "Treat resources with identical content as duplicates of a canonical resource (default: false)")
+	flags.Bool("canonical-notice", false, "Prepend a notice naming the canonical resource to the content of duplicates (default: false)")
+	flags.String("not-found-fallback", "", "URI of a resource returned instead of an error when reading an unknown resource")
+	flags.String("integrity-manifest", "", "Path to a sha256sum manifest that resource files are verified against at startup")
+	flags.String("audit-log", "", "Write an audit trail of search and read tool calls as JSON lines to a file path, or 'stdout'")
+	flags.Int("list-page-size", 0, "Maximum items per page for resources, prompts, and tools lists, 0 for the default (default: 1000)")
+	flags.String("list-order", "", "Order of resources in the list tool: discovery, name, or modified (default: discovery)")
+	flags.Int("max-read-bytes", 0, "Maximum bytes of content returned per read tool call, 0 for unlimited (default: 0)")
+	flags.Bool("expose-instructions-resource", false, "Publish the server instructions as a readable resource (default: false)")
+	flags.String("instructions-uri", "", "URI of the instructions resource (default: <uri-scheme>://instructions)")
+	flags.Bool("self-test", false, "Run a sample search and resource read at startup and fail if either errors (default: false)")
+	flags.Duration("refresh-interval", 0, "Poll content files at this interval, e.g. 30s, and re-index on changes; 0 disables polling (default: 0)")
+	flags.Bool("serve-assets", false, "Serve non-markdown files from the resources directory under /assets/ (SSE mode only) (default: false)")
+	flags.Bool("compression", false, "Enable gzip response compression for SSE transport (default: false)")
+	flags.Int("max-concurrent-sessions", 0, "Maximum concurrent SSE sessions, 0 for unlimited (default: 0)")
 	flags.StringP("auth-type", "a", "", "Authentication type: none, basic, or apikey (default: none)")
 	flags.StringP("auth-basic-username", "u", "", "Basic auth username")
 	flags.StringP("auth-basic-password", "P", "", "Basic auth password")
 	flags.StringSliceP("auth-api-keys", "k", nil, "API keys (comma-separated)")
+	flags.StringSlice("auth-roles", nil, "Roles granted to authenticated subjects as subject=role1|role2 (comma-separated)")
+	flags.Bool("print-config", false, "Print the effective configuration (secrets redacted) and exit")
+	flags.String("export", "", "Write all resources and prompts to stdout as a json or tar bundle and exit")
 }
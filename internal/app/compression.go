@@ -0,0 +1,124 @@
+package app
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// gzipMiddleware compresses responses for clients that accept gzip encoding.
+// Event streams are passed through untouched so SSE framing is preserved.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether the request advertises gzip support
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(strings.SplitN(enc, ";", 2)[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter decides whether to compress when the first body bytes
+// are written or flushed, based on the response headers set by the wrapped
+// handler. Until then the status is held back, so responses without a body,
+// such as a 202 or a HEAD response, are sent without gzip framing.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	status      int  // status set by the handler, sent with the first body bytes
+	wroteHeader bool // whether the status has been sent
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader || w.status != 0 {
+		return
+	}
+	w.status = status
+}
+
+// sendHeader sends the held status, starting compression when compress is
+// set and the response is compressible
+func (w *gzipResponseWriter) sendHeader(compress bool) {
+	w.wroteHeader = true
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	h := w.Header()
+	contentType := h.Get("Content-Type")
+	compressible := status != http.StatusNoContent &&
+		status != http.StatusNotModified &&
+		h.Get("Content-Encoding") == "" &&
+		!strings.HasPrefix(contentType, "text/event-stream")
+
+	if compress && compressible {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if len(b) == 0 {
+			return 0, nil
+		}
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.sendHeader(true)
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the header if it is still held, and flushes any buffered
+// compressed data before flushing the underlying writer
+func (w *gzipResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.sendHeader(true)
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close sends a held status of a response without a body, or ends the
+// compressed stream
+func (w *gzipResponseWriter) close() {
+	if !w.wroteHeader {
+		if w.status != 0 {
+			w.sendHeader(false)
+		}
+		return
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGzipMiddleware(t *testing.T) {
+	body := strings.Repeat("search result content ", 500)
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(body))
+	}))
+
+	// Uncompressed
+	req := httptest.NewRequest("GET", "/health", nil)
+	plain := httptest.NewRecorder()
+	handler.ServeHTTP(plain, req)
+	if enc := plain.Header().Get("Content-Encoding"); enc != "" {
+		t.Errorf("Expected no Content-Encoding without Accept-Encoding, got %q", enc)
+	}
+
+	// Compressed
+	req = httptest.NewRequest("GET", "/health", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip;q=0.9")
+	compressed := httptest.NewRecorder()
+	handler.ServeHTTP(compressed, req)
+	if enc := compressed.Header().Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("Expected gzip Content-Encoding, got %q", enc)
+	}
+
+	if compressed.Body.Len() >= plain.Body.Len() {
+		t.Errorf("Expected compressed body (%d bytes) to be smaller than plain body (%d bytes)", compressed.Body.Len(), plain.Body.Len())
+	}
+
+	gr, err := gzip.NewReader(compressed.Body)
+	if err != nil {
+		t.Fatalf("Failed to create gzip reader: %v", err)
+	}
+	decoded, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatalf("Failed to decompress body: %v", err)
+	}
+	if string(decoded) != body {
+		t.Error("Decompressed body does not match original")
+	}
+}
+
+func TestGzipMiddleware_SkipsEventStream(t *testing.T) {
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: endpoint\ndata: /sse?sessionid=1\n\n"))
+		w.(http.Flusher).Flush()
+	}))
+
+	req := httptest.NewRequest("GET", "/sse", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if enc := w.Header().Get("Content-Encoding"); enc != "" {
+		t.Errorf("Expected event stream to be uncompressed, got Content-Encoding %q", enc)
+	}
+	if !strings.HasPrefix(w.Body.String(), "event: endpoint") {
+		t.Errorf("Expected raw SSE framing, got %q", w.Body.String())
+	}
+}
+
+func TestGzipMiddleware_FlushBeforeWrite(t *testing.T) {
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if enc := w.Header().Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("Expected gzip Content-Encoding, got %q", enc)
+	}
+	gr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to create gzip reader: %v", err)
+	}
+	decoded, err := io.ReadAll(gr)
+	if err != nil || string(decoded) != `{"ok":true}` {
+		t.Errorf("Expected the decompressed body, got %q (%v)", decoded, err)
+	}
+}
+
+func TestGzipMiddleware_Bodiless(t *testing.T) {
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	req := httptest.NewRequest("POST", "/message", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("Expected status %d, got %d", http.StatusAccepted, w.Code)
+	}
+	if enc := w.Header().Get("Content-Encoding"); enc != "" {
+		t.Errorf("Expected no Content-Encoding without a body, got %q", enc)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected an empty body, got %d bytes", w.Body.Len())
+	}
+}
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+)
+
+// Supported corpus export formats
+const (
+	// ExportFormatJSON writes a single JSON document with all contents inlined
+	ExportFormatJSON = "json"
+	// ExportFormatTar writes a tar archive with a manifest.json and one
+	// markdown file per resource and prompt
+	ExportFormatTar = "tar"
+)
+
+// exportBundle is the exported corpus
+type exportBundle struct {
+	Server    exportServer     `json:"server"`
+	Resources []exportResource `json:"resources"`
+	Prompts   []exportPrompt   `json:"prompts"`
+}
+
+type exportServer struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type exportResource struct {
+	URI          string    `json:"uri"`
+	ID           string    `json:"id,omitempty"`
+	Name         string    `json:"name"`
+	Title        string    `json:"title,omitempty"`
+	Description  string    `json:"description"`
+	MIMEType     string    `json:"mime_type"`
+	Keywords     []string  `json:"keywords,omitempty"`
+	LastModified time.Time `json:"last_modified"`
+	Content      string    `json:"content,omitempty"` // Inlined in JSON bundles
+	File         string    `json:"file,omitempty"`    // Archive path in tar bundles
+}
+
+type exportPrompt struct {
+	Name        string           `json:"name"`
+	Locale      string           `json:"locale,omitempty"`
+	Description string           `json:"description"`
+	Arguments   []exportArgument `json:"arguments,omitempty"`
+	Template    string           `json:"template,omitempty"` // Inlined in JSON bundles
+	File        string           `json:"file,omitempty"`     // Archive path in tar bundles
+}
+
+type exportArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+// ExportCorpus writes every listed resource, with its content as served
+// (after transformers), and every prompt, with its unrendered template, to w
+// as a single bundle in the given format. Localized prompt variants are
+// exported individually. Hidden resources and resources
+// outside their publishing window are not exported.
+func ExportCorpus(w io.Writer, format string, settings *config.Settings) error {
+	if format != ExportFormatJSON && format != ExportFormatTar {
+		return fmt.Errorf("unsupported export format %q (expected %s or %s)", format, ExportFormatJSON, ExportFormatTar)
+	}
+
+	c, err := loadCorpus(settings)
+	if err != nil {
+		return err
+	}
+	bundle, err := c.bundle()
+	if err != nil {
+		return err
+	}
+
+	if format == ExportFormatTar {
+		return writeTarBundle(w, bundle)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(bundle)
+}
+
+// bundle collects the corpus into an export bundle with inlined contents
+func (c *corpus) bundle() (exportBundle, error) {
+	bundle := exportBundle{
+		Server:    exportServer{Name: c.metadata.Server.Name, Version: c.metadata.Server.Version},
+		Resources: []exportResource{},
+		Prompts:   []exportPrompt{},
+	}
+
+	now := time.Now()
+	for _, d := range c.resourceDefinitions {
+		if d.Hidden || !d.PublishedAt(now) {
+			continue
+		}
+		content, err := c.resourceProvider.ReadResource(d.URI)
+		if err != nil {
+			return bundle, fmt.Errorf("failed to export resource %s: %w", d.URI, err)
+		}
+		bundle.Resources = append(bundle.Resources, exportResource{
+			URI:          d.URI,
+			ID:           d.ID,
+			Name:         d.Name,
+			Title:        d.Title,
+			Description:  d.Description,
+			MIMEType:     d.MIMEType,
+			Keywords:     d.Keywords,
+			LastModified: d.LastModified.UTC(),
+			Content:      content,
+		})
+	}
+
+	for _, d := range c.promptDefinitions {
+		template, err := c.promptProvider.Template(d.Name, d.Locale)
+		if err != nil {
+			return bundle, fmt.Errorf("failed to export prompt %s: %w", d.Name, err)
+		}
+		prompt := exportPrompt{Name: d.Name, Locale: d.Locale, Description: d.Description, Template: template}
+		for _, a := range d.Arguments {
+			prompt.Arguments = append(prompt.Arguments, exportArgument{Name: a.Name, Description: a.Description, Required: a.Required})
+		}
+		bundle.Prompts = append(bundle.Prompts, prompt)
+	}
+	return bundle, nil
+}
+
+// writeTarBundle writes the bundle as a tar archive. Contents are moved out of
+// the manifest into files named after the resource URI path and prompt name.
+func writeTarBundle(w io.Writer, bundle exportBundle) error {
+	tw := tar.NewWriter(w)
+
+	for i, r := range bundle.Resources {
+		file := bundlePath("resources", r.URI)
+		if err := writeTarFile(tw, file, r.Content, r.LastModified); err != nil {
+			return err
+		}
+		bundle.Resources[i].File, bundle.Resources[i].Content = file, ""
+	}
+	for i, p := range bundle.Prompts {
+		name := p.Name
+		if p.Locale != "" {
+			name += "." + p.Locale
+		}
+		file := bundlePath("prompts", name)
+		if err := writeTarFile(tw, file, p.Template, time.Time{}); err != nil {
+			return err
+		}
+		bundle.Prompts[i].File, bundle.Prompts[i].Template = file, ""
+	}
+
+	manifest, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode export manifest: %w", err)
+	}
+	if err := writeTarFile(tw, "manifest.json", string(manifest), time.Time{}); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// writeTarFile adds a regular file to a tar archive
+func writeTarFile(tw *tar.Writer, name, content string, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s to export archive: %w", name, err)
+	}
+	if _, err := io.WriteString(tw, content); err != nil {
+		return fmt.Errorf("failed to write %s to export archive: %w", name, err)
+	}
+	return nil
+}
+
+// bundlePath maps a URI or name to a markdown file path under dir, e.g.
+// acdc://guides/setup to resources/guides/setup.md. Cleaning the path keeps
+// it inside dir.
+func bundlePath(dir, uriOrName string) string {
+	p := uriOrName
+	if _, rest, found := strings.Cut(uriOrName, "://"); found {
+		p = rest
+	}
+	return path.Join(dir, path.Clean("/"+p)) + ".md"
+}
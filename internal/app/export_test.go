@@ -0,0 +1,138 @@
+package app
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+)
+
+// newExportSettings creates a small corpus with a visible, a hidden, and a
+// nested resource and one prompt
+func newExportSettings(t *testing.T) *config.Settings {
+	t.Helper()
+	contentDir := t.TempDir()
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	promptsDir := filepath.Join(contentDir, "mcp-prompts")
+	_ = os.MkdirAll(filepath.Join(resourcesDir, "guides"), 0755)
+	_ = os.MkdirAll(promptsDir, 0755)
+
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte("server:\n  name: docs\n  version: 1.2.0\n  instructions: inst\ntools: []\n"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "intro.md"), []byte("---\nname: intro\ndescription: Introduction\nkeywords: [start]\n---\n# Intro"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "guides", "setup.md"), []byte("---\nname: setup\ndescription: Setup guide\n---\nToken: abc123"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "internal.md"), []byte("---\nname: internal\ndescription: Hidden\nhidden: true\n---\nHidden"), 0644)
+	_ = os.WriteFile(filepath.Join(promptsDir, "review.md"), []byte("---\nname: review\ndescription: Review code\narguments:\n  - name: lang\n    description: Language\n---\nReview {{.lang}} code"), 0644)
+
+	return &config.Settings{
+		ContentDir:     contentDir,
+		Scheme:         "acdc",
+		RedactPatterns: []string{`abc\d+`},
+	}
+}
+
+func TestExportCorpus_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportCorpus(&buf, ExportFormatJSON, newExportSettings(t)); err != nil {
+		t.Fatalf("ExportCorpus failed: %v", err)
+	}
+
+	var bundle exportBundle
+	if err := json.Unmarshal(buf.Bytes(), &bundle); err != nil {
+		t.Fatalf("Invalid JSON bundle: %v", err)
+	}
+
+	if bundle.Server.Name != "docs" || bundle.Server.Version != "1.2.0" {
+		t.Errorf("Unexpected server metadata: %+v", bundle.Server)
+	}
+	contents := make(map[string]string)
+	for _, r := range bundle.Resources {
+		contents[r.URI] = r.Content
+	}
+	if len(contents) != 2 {
+		t.Errorf("Expected 2 exported resources, got %d", len(contents))
+	}
+	if contents["acdc://intro"] != "# Intro" {
+		t.Errorf("Unexpected intro content %q", contents["acdc://intro"])
+	}
+	// Content is exported as served, after transformers
+	if contents["acdc://guides/setup"] != "Token: [REDACTED]" {
+		t.Errorf("Expected redacted setup content, got %q", contents["acdc://guides/setup"])
+	}
+	if _, ok := contents["acdc://internal"]; ok {
+		t.Error("Expected hidden resource not to be exported")
+	}
+
+	if len(bundle.Prompts) != 1 {
+		t.Fatalf("Expected 1 exported prompt, got %d", len(bundle.Prompts))
+	}
+	prompt := bundle.Prompts[0]
+	if prompt.Name != "review" || prompt.Template != "Review {{.lang}} code" || len(prompt.Arguments) != 1 {
+		t.Errorf("Unexpected exported prompt: %+v", prompt)
+	}
+}
+
+func TestExportCorpus_Tar(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportCorpus(&buf, ExportFormatTar, newExportSettings(t)); err != nil {
+		t.Fatalf("ExportCorpus failed: %v", err)
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Invalid tar bundle: %v", err)
+		}
+		data, _ := io.ReadAll(tr)
+		files[header.Name] = string(data)
+	}
+
+	if files["resources/intro.md"] != "# Intro" {
+		t.Errorf("Unexpected resources/intro.md: %q", files["resources/intro.md"])
+	}
+	if files["resources/guides/setup.md"] != "Token: [REDACTED]" {
+		t.Errorf("Unexpected resources/guides/setup.md: %q", files["resources/guides/setup.md"])
+	}
+	if files["prompts/review.md"] != "Review {{.lang}} code" {
+		t.Errorf("Unexpected prompts/review.md: %q", files["prompts/review.md"])
+	}
+
+	var manifest exportBundle
+	if err := json.Unmarshal([]byte(files["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("Invalid manifest: %v", err)
+	}
+	for _, r := range manifest.Resources {
+		if r.Content != "" || !strings.HasPrefix(r.File, "resources/") {
+			t.Errorf("Expected manifest entry to reference a file, got %+v", r)
+		}
+	}
+}
+
+func TestExportCorpus_UnsupportedFormat(t *testing.T) {
+	if err := ExportCorpus(io.Discard, "zip", newExportSettings(t)); err == nil {
+		t.Error("Expected error for unsupported export format")
+	}
+}
+
+func TestBundlePath(t *testing.T) {
+	tests := map[string]string{
+		"acdc://guides/setup": "resources/guides/setup.md",
+		"acdc://../../etc":    "resources/etc.md",
+		"docs://a/./b":        "resources/a/b.md",
+	}
+	for uri, want := range tests {
+		if got := bundlePath("resources", uri); got != want {
+			t.Errorf("bundlePath(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
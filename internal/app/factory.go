@@ -2,8 +2,11 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/config"
@@ -18,59 +21,322 @@ import (
 
 // CreateMCPServer initializes the core MCP server components
 func CreateMCPServer(settings *config.Settings) (*mcpsdk.Server, func(), error) {
-	// Initialize content provider
-	cp := content.NewContentProvider(settings.ContentDir)
+	c, err := loadCorpus(settings)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Load metadata
-	metadataPath := cp.GetPath("mcp-metadata.yaml")
+	var instructionsURI string
+	if settings.ExposeInstructionsResource {
+		instructionsURI = settings.InstructionsURI
+		if instructionsURI == "" {
+			instructionsURI = settings.Scheme + "://instructions"
+		}
+		if containsURI(c.resourceDefinitions, instructionsURI) {
+			return nil, nil, fmt.Errorf("instructions resource URI conflicts with an existing resource: %s", instructionsURI)
+		}
+	}
 
-	mdBytes, err := os.ReadFile(metadataPath)
+	// Initialize search service
+	searchService, err := search.NewSearcher(settings.Search)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read metadata file: %w", err)
+		return nil, nil, err
+	}
+	if setter, ok := searchService.(search.ContentLoaderSetter); ok {
+		// Snippets are cut at hit offsets, so only the exact document may be
+		// read, never a name match or the not-found fallback
+		setter.SetContentLoader(c.resourceProvider.ReadResourceByURI)
+	}
+	cleanup := func() {
+		searchService.Close()
 	}
 
-	var metadata domain.McpMetadata
-	if err := yaml.Unmarshal(mdBytes, &metadata); err != nil {
-		return nil, nil, fmt.Errorf("failed to parse metadata: %w", err)
+	// Index resources, and prompts when enabled
+	var streamer ResourceStreamer = c.resourceProvider
+	contentFiles := make([]string, 0, len(c.resourceDefinitions))
+	for _, d := range c.resourceDefinitions {
+		contentFiles = append(contentFiles, d.FilePath)
+	}
+	if settings.IndexPrompts {
+		streamer = multiStreamer{c.resourceProvider, StreamerFunc(c.promptProvider.StreamPrompts)}
+		for _, d := range c.promptDefinitions {
+			contentFiles = append(contentFiles, d.FilePath)
+		}
+	}
+	reindex := func(ctx context.Context) {
+		IndexResources(ctx, streamer, searchService)
+	}
+	reindex(context.Background())
+
+	if settings.RefreshInterval > 0 {
+		stopRefresh := startContentRefresh(settings.RefreshInterval, contentFiles, reindex)
+		cleanup = func() {
+			stopRefresh()
+			searchService.Close()
+		}
+	}
+
+	if settings.SelfTest {
+		if err := runSelfTest(context.Background(), searchService, c.resourceProvider); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("startup self-test failed: %w", err)
+		}
 	}
 
+	// Create MCP server
+	serverOpts := []mcp.ServerOption{
+		mcp.WithTransport(settings.Transport),
+		mcp.WithToolPrefix(settings.ToolPrefix),
+		mcp.WithSearchTimeout(settings.Search.Timeout),
+		mcp.WithPageSize(settings.ListPageSize),
+		mcp.WithMaxReadBytes(settings.MaxReadBytes),
+		mcp.WithSearchOutputStyle(settings.Search.OutputStyle),
+	}
+	if settings.Search.Suggestions {
+		serverOpts = append(serverOpts, mcp.WithSearchSuggestions())
+	}
+	if settings.MetadataToolsOnly {
+		serverOpts = append(serverOpts, mcp.WithMetadataToolsOnly())
+	}
+	if instructionsURI != "" {
+		serverOpts = append(serverOpts, mcp.WithInstructionsResource(instructionsURI))
+	}
+	if settings.AuditLog != "" {
+		auditLog, closeAuditLog, err := openAuditLog(settings.AuditLog)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		serverOpts = append(serverOpts, mcp.WithAuditLog(auditLog))
+		stop := cleanup
+		cleanup = func() {
+			stop()
+			closeAuditLog()
+		}
+	}
+	mcpServer := mcp.CreateServer(c.metadata, c.resourceProvider, c.promptProvider, searchService, serverOpts...)
+
+	return mcpServer, cleanup, nil
+}
+
+// openAuditLog opens the audit log destination: standard output, or a file
+// that entries are appended to. The returned function closes the file.
+func openAuditLog(destination string) (*mcp.AuditLog, func(), error) {
+	if destination == config.AuditLogStdout {
+		return mcp.NewAuditLog(os.Stdout), func() {}, nil
+	}
+	f, err := os.OpenFile(destination, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return mcp.NewAuditLog(f), func() { _ = f.Close() }, nil
+}
+
+// corpus is the content a server is created from
+type corpus struct {
+	metadata            domain.McpMetadata
+	resourceDefinitions []resources.ResourceDefinition
+	resourceProvider    *resources.ResourceProvider
+	promptDefinitions   []prompts.PromptDefinition
+	promptProvider      *prompts.PromptProvider
+}
+
+// loadCorpus loads the metadata, resources, and prompts a server is created from
+func loadCorpus(settings *config.Settings) (*corpus, error) {
+	// Initialize content provider
+	var contentOpts []content.Option
+	if settings.DetectEncoding {
+		contentOpts = append(contentOpts, content.WithEncodingDetection())
+	}
+	if settings.DeriveMetadata {
+		contentOpts = append(contentOpts, content.WithOptionalFrontmatter())
+	}
+	if settings.TrailingNewline != "" {
+		contentOpts = append(contentOpts, content.WithTrailingNewline(settings.TrailingNewline))
+	}
+	cp := content.NewContentProvider(settings.ContentDir, contentOpts...)
+
+	// Load metadata
+	metadata, err := loadMetadata(cp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := metadata.ResolveDescriptionFiles(cp.ContentDir); err != nil {
+		return nil, fmt.Errorf("metadata validation failed: %w", err)
+	}
+	metadata.ApplyToolDefaults()
 	if err := metadata.Validate(); err != nil {
-		return nil, nil, fmt.Errorf("metadata validation failed: %w", err)
+		return nil, fmt.Errorf("metadata validation failed: %w", err)
 	}
 
 	// Discover resources
-	resourceDefinitions, err := resources.DiscoverResources(cp, settings.Scheme)
+	var discoverOpts []resources.DiscoverOption
+	if settings.FollowSymlinks {
+		discoverOpts = append(discoverOpts, resources.WithFollowSymlinks())
+	}
+	if settings.URITemplate != "" {
+		discoverOpts = append(discoverOpts, resources.WithURITemplate(settings.URITemplate))
+	}
+	if len(settings.Search.Fields) > 0 {
+		discoverOpts = append(discoverOpts, resources.WithIndexedFields(settings.Search.Fields...))
+	}
+	if settings.DeriveMetadata {
+		discoverOpts = append(discoverOpts, resources.WithDerivedMetadata())
+	}
+	if settings.DefaultMIMEType != "" {
+		discoverOpts = append(discoverOpts, resources.WithDefaultMIMEType(settings.DefaultMIMEType))
+	}
+	if settings.DetectDuplicates {
+		discoverOpts = append(discoverOpts, resources.WithDuplicateDetection())
+	}
+	resourceDefinitions, err := resources.DiscoverResources(cp, settings.Scheme, discoverOpts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to discover resources: %w", err)
+		return nil, fmt.Errorf("failed to discover resources: %w", err)
+	}
+	if settings.IntegrityManifest != "" {
+		if err := resources.VerifyIntegrity(resourceDefinitions, cp.ContentDir, settings.IntegrityManifest); err != nil {
+			return nil, fmt.Errorf("integrity verification failed: %w", err)
+		}
 	}
 
-	var resourceOpts []resources.Option
+	linkOpts := []resources.LinkOption{resources.WithIndexFiles(settings.CrossRefIndexFiles...)}
+	resourceOpts := []resources.Option{
+		resources.WithContentProvider(cp),
+		resources.WithListOrder(settings.ListOrder),
+		resources.WithLinkGraph(resources.BuildLinkGraph(resourceDefinitions, settings.Scheme, cp, linkOpts...)),
+	}
 	if settings.CrossRef {
+		crossRefOpts := linkOpts
+		if settings.CrossRefPreserveOriginal {
+			crossRefOpts = append(crossRefOpts, resources.WithPreserveOriginal())
+		}
+		resourceOpts = append(resourceOpts, resources.WithTransformer(
+			resources.NewCrossRefTransformer(resourceDefinitions, settings.Scheme, crossRefOpts...),
+		))
+	}
+	if settings.ImageBaseURL != "" {
 		resourceOpts = append(resourceOpts, resources.WithTransformer(
-			resources.NewCrossRefTransformer(resourceDefinitions, settings.Scheme),
+			resources.NewImageTransformer(cp.ResourcesDir, settings.ImageBaseURL),
 		))
 	}
+	if settings.DeprecationBanner {
+		resourceOpts = append(resourceOpts, resources.WithTransformer(resources.NewDeprecationTransformer()))
+	}
+	if settings.CanonicalNotice {
+		resourceOpts = append(resourceOpts, resources.WithTransformer(resources.NewCanonicalTransformer()))
+	}
+	if len(settings.RedactPatterns) > 0 {
+		patterns, err := resources.CompileRedactionPatterns(settings.RedactPatterns)
+		if err != nil {
+			return nil, err
+		}
+		// Redaction runs last so rewritten content is masked as well
+		resourceOpts = append(resourceOpts, resources.WithTransformer(
+			resources.NewRedactionTransformer(patterns),
+		))
+	}
+	if len(settings.Search.IndexMIMETypes) > 0 || len(settings.Search.ExcludeMIMETypes) > 0 {
+		resourceOpts = append(resourceOpts, resources.WithIndexMIMETypes(settings.Search.IndexMIMETypes, settings.Search.ExcludeMIMETypes))
+	}
+	if settings.NotFoundFallback != "" {
+		if !containsURI(resourceDefinitions, settings.NotFoundFallback) {
+			return nil, fmt.Errorf("not-found fallback resource does not exist: %s", settings.NotFoundFallback)
+		}
+		resourceOpts = append(resourceOpts, resources.WithNotFoundFallback(settings.NotFoundFallback))
+	}
 	resourceProvider := resources.NewResourceProvider(resourceDefinitions, resourceOpts...)
 
 	// Discover prompts
-	promptDefinitions, err := prompts.DiscoverPrompts(cp)
+	var promptOpts []prompts.DiscoverOption
+	if settings.Prompts.Strict {
+		promptOpts = append(promptOpts, prompts.WithStrictArguments())
+	}
+	if settings.Prompts.MissingKeyError {
+		promptOpts = append(promptOpts, prompts.WithMissingKeyError())
+	}
+	if settings.Prompts.TrimOutput {
+		promptOpts = append(promptOpts, prompts.WithTrimOutput())
+	}
+	if settings.Prompts.Engine != "" {
+		promptOpts = append(promptOpts, prompts.WithTemplateEngine(settings.Prompts.Engine))
+	}
+	if len(settings.Prompts.AllowedFunctions) > 0 {
+		promptOpts = append(promptOpts, prompts.WithAllowedFunctions(settings.Prompts.AllowedFunctions...))
+	}
+	if settings.FollowSymlinks {
+		promptOpts = append(promptOpts, prompts.WithFollowSymlinks())
+	}
+	promptDefinitions, err := prompts.DiscoverPrompts(cp, promptOpts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to discover prompts: %w", err)
+		return nil, fmt.Errorf("failed to discover prompts: %w", err)
 	}
 
-	promptProvider := prompts.NewPromptProvider(promptDefinitions, cp)
+	var providerOpts []prompts.Option
+	if settings.Prompts.DefaultLocale != "" {
+		providerOpts = append(providerOpts, prompts.WithDefaultLocale(settings.Prompts.DefaultLocale))
+	}
+	promptProvider := prompts.NewPromptProvider(promptDefinitions, cp, providerOpts...)
 
-	// Initialize search service
-	searchService := search.NewService(settings.Search)
-	cleanup := func() {
-		searchService.Close()
+	return &corpus{
+		metadata:            metadata,
+		resourceDefinitions: resourceDefinitions,
+		resourceProvider:    resourceProvider,
+		promptDefinitions:   promptDefinitions,
+		promptProvider:      promptProvider,
+	}, nil
+}
+
+// containsURI reports whether any of the definitions has the given URI
+func containsURI(definitions []resources.ResourceDefinition, uri string) bool {
+	for _, d := range definitions {
+		if d.URI == uri {
+			return true
+		}
 	}
+	return false
+}
 
-	// Index resources
-	IndexResources(context.Background(), resourceProvider, searchService)
+// loadMetadata reads mcp-metadata.yaml and merges the YAML fragments found in
+// the metadata.d directory into it, in lexical filename order. The directory
+// is optional.
+func loadMetadata(cp *content.ContentProvider) (domain.McpMetadata, error) {
+	var metadata domain.McpMetadata
 
-	// Create MCP server
-	mcpServer := mcp.CreateServer(metadata, resourceProvider, promptProvider, searchService)
+	mdBytes, err := os.ReadFile(cp.GetPath("mcp-metadata.yaml"))
+	if err != nil {
+		return metadata, fmt.Errorf("failed to read metadata file: %w", err)
+	}
+	if err := yaml.Unmarshal(mdBytes, &metadata); err != nil {
+		return metadata, fmt.Errorf("failed to parse metadata: %w", err)
+	}
 
-	return mcpServer, cleanup, nil
+	fragmentsDir := cp.GetPath("metadata.d")
+	entries, err := os.ReadDir(fragmentsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return metadata, nil
+	}
+	if err != nil {
+		return metadata, fmt.Errorf("failed to read metadata fragments: %w", err)
+	}
+
+	// os.ReadDir returns entries sorted by filename
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(fragmentsDir, entry.Name())
+		fragmentBytes, err := os.ReadFile(path)
+		if err != nil {
+			return metadata, fmt.Errorf("failed to read metadata fragment %s: %w", entry.Name(), err)
+		}
+		var fragment domain.McpMetadata
+		if err := yaml.Unmarshal(fragmentBytes, &fragment); err != nil {
+			return metadata, fmt.Errorf("failed to parse metadata fragment %s: %w", entry.Name(), err)
+		}
+		metadata.Merge(fragment)
+	}
+	return metadata, nil
 }
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,7 +10,9 @@ import (
 
 	"github.com/sha1n/mcp-acdc-server/internal/config"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
 )
 
 func TestCreateMCPServer_Success(t *testing.T) {
@@ -239,6 +243,41 @@ tools: []
 	}
 }
 
+func TestCreateMCPServer_MissingResourcesDir(t *testing.T) {
+	contentDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte("server: { name: test, version: 1.0, instructions: inst }\n"), 0644)
+
+	settings := &config.Settings{
+		ContentDir: contentDir,
+		Scheme:     "acdc",
+		Search:     config.SearchSettings{InMemory: true, MaxResults: 10},
+	}
+	_, _, err := CreateMCPServer(settings)
+	if err == nil || !strings.Contains(err.Error(), "resources directory not found") {
+		t.Errorf("Expected resources directory not found error, got %v", err)
+	}
+}
+
+func TestCreateMCPServer_IntegrityManifestMismatch(t *testing.T) {
+	contentDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(contentDir, "mcp-resources"), 0755)
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte("server: { name: test, version: 1.0, instructions: inst }\n"), 0644)
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-resources", "doc.md"), []byte("---\nname: doc\ndescription: d\n---\ncontent"), 0644)
+	manifest := filepath.Join(t.TempDir(), "SHA256SUMS")
+	_ = os.WriteFile(manifest, []byte(strings.Repeat("0", 64)+"  mcp-resources/doc.md\n"), 0644)
+
+	settings := &config.Settings{
+		ContentDir:        contentDir,
+		Scheme:            "acdc",
+		IntegrityManifest: manifest,
+		Search:            config.SearchSettings{InMemory: true, MaxResults: 10},
+	}
+	_, _, err := CreateMCPServer(settings)
+	if err == nil || !strings.Contains(err.Error(), "integrity verification failed") {
+		t.Errorf("Expected integrity verification error, got %v", err)
+	}
+}
+
 func TestCreateMCPServer_InvalidToolMetadata_MissingName(t *testing.T) {
 	tempDir := t.TempDir()
 	contentDir := filepath.Join(tempDir, "content")
@@ -485,3 +524,303 @@ func TestCreateMCPServer_CrossRefTransformation_CustomScheme(t *testing.T) {
 		t.Errorf("Content should contain 'myco://b', got: %s", contentA)
 	}
 }
+
+func TestCreateMCPServer_ToolDefaults(t *testing.T) {
+	tempDir := t.TempDir()
+	contentDir := filepath.Join(tempDir, "content")
+	_ = os.MkdirAll(filepath.Join(contentDir, "mcp-resources"), 0755)
+
+	metadataContent := `
+server:
+  name: test
+  version: 1.0
+  instructions: inst
+tool_defaults:
+  description: shared description
+tools:
+  - name: search
+`
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadataContent), 0644)
+
+	settings := &config.Settings{
+		ContentDir: contentDir,
+		Scheme:     "acdc",
+		Search: config.SearchSettings{
+			InMemory:   true,
+			MaxResults: 10,
+		},
+	}
+
+	_, cleanup, err := CreateMCPServer(settings)
+	if err != nil {
+		t.Fatalf("Expected tool defaults to satisfy validation, got: %v", err)
+	}
+	cleanup()
+}
+
+func TestCreateMCPServer_NotFoundFallback(t *testing.T) {
+	tempDir := t.TempDir()
+	contentDir := filepath.Join(tempDir, "content")
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	_ = os.MkdirAll(resourcesDir, 0755)
+
+	metadataContent := `
+server:
+  name: test
+  version: 1.0
+  instructions: inst
+tools: []
+`
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadataContent), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "index.md"), []byte("---\nname: Index\ndescription: Index\n---\nindex"), 0644)
+
+	newSettings := func(fallback string) *config.Settings {
+		return &config.Settings{
+			ContentDir:       contentDir,
+			Scheme:           "acdc",
+			NotFoundFallback: fallback,
+			Search:           config.SearchSettings{InMemory: true, MaxResults: 10},
+		}
+	}
+
+	server, cleanup, err := CreateMCPServer(newSettings("acdc://index"))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer cleanup()
+	if server == nil {
+		t.Fatal("Server is nil")
+	}
+
+	_, _, err = CreateMCPServer(newSettings("acdc://missing"))
+	if err == nil || !strings.Contains(err.Error(), "not-found fallback") {
+		t.Errorf("Expected error for unknown fallback resource, got %v", err)
+	}
+}
+
+// recordingSearcher is a search backend that records the documents it indexes
+type recordingSearcher struct {
+	indexed []string
+	closed  bool
+}
+
+func (r *recordingSearcher) Search(ctx context.Context, queryStr string, opts search.SearchOptions) (search.SearchResponse, error) {
+	return search.SearchResponse{}, nil
+}
+
+func (r *recordingSearcher) Index(ctx context.Context, documents <-chan domain.Document) error {
+	for doc := range documents {
+		r.indexed = append(r.indexed, doc.URI)
+	}
+	return nil
+}
+
+func (r *recordingSearcher) Close() { r.closed = true }
+
+func TestCreateMCPServer_SearchBackend(t *testing.T) {
+	tempDir := t.TempDir()
+	contentDir := filepath.Join(tempDir, "content")
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	_ = os.MkdirAll(resourcesDir, 0755)
+
+	metadataContent := `
+server:
+  name: test
+  version: 1.0
+  instructions: inst
+tools: []
+`
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadataContent), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "doc.md"), []byte("---\nname: Doc\ndescription: Doc\n---\ndoc"), 0644)
+
+	recorder := &recordingSearcher{}
+	search.RegisterBackend("factory-test-recorder", func(settings config.SearchSettings) (search.Searcher, error) {
+		return recorder, nil
+	})
+
+	newSettings := func(backend string) *config.Settings {
+		return &config.Settings{
+			ContentDir: contentDir,
+			Scheme:     "acdc",
+			Search:     config.SearchSettings{Backend: backend, InMemory: true, MaxResults: 10},
+		}
+	}
+
+	_, cleanup, err := CreateMCPServer(newSettings("factory-test-recorder"))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if len(recorder.indexed) != 1 || recorder.indexed[0] != "acdc://doc" {
+		t.Errorf("Expected the registered backend to index acdc://doc, got %v", recorder.indexed)
+	}
+	cleanup()
+	if !recorder.closed {
+		t.Error("Expected cleanup to close the registered backend")
+	}
+
+	_, _, err = CreateMCPServer(newSettings("missing"))
+	if err == nil || !strings.Contains(err.Error(), "unknown search backend") {
+		t.Errorf("Expected error for unknown search backend, got %v", err)
+	}
+}
+
+func writeMetadataFragments(t *testing.T, contentDir string, fragments map[string]string) {
+	t.Helper()
+	fragmentsDir := filepath.Join(contentDir, "metadata.d")
+	if err := os.MkdirAll(fragmentsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range fragments {
+		if err := os.WriteFile(filepath.Join(fragmentsDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadMetadata_Fragments(t *testing.T) {
+	contentDir := t.TempDir()
+	base := "server:\n  name: base\n  version: 1.0\n  instructions: base instructions\ntools:\n  - name: search\n    description: base search\n"
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(base), 0644)
+	writeMetadataFragments(t, contentDir, map[string]string{
+		"20-override.yml": "server:\n  version: 3.0\n",
+		"10-tools.yaml":   "server:\n  version: 2.0\n  instructions: fragment instructions\ntools:\n  - name: read\n    description: fragment read\n",
+		"README.md":       "not a fragment",
+	})
+
+	metadata, err := loadMetadata(content.NewContentProvider(contentDir))
+	if err != nil {
+		t.Fatalf("loadMetadata error = %v", err)
+	}
+
+	want := domain.ServerMetadata{Name: "base", Version: "3.0", Instructions: "fragment instructions"}
+	if metadata.Server != want {
+		t.Errorf("Expected server %+v, got %+v", want, metadata.Server)
+	}
+	if got := metadata.GetToolMetadata("read").Description; got != "fragment read" {
+		t.Errorf("Expected fragment tool to be merged, got %q", got)
+	}
+	if got := metadata.GetToolMetadata("search").Description; got != "base search" {
+		t.Errorf("Expected base tool to be kept, got %q", got)
+	}
+}
+
+func TestLoadMetadata_InvalidFragment(t *testing.T) {
+	contentDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte("server:\n  name: base\n"), 0644)
+	writeMetadataFragments(t, contentDir, map[string]string{"bad.yaml": "not: valid: yaml: {{"})
+
+	_, err := loadMetadata(content.NewContentProvider(contentDir))
+	if err == nil || !strings.Contains(err.Error(), "failed to parse metadata fragment bad.yaml") {
+		t.Errorf("Expected fragment parse error, got %v", err)
+	}
+}
+
+func TestCreateMCPServer_DuplicateToolAcrossFragments(t *testing.T) {
+	tempDir := t.TempDir()
+	contentDir := filepath.Join(tempDir, "content")
+	_ = os.MkdirAll(filepath.Join(contentDir, "mcp-resources"), 0755)
+	base := "server:\n  name: test\n  version: 1.0\n  instructions: inst\ntools:\n  - name: search\n    description: base search\n"
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(base), 0644)
+	writeMetadataFragments(t, contentDir, map[string]string{
+		"tools.yaml": "tools:\n  - name: search\n    description: fragment search\n",
+	})
+
+	settings := &config.Settings{
+		ContentDir: contentDir,
+		Scheme:     "acdc",
+		Search: config.SearchSettings{
+			InMemory:   true,
+			MaxResults: 10,
+		},
+	}
+
+	_, _, err := CreateMCPServer(settings)
+	if err == nil || !strings.Contains(err.Error(), "duplicate tool name: search") {
+		t.Errorf("Expected duplicate tool error, got %v", err)
+	}
+}
+
+func TestCreateMCPServer_InstructionsURIConflict(t *testing.T) {
+	tempDir := t.TempDir()
+	contentDir := filepath.Join(tempDir, "content")
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	_ = os.MkdirAll(resourcesDir, 0755)
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte("server:\n  name: test\n  version: 1.0\n  instructions: inst\n"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "instructions.md"), []byte("---\nname: Instructions\ndescription: D\n---\ncontent"), 0644)
+
+	settings := &config.Settings{
+		ContentDir:                 contentDir,
+		Scheme:                     "acdc",
+		ExposeInstructionsResource: true,
+		Search:                     config.SearchSettings{InMemory: true, MaxResults: 10},
+	}
+
+	_, _, err := CreateMCPServer(settings)
+	if err == nil || !strings.Contains(err.Error(), "conflicts with an existing resource: acdc://instructions") {
+		t.Errorf("Expected instructions URI conflict error, got %v", err)
+	}
+
+	settings.InstructionsURI = "acdc://server-instructions"
+	_, cleanup, err := CreateMCPServer(settings)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	cleanup()
+}
+
+// failingSearcher is a search backend whose searches always fail
+type failingSearcher struct {
+	recordingSearcher
+}
+
+func (f *failingSearcher) Search(ctx context.Context, queryStr string, opts search.SearchOptions) (search.SearchResponse, error) {
+	return search.SearchResponse{}, errors.New("backend unavailable")
+}
+
+func TestCreateMCPServer_SelfTest(t *testing.T) {
+	contentDir := t.TempDir()
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	_ = os.MkdirAll(resourcesDir, 0755)
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte("server: { name: test, version: 1.0, instructions: inst }\n"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "doc.md"), []byte("---\nname: Doc\ndescription: Doc\n---\ndoc"), 0644)
+
+	failing := &failingSearcher{}
+	search.RegisterBackend("factory-test-failing", func(settings config.SearchSettings) (search.Searcher, error) {
+		return failing, nil
+	})
+
+	newSettings := func(backend string, selfTest bool) *config.Settings {
+		return &config.Settings{
+			ContentDir: contentDir,
+			Scheme:     "acdc",
+			SelfTest:   selfTest,
+			Search:     config.SearchSettings{Backend: backend, InMemory: true, MaxResults: 10},
+		}
+	}
+
+	t.Run("Passes", func(t *testing.T) {
+		_, cleanup, err := CreateMCPServer(newSettings(search.DefaultBackend, true))
+		if err != nil {
+			t.Fatalf("Expected self-test to pass, got %v", err)
+		}
+		cleanup()
+	})
+
+	t.Run("Failure aborts startup", func(t *testing.T) {
+		_, _, err := CreateMCPServer(newSettings("factory-test-failing", true))
+		if err == nil || !strings.Contains(err.Error(), "startup self-test failed") {
+			t.Fatalf("Expected self-test failure, got %v", err)
+		}
+		if !failing.closed {
+			t.Error("Expected the search backend to be closed after a failed self-test")
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, cleanup, err := CreateMCPServer(newSettings("factory-test-failing", false))
+		if err != nil {
+			t.Fatalf("Expected startup without self-test to succeed, got %v", err)
+		}
+		cleanup()
+	})
+}
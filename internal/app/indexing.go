@@ -13,6 +13,27 @@ type ResourceStreamer interface {
 	StreamResources(ctx context.Context, ch chan<- domain.Document) error
 }
 
+// StreamerFunc adapts a streaming function to the ResourceStreamer interface
+type StreamerFunc func(ctx context.Context, ch chan<- domain.Document) error
+
+// StreamResources calls f
+func (f StreamerFunc) StreamResources(ctx context.Context, ch chan<- domain.Document) error {
+	return f(ctx, ch)
+}
+
+// multiStreamer streams documents from several streamers in sequence
+type multiStreamer []ResourceStreamer
+
+// StreamResources streams from each streamer in order, stopping at the first error
+func (m multiStreamer) StreamResources(ctx context.Context, ch chan<- domain.Document) error {
+	for _, s := range m {
+		if err := s.StreamResources(ctx, ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // IndexResources coordinates the streaming and indexing of resources
 func IndexResources(ctx context.Context, rs ResourceStreamer, indexer search.Searcher) {
 	docsChan := make(chan domain.Document, 100)
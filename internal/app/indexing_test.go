@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/sha1n/mcp-acdc-server/internal/config"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/sha1n/mcp-acdc-server/internal/search"
 )
@@ -36,8 +37,8 @@ func (m *mockIndexer) Index(ctx context.Context, documents <-chan domain.Documen
 	return nil
 }
 
-func (m *mockIndexer) Search(queryStr string, limit *int) ([]search.SearchResult, error) {
-	return nil, nil
+func (m *mockIndexer) Search(ctx context.Context, queryStr string, opts search.SearchOptions) (search.SearchResponse, error) {
+	return search.SearchResponse{}, nil
 }
 func (m *mockIndexer) Close() {}
 
@@ -63,3 +64,46 @@ func TestIndexResources_IndexError(t *testing.T) {
 	// Should not panic, logs error
 	IndexResources(context.Background(), rs, idx)
 }
+
+func TestIndexResources_MultiStreamer(t *testing.T) {
+	first := StreamerFunc(func(ctx context.Context, ch chan<- domain.Document) error {
+		ch <- domain.Document{URI: "acdc://resource", Name: "Resource", Content: "deployment checklist"}
+		return nil
+	})
+	second := StreamerFunc(func(ctx context.Context, ch chan<- domain.Document) error {
+		ch <- domain.Document{URI: "prompt://review", Name: "review", Content: "code review guidance"}
+		return nil
+	})
+
+	svc := search.NewService(config.SearchSettings{InMemory: true, MaxResults: 10})
+	defer svc.Close()
+
+	IndexResources(context.Background(), multiStreamer{first, second}, svc)
+
+	resp, err := svc.Search(context.Background(), "review", search.SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].URI != "prompt://review" {
+		t.Errorf("Expected prompt://review in results, got %+v", resp.Results)
+	}
+}
+
+func TestMultiStreamer_StopsOnError(t *testing.T) {
+	called := false
+	failing := StreamerFunc(func(ctx context.Context, ch chan<- domain.Document) error {
+		return errors.New("stream error")
+	})
+	next := StreamerFunc(func(ctx context.Context, ch chan<- domain.Document) error {
+		called = true
+		return nil
+	})
+
+	err := multiStreamer{failing, next}.StreamResources(context.Background(), make(chan domain.Document, 1))
+	if err == nil {
+		t.Error("Expected error from failing streamer")
+	}
+	if called {
+		t.Error("Expected streaming to stop after the first error")
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// fileState is the part of a file's status that signals a content change
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshotFiles stats the given files. Files that cannot be stat'ed, e.g.
+// because they were removed, are recorded with a zero state.
+func snapshotFiles(paths []string) map[string]fileState {
+	states := make(map[string]fileState, len(paths))
+	for _, path := range paths {
+		var state fileState
+		if info, err := os.Stat(path); err == nil {
+			state = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+		states[path] = state
+	}
+	return states
+}
+
+// pollContent re-stats the given files on every tick and calls reindex when
+// the modification time or size of any of them changed since the previous
+// tick. It is an alternative to filesystem notifications, which are not
+// delivered on some network filesystems. It returns when ctx is done.
+func pollContent(ctx context.Context, ticks <-chan time.Time, paths []string, reindex func(context.Context)) {
+	previous := snapshotFiles(paths)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticks:
+			current := snapshotFiles(paths)
+			if changed := changedFiles(previous, current); len(changed) > 0 {
+				slog.Info("Content changed, re-indexing", "files", changed)
+				reindex(ctx)
+			}
+			previous = current
+		}
+	}
+}
+
+// changedFiles lists the files whose state differs between two snapshots of
+// the same files
+func changedFiles(previous, current map[string]fileState) []string {
+	var changed []string
+	for path, state := range current {
+		if before := previous[path]; !before.modTime.Equal(state.modTime) || before.size != state.size {
+			changed = append(changed, path)
+		}
+	}
+	return changed
+}
+
+// startContentRefresh polls the given files every interval and re-indexes on
+// changes. The returned function stops polling and waits for an in-flight
+// re-index to finish.
+func startContentRefresh(interval time.Duration, paths []string, reindex func(context.Context)) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		pollContent(ctx, ticker.C, paths, reindex)
+	}()
+
+	return func() {
+		ticker.Stop()
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPollContent_ReindexesOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(path, []byte("# Doc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time)
+	reindexed := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pollContent(ctx, ticks, []string{path}, func(context.Context) { reindexed <- struct{}{} })
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// An unchanged file does not trigger a re-index
+	ticks <- time.Now()
+	ticks <- time.Now()
+	select {
+	case <-reindexed:
+		t.Fatal("Expected no re-index for unchanged content")
+	default:
+	}
+
+	if err := os.WriteFile(path, []byte("# Doc\n\nUpdated"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ticks <- time.Now()
+	select {
+	case <-reindexed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a re-index after the file changed")
+	}
+
+	// The change is only reported once
+	ticks <- time.Now()
+	ticks <- time.Now()
+	select {
+	case <-reindexed:
+		t.Fatal("Expected no further re-index")
+	default:
+	}
+}
+
+func TestPollContent_ReindexesOnRemoval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(path, []byte("# Doc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticks := make(chan time.Time)
+	reindexed := make(chan struct{}, 1)
+	go pollContent(ctx, ticks, []string{path}, func(context.Context) { reindexed <- struct{}{} })
+
+	// Wait for the initial snapshot to be taken
+	ticks <- time.Now()
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	ticks <- time.Now()
+	select {
+	case <-reindexed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a re-index after the file was removed")
+	}
+}
+
+func TestStartContentRefresh_Stop(t *testing.T) {
+	stop := startContentRefresh(time.Millisecond, nil, func(context.Context) {})
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Expected stop to return")
+	}
+}
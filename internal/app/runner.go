@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 
@@ -17,7 +18,9 @@ type RunParams struct {
 	ValidSettings     func(*config.Settings) error
 	StartSSEServer    func(*mcp.Server, *config.Settings) error
 	CreateServer      func(*config.Settings) (*mcp.Server, func(), error)
+	ExportCorpus      func(io.Writer, string, *config.Settings) error
 	CustomIOTransport mcp.Transport // Optional: for testing with custom IO
+	Stdout            io.Writer     // Optional: output for --print-config and --export (default: os.Stdout)
 }
 
 // DefaultRunParams returns production dependencies
@@ -27,6 +30,7 @@ func DefaultRunParams() RunParams {
 		ValidSettings:  config.ValidateSettings,
 		StartSSEServer: StartSSEServer,
 		CreateServer:   CreateMCPServer,
+		ExportCorpus:   ExportCorpus,
 	}
 }
 
@@ -38,6 +42,16 @@ func RunWithDeps(ctx context.Context, params RunParams, flags *pflag.FlagSet, ve
 		return fmt.Errorf("failed to load settings: %w", err)
 	}
 
+	out := params.Stdout
+	if out == nil {
+		out = os.Stdout
+	}
+
+	// Print the effective configuration and exit, before validation so invalid configs can be diagnosed
+	if printConfigRequested(flags) {
+		return config.PrintSettings(out, settings)
+	}
+
 	// Validate settings for conflicting configurations
 	if err := params.ValidSettings(settings); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
@@ -47,6 +61,11 @@ func RunWithDeps(ctx context.Context, params RunParams, flags *pflag.FlagSet, ve
 	handler := slog.NewTextHandler(os.Stderr, nil)
 	slog.SetDefault(slog.New(handler))
 
+	// Export the content corpus and exit instead of serving it
+	if format := exportFormat(flags); format != "" {
+		return params.ExportCorpus(out, format, settings)
+	}
+
 	slog.Info("Starting MCP Acdc server", "version", version)
 	config.Log(settings)
 
@@ -71,3 +90,24 @@ func RunWithDeps(ctx context.Context, params RunParams, flags *pflag.FlagSet, ve
 		return params.StartSSEServer(mcpServer, settings)
 	}
 }
+
+// printConfigRequested reports whether the --print-config flag is set
+func printConfigRequested(flags *pflag.FlagSet) bool {
+	if flags == nil || flags.Lookup("print-config") == nil {
+		return false
+	}
+	v, err := flags.GetBool("print-config")
+	return err == nil && v
+}
+
+// exportFormat returns the format requested with the --export flag, if any
+func exportFormat(flags *pflag.FlagSet) string {
+	if flags == nil || flags.Lookup("export") == nil {
+		return ""
+	}
+	v, err := flags.GetString("export")
+	if err != nil {
+		return ""
+	}
+	return v
+}
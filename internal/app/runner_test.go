@@ -1,8 +1,10 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -110,6 +112,84 @@ func TestRunWithDeps_Cleanup(t *testing.T) {
 	}
 }
 
+func TestRunWithDeps_PrintConfig(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	RegisterFlags(flags)
+	_ = flags.Set("print-config", "true")
+
+	var out bytes.Buffer
+	params := RunParams{
+		LoadSettings: func(*pflag.FlagSet) (*config.Settings, error) {
+			return &config.Settings{
+				Transport: "sse",
+				Auth: config.AuthSettings{
+					Type:  config.AuthTypeBasic,
+					Basic: config.BasicAuthSettings{Username: "admin", Password: "secret"},
+				},
+			}, nil
+		},
+		ValidSettings: func(*config.Settings) error {
+			t.Error("Validation should not run when printing config")
+			return nil
+		},
+		CreateServer: func(*config.Settings) (*mcp.Server, func(), error) {
+			t.Error("Server should not be created when printing config")
+			return nil, nil, nil
+		},
+		Stdout: &out,
+	}
+
+	if err := RunWithDeps(context.Background(), params, flags, "test"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	printed := out.String()
+	if !strings.Contains(printed, "transport: sse") {
+		t.Errorf("Expected transport in printed config, got:\n%s", printed)
+	}
+	if !strings.Contains(printed, "username: admin") {
+		t.Errorf("Expected username in printed config, got:\n%s", printed)
+	}
+	if strings.Contains(printed, "secret") {
+		t.Errorf("Printed config leaks password:\n%s", printed)
+	}
+}
+
+func TestRunWithDeps_Export(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	RegisterFlags(flags)
+	_ = flags.Set("export", "tar")
+
+	var out bytes.Buffer
+	var gotFormat string
+	params := RunParams{
+		LoadSettings: func(*pflag.FlagSet) (*config.Settings, error) {
+			return &config.Settings{Transport: "stdio"}, nil
+		},
+		ValidSettings: func(*config.Settings) error { return nil },
+		CreateServer: func(*config.Settings) (*mcp.Server, func(), error) {
+			t.Error("Server should not be created when exporting")
+			return nil, nil, nil
+		},
+		ExportCorpus: func(w io.Writer, format string, _ *config.Settings) error {
+			gotFormat = format
+			_, err := io.WriteString(w, "bundle")
+			return err
+		},
+		Stdout: &out,
+	}
+
+	if err := RunWithDeps(context.Background(), params, flags, "test"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotFormat != "tar" {
+		t.Errorf("Expected export format tar, got %q", gotFormat)
+	}
+	if out.String() != "bundle" {
+		t.Errorf("Expected the bundle on stdout, got %q", out.String())
+	}
+}
+
 func TestDefaultRunParams(t *testing.T) {
 	params := DefaultRunParams()
 
@@ -119,6 +199,9 @@ func TestDefaultRunParams(t *testing.T) {
 	if params.ValidSettings == nil {
 		t.Error("ValidSettings is nil")
 	}
+	if params.ExportCorpus == nil {
+		t.Error("ExportCorpus is nil")
+	}
 	if params.StartSSEServer == nil {
 		t.Error("StartSSEServer is nil")
 	}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+)
+
+// selfTestFallbackQuery is searched when there are no resources to take a query from
+const selfTestFallbackQuery = "test"
+
+// runSelfTest exercises the server's read paths before clients connect: it
+// runs a search for the name of the first listed resource and reads that
+// resource. Without resources, only a search is run.
+func runSelfTest(ctx context.Context, searcher search.Searcher, rp *resources.ResourceProvider) error {
+	query := selfTestFallbackQuery
+	var uri string
+	if listed := rp.ListResources(); len(listed) > 0 {
+		query, uri = listed[0].Name, listed[0].URI
+	}
+
+	response, err := searcher.Search(ctx, query, search.SearchOptions{})
+	if err != nil {
+		return fmt.Errorf("search for %q failed: %w", query, err)
+	}
+
+	if uri != "" {
+		if _, err := rp.ReadResource(uri); err != nil {
+			return fmt.Errorf("read of %s failed: %w", uri, err)
+		}
+	}
+
+	slog.Info("Startup self-test passed", "query", query, "results", len(response.Results), "read", uri)
+	return nil
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/auth"
 	"github.com/sha1n/mcp-acdc-server/internal/config"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
 )
 
 // StartSSEServer starts the SSE server with authentication
@@ -34,14 +36,30 @@ func NewSSEServer(s *mcp.Server, settings *config.Settings) (*http.Server, error
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	mux.Handle("/sse", sseHandler)
+	basePath := strings.TrimSuffix(settings.BasePath, "/")
+	mux.Handle("/sse", sessionLimitMiddleware(settings.MaxConcurrentSessions, withBasePath(basePath, sseHandler)))
+	if settings.ServeAssets {
+		assetHandler, err := newAssetHandler(content.NewContentProvider(settings.ContentDir).ResourcesDir)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle(assetsPathPrefix, assetHandler)
+	}
 
 	authMiddleware, err := auth.NewMiddleware(settings.Auth)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth middleware: %w", err)
 	}
 
-	handler := authMiddleware(mux)
+	// Compression runs inside auth so rejected requests are not compressed
+	var handler http.Handler = mux
+	if settings.Compression {
+		handler = gzipMiddleware(handler)
+	}
+	handler = authMiddleware(handler)
+	if basePath != "" {
+		handler = http.StripPrefix(basePath, handler)
+	}
 	addr := fmt.Sprintf("%s:%d", settings.Host, settings.Port)
 
 	return &http.Server{
@@ -49,3 +67,19 @@ func NewSSEServer(s *mcp.Server, settings *config.Settings) (*http.Server, error
 		Handler: handler,
 	}, nil
 }
+
+// withBasePath restores the base path that was stripped from the request path
+// for routing before calling next. The SSE handler derives the message
+// endpoint it advertises from the request path, which must include the base
+// path for clients to resolve it.
+func withBasePath(basePath string, next http.Handler) http.Handler {
+	if basePath == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = basePath + r.URL.Path
+		r2.URL.RawPath = ""
+		next.ServeHTTP(w, r2)
+	})
+}
@@ -1,7 +1,10 @@
 package app
 
 import (
+	"context"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -121,3 +124,65 @@ func TestStartSSEServer_PortCollision(t *testing.T) {
 		t.Error("Expected error because port is already in use")
 	}
 }
+
+func TestNewSSEServer_BasePath(t *testing.T) {
+	mcpSrv := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0"}, nil)
+	mcpSrv.AddPrompt(&mcp.Prompt{Name: "greet"}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{}, nil
+	})
+	srv, err := NewSSEServer(mcpSrv, &config.Settings{
+		BasePath: "/mcp/docs/",
+		Auth:     config.AuthSettings{Type: config.AuthTypeAPIKey, APIKeys: []string{"test-key"}},
+	})
+	if err != nil {
+		t.Fatalf("NewSSEServer failed: %v", err)
+	}
+	ts := httptest.NewServer(srv.Handler)
+	defer ts.Close()
+
+	statusOf := func(path string) int {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	// The health check stays public under the prefix
+	if got := statusOf("/mcp/docs/health"); got != http.StatusOK {
+		t.Errorf("Expected 200 for the prefixed health check, got %d", got)
+	}
+	if got := statusOf("/health"); got != http.StatusNotFound {
+		t.Errorf("Expected 404 outside the prefix, got %d", got)
+	}
+
+	// The advertised message endpoint must resolve for a session to work
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0"}, nil)
+	session, err := client.Connect(context.Background(), &mcp.SSEClientTransport{
+		Endpoint:   ts.URL + "/mcp/docs/sse",
+		HTTPClient: &http.Client{Transport: apiKeyTransport{key: "test-key"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	result, err := session.ListPrompts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListPrompts failed: %v", err)
+	}
+	if len(result.Prompts) != 1 || result.Prompts[0].Name != "greet" {
+		t.Errorf("Expected the greet prompt, got %v", result.Prompts)
+	}
+}
+
+// apiKeyTransport adds an API key header to every request
+type apiKeyTransport struct {
+	key string
+}
+
+func (t apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-API-Key", t.key)
+	return http.DefaultTransport.RoundTrip(req)
+}
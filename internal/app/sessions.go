@@ -0,0 +1,40 @@
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// sessionRetryAfterSeconds is the Retry-After hint sent when the session limit is reached
+const sessionRetryAfterSeconds = 5
+
+// sessionLimitMiddleware caps the number of concurrently open SSE sessions.
+// Only GET requests open a session; POSTs carry messages for an existing
+// session and are never rejected. A non-positive limit disables the check.
+func sessionLimitMiddleware(limit int, next http.Handler) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+
+	sem := make(chan struct{}, limit)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			slog.Warn("Rejecting SSE session, concurrency limit reached", "limit", limit, "remote_addr", r.RemoteAddr)
+			w.Header().Set("Retry-After", strconv.Itoa(sessionRetryAfterSeconds))
+			http.Error(w, "too many concurrent sessions", http.StatusServiceUnavailable)
+			return
+		}
+		// The SSE handler blocks until the client disconnects
+		defer func() { <-sem }()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSessionLimitMiddleware(t *testing.T) {
+	const limit = 2
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	handler := sessionLimitMiddleware(limit, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			entered <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Open sessions up to the limit
+	var wg sync.WaitGroup
+	for i := 0; i < limit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("Expected 200 for session within limit, got %d", rec.Code)
+			}
+		}()
+		<-entered
+	}
+
+	// The next session is rejected
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 when limit is exceeded, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "5" {
+		t.Errorf("Expected Retry-After 5, got %q", got)
+	}
+
+	// Messages for existing sessions are not limited
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sse?sessionid=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 for POST while at limit, got %d", rec.Code)
+	}
+
+	// Disconnecting frees slots
+	close(release)
+	wg.Wait()
+
+	go func() { <-entered }()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 after sessions were released, got %d", rec.Code)
+	}
+}
+
+func TestSessionLimitMiddleware_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := sessionLimitMiddleware(0, next)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 with limit disabled, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import "context"
+
+// Identity describes the authenticated caller of a request
+type Identity struct {
+	Subject string   // authenticated principal (e.g. basic auth username)
+	Type    string   // auth type that authenticated the subject (e.g. config.AuthTypeBasic)
+	Roles   []string // roles assigned to the subject
+}
+
+// identityContextKey is the context key under which the Identity is stored
+type identityContextKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the given identity
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, id)
+}
+
+// IdentityFromContext returns the identity stored in ctx, if any
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityContextKey{}).(Identity)
+	return id, ok
+}
+
+// SubjectFromContext returns the authenticated subject stored in ctx,
+// or an empty string if the request is unauthenticated.
+func SubjectFromContext(ctx context.Context) string {
+	id, _ := IdentityFromContext(ctx)
+	return id.Subject
+}
+
+// RolesFromContext returns the roles of the authenticated identity stored in
+// ctx, or nil if the request is unauthenticated.
+func RolesFromContext(ctx context.Context) []string {
+	id, _ := IdentityFromContext(ctx)
+	return id.Roles
+}
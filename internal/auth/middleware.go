@@ -2,6 +2,7 @@ package auth
 
 import (
 	"crypto/subtle"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -13,6 +14,12 @@ var excludedPaths = map[string]bool{
 	"/health": true,
 }
 
+// Challenges sent in the WWW-Authenticate header of 401 responses
+const (
+	basicChallenge  = `Basic realm="Restricted"`
+	apiKeyChallenge = `APIKey realm="Restricted", header="X-API-Key"`
+)
+
 // isExcludedPath checks if the request path should bypass authentication
 func isExcludedPath(path string) bool {
 	return excludedPaths[path]
@@ -20,6 +27,11 @@ func isExcludedPath(path string) bool {
 
 // NewMiddleware creates a new authentication middleware based on settings
 func NewMiddleware(settings config.AuthSettings) (func(http.Handler) http.Handler, error) {
+	roles, err := settings.RoleMap()
+	if err != nil {
+		return nil, err
+	}
+
 	switch settings.Type {
 	case config.AuthTypeNone, "":
 		return func(next http.Handler) http.Handler {
@@ -29,12 +41,12 @@ func NewMiddleware(settings config.AuthSettings) (func(http.Handler) http.Handle
 		if settings.Basic.Username == "" || settings.Basic.Password == "" {
 			return nil, fmt.Errorf("basic auth requires non-empty username and password")
 		}
-		return withExclusions(basicAuthMiddleware(settings.Basic)), nil
+		return withExclusions(basicAuthMiddleware(settings.Basic, roles)), nil
 	case config.AuthTypeAPIKey:
 		if len(settings.APIKeys) == 0 {
 			return nil, fmt.Errorf("apikey auth requires at least one API key")
 		}
-		return withExclusions(apiKeyMiddleware(settings.APIKeys)), nil
+		return withExclusions(apiKeyMiddleware(settings.APIKeys, roles)), nil
 	default:
 		return nil, fmt.Errorf("unknown auth type: %s", settings.Type)
 	}
@@ -54,44 +66,57 @@ func withExclusions(authMiddleware func(http.Handler) http.Handler) func(http.Ha
 	}
 }
 
-func basicAuthMiddleware(settings config.BasicAuthSettings) func(http.Handler) http.Handler {
+func basicAuthMiddleware(settings config.BasicAuthSettings, roles map[string][]string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			user, pass, ok := r.BasicAuth()
 			userMatch := subtle.ConstantTimeCompare([]byte(user), []byte(settings.Username)) == 1
 			passMatch := subtle.ConstantTimeCompare([]byte(pass), []byte(settings.Password)) == 1
 			if !ok || !userMatch || !passMatch {
-				w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				writeUnauthorized(w, basicChallenge)
 				return
 			}
-			next.ServeHTTP(w, r)
+			ctx := WithIdentity(r.Context(), Identity{Subject: user, Type: config.AuthTypeBasic, Roles: roles[user]})
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-func apiKeyMiddleware(apiKeys []string) func(http.Handler) http.Handler {
+func apiKeyMiddleware(apiKeys []string, roles map[string][]string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			key := r.Header.Get("X-API-Key")
 			if key == "" {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				writeUnauthorized(w, apiKeyChallenge)
 				return
 			}
 
-			valid := false
-			for _, validKey := range apiKeys {
+			matched := -1
+			for i, validKey := range apiKeys {
 				if subtle.ConstantTimeCompare([]byte(key), []byte(validKey)) == 1 {
-					valid = true
+					matched = i
 					break
 				}
 			}
 
-			if !valid {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			if matched < 0 {
+				writeUnauthorized(w, apiKeyChallenge)
 				return
 			}
-			next.ServeHTTP(w, r)
+			// The key itself is a secret, so the subject identifies it by position only
+			subject := fmt.Sprintf("apikey-%d", matched+1)
+			ctx := WithIdentity(r.Context(), Identity{Subject: subject, Type: config.AuthTypeAPIKey, Roles: roles[subject]})
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// writeUnauthorized writes a 401 response with the given WWW-Authenticate challenge
+// and a small JSON error body, so all auth types respond consistently.
+func writeUnauthorized(w http.ResponseWriter, challenge string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+}
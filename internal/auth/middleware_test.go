@@ -1,8 +1,11 @@
 package auth
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sha1n/mcp-acdc-server/internal/config"
@@ -13,7 +16,7 @@ func TestBasicAuth(t *testing.T) {
 		Username: "user",
 		Password: "password",
 	}
-	middleware := basicAuthMiddleware(settings)
+	middleware := basicAuthMiddleware(settings, nil)
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -47,7 +50,7 @@ func TestBasicAuth(t *testing.T) {
 
 func TestAPIKeyAuth(t *testing.T) {
 	apiKeys := []string{"key-1", "key-2"}
-	middleware := apiKeyMiddleware(apiKeys)
+	middleware := apiKeyMiddleware(apiKeys, nil)
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -241,3 +244,144 @@ func TestPathExclusions(t *testing.T) {
 		t.Errorf("/api/data with valid auth should succeed, got %d", w.Code)
 	}
 }
+
+func TestUnauthorizedResponse_ChallengeHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  config.AuthSettings
+		challenge string
+	}{
+		{
+			name: "Basic",
+			settings: config.AuthSettings{
+				Type:  config.AuthTypeBasic,
+				Basic: config.BasicAuthSettings{Username: "u", Password: "p"},
+			},
+			challenge: "Basic ",
+		},
+		{
+			name: "APIKey",
+			settings: config.AuthSettings{
+				Type:    config.AuthTypeAPIKey,
+				APIKeys: []string{"k"},
+			},
+			challenge: "APIKey ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw, err := NewMiddleware(tt.settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			req := httptest.NewRequest("GET", "/sse", nil)
+			w := httptest.NewRecorder()
+			mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("Expected status 401, got %d", w.Code)
+			}
+			if got := w.Header().Get("WWW-Authenticate"); !strings.HasPrefix(got, tt.challenge) {
+				t.Errorf("Expected WWW-Authenticate to start with %q, got %q", tt.challenge, got)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Expected JSON content type, got %q", got)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode body: %v", err)
+			}
+			if body["error"] != "unauthorized" {
+				t.Errorf("Expected error 'unauthorized', got %q", body["error"])
+			}
+		})
+	}
+}
+
+func TestIdentityPropagation(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.AuthSettings
+		setup    func(r *http.Request)
+		want     Identity
+	}{
+		{
+			name: "Basic",
+			settings: config.AuthSettings{
+				Type:  config.AuthTypeBasic,
+				Basic: config.BasicAuthSettings{Username: "alice", Password: "p"},
+			},
+			setup: func(r *http.Request) { r.SetBasicAuth("alice", "p") },
+			want:  Identity{Subject: "alice", Type: config.AuthTypeBasic},
+		},
+		{
+			name: "APIKey",
+			settings: config.AuthSettings{
+				Type:    config.AuthTypeAPIKey,
+				APIKeys: []string{"key-1", "key-2"},
+			},
+			setup: func(r *http.Request) { r.Header.Set("X-API-Key", "key-2") },
+			want:  Identity{Subject: "apikey-2", Type: config.AuthTypeAPIKey},
+		},
+		{
+			name: "Basic With Roles",
+			settings: config.AuthSettings{
+				Type:  config.AuthTypeBasic,
+				Basic: config.BasicAuthSettings{Username: "alice", Password: "p"},
+				Roles: []string{"alice=internal|admin"},
+			},
+			setup: func(r *http.Request) { r.SetBasicAuth("alice", "p") },
+			want:  Identity{Subject: "alice", Type: config.AuthTypeBasic, Roles: []string{"internal", "admin"}},
+		},
+		{
+			name: "APIKey With Roles",
+			settings: config.AuthSettings{
+				Type:    config.AuthTypeAPIKey,
+				APIKeys: []string{"key-1", "key-2"},
+				Roles:   []string{"apikey-1=internal"},
+			},
+			setup: func(r *http.Request) { r.Header.Set("X-API-Key", "key-1") },
+			want:  Identity{Subject: "apikey-1", Type: config.AuthTypeAPIKey, Roles: []string{"internal"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw, err := NewMiddleware(tt.settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var got Identity
+			var found bool
+			handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, found = IdentityFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/sse", nil)
+			tt.setup(req)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !found {
+				t.Fatal("Expected identity in downstream context")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected identity %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("None", func(t *testing.T) {
+		mw, _ := NewMiddleware(config.AuthSettings{Type: config.AuthTypeNone})
+		handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := IdentityFromContext(r.Context()); ok {
+				t.Error("Expected no identity when auth is disabled")
+			}
+			if s := SubjectFromContext(r.Context()); s != "" {
+				t.Errorf("Expected empty subject, got %q", s)
+			}
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/sse", nil))
+	})
+}
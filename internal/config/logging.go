@@ -15,16 +15,92 @@ func LogWithLogger(s *Settings, logger *slog.Logger) {
 	ctx := context.Background()
 	logger.InfoContext(ctx, "Config: content_dir", "value", s.ContentDir)
 	logger.InfoContext(ctx, "Config: transport", "value", s.Transport)
+	logger.InfoContext(ctx, "Config: uri_template", "value", s.URITemplate)
+	logger.InfoContext(ctx, "Config: follow_symlinks", "value", s.FollowSymlinks)
+	logger.InfoContext(ctx, "Config: detect_encoding", "value", s.DetectEncoding)
+	logger.InfoContext(ctx, "Config: derive_metadata", "value", s.DeriveMetadata)
+	logger.InfoContext(ctx, "Config: default_mime_type", "value", s.DefaultMIMEType)
+	logger.InfoContext(ctx, "Config: trailing_newline", "value", s.TrailingNewline)
+	logger.InfoContext(ctx, "Config: deprecation_banner", "value", s.DeprecationBanner)
+	logger.InfoContext(ctx, "Config: detect_duplicates", "value", s.DetectDuplicates)
+	logger.InfoContext(ctx, "Config: canonical_notice", "value", s.CanonicalNotice)
+	if s.CrossRef {
+		logger.InfoContext(ctx, "Config: cross_ref_index_files", "value", s.CrossRefIndexFiles)
+		logger.InfoContext(ctx, "Config: cross_ref_preserve_original", "value", s.CrossRefPreserveOriginal)
+	}
+	if s.ToolPrefix != "" {
+		logger.InfoContext(ctx, "Config: tool_prefix", "value", s.ToolPrefix)
+	}
+	logger.InfoContext(ctx, "Config: metadata_tools_only", "value", s.MetadataToolsOnly)
 	if s.Transport == "sse" {
 		logger.InfoContext(ctx, "Config: host", "value", s.Host)
 		logger.InfoContext(ctx, "Config: port", "value", s.Port)
+		if s.BasePath != "" {
+			logger.InfoContext(ctx, "Config: base_path", "value", s.BasePath)
+		}
+		logger.InfoContext(ctx, "Config: compression", "value", s.Compression)
+		logger.InfoContext(ctx, "Config: serve_assets", "value", s.ServeAssets)
+		logger.InfoContext(ctx, "Config: max_concurrent_sessions", "value", s.MaxConcurrentSessions)
 	}
 
+	if s.NotFoundFallback != "" {
+		logger.InfoContext(ctx, "Config: not_found_fallback", "value", s.NotFoundFallback)
+	}
+	logger.InfoContext(ctx, "Config: self_test", "value", s.SelfTest)
+	if s.RefreshInterval > 0 {
+		logger.InfoContext(ctx, "Config: refresh_interval", "value", s.RefreshInterval)
+	}
+	if s.ImageBaseURL != "" {
+		logger.InfoContext(ctx, "Config: image_base_url", "value", s.ImageBaseURL)
+	}
+	if s.IntegrityManifest != "" {
+		logger.InfoContext(ctx, "Config: integrity_manifest", "value", s.IntegrityManifest)
+	}
+	if s.AuditLog != "" {
+		logger.InfoContext(ctx, "Config: audit_log", "value", s.AuditLog)
+	}
+	if s.ListPageSize > 0 {
+		logger.InfoContext(ctx, "Config: list_page_size", "value", s.ListPageSize)
+	}
+	logger.InfoContext(ctx, "Config: list_order", "value", s.ListOrder)
+	if s.MaxReadBytes > 0 {
+		logger.InfoContext(ctx, "Config: max_read_bytes", "value", s.MaxReadBytes)
+	}
+	if s.ExposeInstructionsResource {
+		logger.InfoContext(ctx, "Config: instructions_uri", "value", s.InstructionsURI)
+	}
+	logger.InfoContext(ctx, "Config: redact_patterns", "count", len(s.RedactPatterns))
+
+	logger.InfoContext(ctx, "Config: search.backend", "value", s.Search.Backend)
+	logger.InfoContext(ctx, "Config: search.ranking", "value", s.Search.Ranking)
 	logger.InfoContext(ctx, "Config: search.max_results", "value", s.Search.MaxResults)
+	logger.InfoContext(ctx, "Config: search.max_terms", "value", s.Search.MaxTerms)
 	logger.InfoContext(ctx, "Config: search.in_memory", "value", s.Search.InMemory)
+	logger.InfoContext(ctx, "Config: search.low_memory", "value", s.Search.LowMemory)
 	logger.InfoContext(ctx, "Config: search.keywords_boost", "value", s.Search.KeywordsBoost)
 	logger.InfoContext(ctx, "Config: search.name_boost", "value", s.Search.NameBoost)
 	logger.InfoContext(ctx, "Config: search.content_boost", "value", s.Search.ContentBoost)
+	logger.InfoContext(ctx, "Config: search.headings_boost", "value", s.Search.HeadingsBoost)
+	logger.InfoContext(ctx, "Config: search.fields", "value", s.Search.Fields)
+	logger.InfoContext(ctx, "Config: search.fields_boost", "value", s.Search.FieldsBoost)
+	logger.InfoContext(ctx, "Config: search.synonyms", "value", s.Search.Synonyms)
+	if len(s.Search.IndexMIMETypes) > 0 {
+		logger.InfoContext(ctx, "Config: search.index_mime_types", "value", s.Search.IndexMIMETypes)
+	}
+	if len(s.Search.ExcludeMIMETypes) > 0 {
+		logger.InfoContext(ctx, "Config: search.exclude_mime_types", "value", s.Search.ExcludeMIMETypes)
+	}
+	logger.InfoContext(ctx, "Config: search.timeout", "value", s.Search.Timeout)
+	logger.InfoContext(ctx, "Config: search.suggestions", "value", s.Search.Suggestions)
+	logger.InfoContext(ctx, "Config: search.output_style", "value", s.Search.OutputStyle)
+
+	logger.InfoContext(ctx, "Config: index_prompts", "value", s.IndexPrompts)
+	logger.InfoContext(ctx, "Config: prompts.strict", "value", s.Prompts.Strict)
+	logger.InfoContext(ctx, "Config: prompts.missing_key_error", "value", s.Prompts.MissingKeyError)
+	logger.InfoContext(ctx, "Config: prompts.trim_output", "value", s.Prompts.TrimOutput)
+	logger.InfoContext(ctx, "Config: prompts.engine", "value", s.Prompts.Engine)
+	logger.InfoContext(ctx, "Config: prompts.default_locale", "value", s.Prompts.DefaultLocale)
+	logger.InfoContext(ctx, "Config: prompts.allowed_functions", "value", s.Prompts.AllowedFunctions)
 
 	logger.InfoContext(ctx, "Config: auth.type", "value", s.Auth.Type)
 	switch s.Auth.Type {
@@ -34,16 +110,30 @@ func LogWithLogger(s *Settings, logger *slog.Logger) {
 	case AuthTypeAPIKey:
 		logger.InfoContext(ctx, "Config: auth.api_keys", "count", len(s.Auth.APIKeys))
 	}
+	if len(s.Auth.Roles) > 0 {
+		logger.InfoContext(ctx, "Config: auth.roles", "value", s.Auth.Roles)
+	}
 }
 
 // SearchSettingsLogValue returns a slog.Value for SearchSettings with masked data if needed
 func SearchSettingsLogValue(s SearchSettings) slog.Value {
 	return slog.GroupValue(
+		slog.String("backend", s.Backend),
+		slog.String("ranking", s.Ranking),
 		slog.Int("max_results", s.MaxResults),
+		slog.Int("max_terms", s.MaxTerms),
 		slog.Bool("in_memory", s.InMemory),
+		slog.Bool("low_memory", s.LowMemory),
 		slog.Float64("keywords_boost", s.KeywordsBoost),
 		slog.Float64("name_boost", s.NameBoost),
 		slog.Float64("content_boost", s.ContentBoost),
+		slog.Float64("headings_boost", s.HeadingsBoost),
+		slog.Any("fields", s.Fields),
+		slog.Float64("fields_boost", s.FieldsBoost),
+		slog.Any("synonyms", s.Synonyms),
+		slog.Duration("timeout", s.Timeout),
+		slog.Bool("suggestions", s.Suggestions),
+		slog.String("output_style", s.OutputStyle),
 	)
 }
 
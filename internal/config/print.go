@@ -0,0 +1,35 @@
+package config
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue replaces secret values in printed or logged settings
+const redactedValue = "****"
+
+// Redacted returns a copy of the settings with secrets (passwords, API keys) masked
+func Redacted(s Settings) Settings {
+	if s.Auth.Basic.Password != "" {
+		s.Auth.Basic.Password = redactedValue
+	}
+	if len(s.Auth.APIKeys) > 0 {
+		keys := make([]string, len(s.Auth.APIKeys))
+		for i := range keys {
+			keys[i] = redactedValue
+		}
+		s.Auth.APIKeys = keys
+	}
+	return s
+}
+
+// PrintSettings writes the effective settings to w as YAML, with secrets redacted
+func PrintSettings(w io.Writer, s *Settings) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(Redacted(*s)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRedacted(t *testing.T) {
+	s := Settings{
+		Auth: AuthSettings{
+			Type:    AuthTypeBasic,
+			Basic:   BasicAuthSettings{Username: "admin", Password: "secret"},
+			APIKeys: []string{"key-1", "key-2"},
+		},
+	}
+
+	r := Redacted(s)
+
+	if r.Auth.Basic.Password != "****" {
+		t.Errorf("Expected password redacted, got %q", r.Auth.Basic.Password)
+	}
+	if r.Auth.Basic.Username != "admin" {
+		t.Errorf("Expected username preserved, got %q", r.Auth.Basic.Username)
+	}
+	for _, k := range r.Auth.APIKeys {
+		if k != "****" {
+			t.Errorf("Expected API key redacted, got %q", k)
+		}
+	}
+	if s.Auth.Basic.Password != "secret" || s.Auth.APIKeys[0] != "key-1" {
+		t.Error("Redacted must not modify the original settings")
+	}
+}
+
+func TestPrintSettings(t *testing.T) {
+	s := &Settings{
+		ContentDir: "/content",
+		Transport:  "sse",
+		Port:       9000,
+		Scheme:     "acdc",
+		Search:     SearchSettings{MaxResults: 7},
+		Auth: AuthSettings{
+			Type:    AuthTypeAPIKey,
+			APIKeys: []string{"super-secret-key"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintSettings(&buf, s); err != nil {
+		t.Fatalf("PrintSettings failed: %v", err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "super-secret-key") {
+		t.Errorf("Printed config leaks API key:\n%s", out)
+	}
+
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Printed config is not valid YAML: %v", err)
+	}
+	if parsed["content_dir"] != "/content" {
+		t.Errorf("Expected content_dir '/content', got %v", parsed["content_dir"])
+	}
+	search, ok := parsed["search"].(map[string]interface{})
+	if !ok || search["max_results"] != 7 {
+		t.Errorf("Expected search.max_results 7, got %v", parsed["search"])
+	}
+}
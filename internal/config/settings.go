@@ -2,10 +2,13 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -14,15 +17,117 @@ import (
 // schemeRegexp validates URI schemes per RFC 3986: ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )
 var schemeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+\-.]*$`)
 
+// toolPrefixRegexp restricts tool prefixes to characters allowed in MCP tool names
+var toolPrefixRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.\-]*$`)
+
+// searchFieldRegexp restricts indexed frontmatter field names to simple identifiers
+var searchFieldRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]*$`)
+
+// mimeTypeRegexp validates MIME types of the form type/subtype
+var mimeTypeRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+\-]*/[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+\-]*$`)
+
+// localeRegexp validates locale tags such as en, de-AT, or pt_BR
+var localeRegexp = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+
+// basePathRegexp validates HTTP base paths such as /mcp/docs
+var basePathRegexp = regexp.MustCompile(`^(/[a-zA-Z0-9._~\-]+)*/?$`)
+
+// uriTemplatePlaceholderRegexp matches the {placeholder} segments of a URI template
+var uriTemplatePlaceholderRegexp = regexp.MustCompile(`\{[^{}]*\}`)
+
 // SearchSettings configuration for search service
 type SearchSettings struct {
-	MaxResults    int     `mapstructure:"max_results"`
-	InMemory      bool    `mapstructure:"in_memory"`
-	KeywordsBoost float64 `mapstructure:"keywords_boost"`
-	NameBoost     float64 `mapstructure:"name_boost"`
-	ContentBoost  float64 `mapstructure:"content_boost"`
+	Backend          string        `mapstructure:"backend" yaml:"backend"`
+	Ranking          string        `mapstructure:"ranking" yaml:"ranking"` // SearchRankingTFIDF or SearchRankingBM25
+	MaxResults       int           `mapstructure:"max_results" yaml:"max_results"`
+	MaxTerms         int           `mapstructure:"max_terms" yaml:"max_terms"`
+	Timeout          time.Duration `mapstructure:"timeout" yaml:"timeout"`
+	Suggestions      bool          `mapstructure:"suggestions" yaml:"suggestions"`
+	OutputStyle      string        `mapstructure:"output_style" yaml:"output_style"` // SearchOutputMarkdown or SearchOutputCompact
+	InMemory         bool          `mapstructure:"in_memory" yaml:"in_memory"`
+	LowMemory        bool          `mapstructure:"low_memory" yaml:"low_memory"`
+	KeywordsBoost    float64       `mapstructure:"keywords_boost" yaml:"keywords_boost"`
+	NameBoost        float64       `mapstructure:"name_boost" yaml:"name_boost"`
+	ContentBoost     float64       `mapstructure:"content_boost" yaml:"content_boost"`
+	HeadingsBoost    float64       `mapstructure:"headings_boost" yaml:"headings_boost"`
+	Fields           []string      `mapstructure:"fields" yaml:"fields"`
+	FieldsBoost      float64       `mapstructure:"fields_boost" yaml:"fields_boost"`
+	IndexMIMETypes   []string      `mapstructure:"index_mime_types" yaml:"index_mime_types"`
+	ExcludeMIMETypes []string      `mapstructure:"exclude_mime_types" yaml:"exclude_mime_types"`
+	Synonyms         []string      `mapstructure:"synonyms" yaml:"synonyms"` // term=synonym1|synonym2 entries
+}
+
+// SynonymMap parses the synonym entries into a map of lowercased term to its
+// synonyms. Each entry has the form term=synonym1|synonym2; a term listed in
+// several entries collects the synonyms of all of them.
+func (s SearchSettings) SynonymMap() (map[string][]string, error) {
+	synonyms := make(map[string][]string, len(s.Synonyms))
+	for _, entry := range s.Synonyms {
+		term, list, found := strings.Cut(entry, "=")
+		term = strings.ToLower(strings.TrimSpace(term))
+		if !found || term == "" || strings.ContainsAny(term, " \t") {
+			return nil, fmt.Errorf("search-synonyms entries must have the form term=synonym1|synonym2, got: %s", entry)
+		}
+		for _, synonym := range strings.Split(list, "|") {
+			if synonym = strings.TrimSpace(synonym); synonym != "" {
+				synonyms[term] = append(synonyms[term], synonym)
+			}
+		}
+		if len(synonyms[term]) == 0 {
+			return nil, fmt.Errorf("search-synonyms entry for %s lists no synonyms", term)
+		}
+	}
+	return synonyms, nil
 }
 
+// PromptSettings configuration for prompt discovery and rendering
+type PromptSettings struct {
+	Strict          bool   `mapstructure:"strict" yaml:"strict"`
+	MissingKeyError bool   `mapstructure:"missing_key_error" yaml:"missing_key_error"`
+	TrimOutput      bool   `mapstructure:"trim_output" yaml:"trim_output"`
+	Engine          string `mapstructure:"engine" yaml:"engine"` // PromptEngineGo, PromptEngineSimple, or PromptEngineMustache
+	DefaultLocale   string `mapstructure:"default_locale" yaml:"default_locale"`
+	// AllowedFunctions restricts the template functions prompts may call; empty allows all
+	AllowedFunctions []string `mapstructure:"allowed_functions" yaml:"allowed_functions"`
+}
+
+// Search ranking algorithm constants. The values are the names of the
+// corresponding Bleve scoring models.
+const (
+	SearchRankingTFIDF = "tf-idf"
+	SearchRankingBM25  = "bm25"
+)
+
+// Search tool output style constants
+const (
+	SearchOutputMarkdown = "markdown"
+	SearchOutputCompact  = "compact"
+)
+
+// Prompt template engine constants
+const (
+	PromptEngineGo       = "go"
+	PromptEngineSimple   = "simple"
+	PromptEngineMustache = "mustache"
+)
+
+// Trailing newline policy constants
+const (
+	TrailingNewlinePreserve = "preserve" // keep the end of the file as is
+	TrailingNewlineSingle   = "single"   // end non-empty content with exactly one newline
+	TrailingNewlineNone     = "none"     // end content without a newline
+)
+
+// Resource listing order constants
+const (
+	ListOrderDiscovery = "discovery" // the order resources were discovered in
+	ListOrderName      = "name"      // by name, case-insensitively
+	ListOrderModified  = "modified"  // most recently modified first
+)
+
+// AuditLogStdout is the audit log destination that writes to standard output
+const AuditLogStdout = "stdout"
+
 // Auth type constants
 const (
 	AuthTypeNone   = "none"
@@ -32,27 +137,82 @@ const (
 
 // AuthSettings configuration for authentication
 type AuthSettings struct {
-	Type    string            `mapstructure:"type"` // AuthTypeNone, AuthTypeBasic, or AuthTypeAPIKey
-	Basic   BasicAuthSettings `mapstructure:"basic"`
-	APIKeys []string          `mapstructure:"api_keys"`
+	Type    string            `mapstructure:"type" yaml:"type"` // AuthTypeNone, AuthTypeBasic, or AuthTypeAPIKey
+	Basic   BasicAuthSettings `mapstructure:"basic" yaml:"basic"`
+	APIKeys []string          `mapstructure:"api_keys" yaml:"api_keys"`
+	Roles   []string          `mapstructure:"roles" yaml:"roles"` // subject=role1|role2 entries
+}
+
+// RoleMap parses the role assignments into a map of subject to roles. Each
+// entry has the form subject=role1|role2, where the subject is a basic auth
+// username or an API key's position (apikey-1, apikey-2, ...).
+func (s AuthSettings) RoleMap() (map[string][]string, error) {
+	roles := make(map[string][]string, len(s.Roles))
+	for _, entry := range s.Roles {
+		subject, list, found := strings.Cut(entry, "=")
+		subject = strings.TrimSpace(subject)
+		if !found || subject == "" {
+			return nil, fmt.Errorf("auth-roles entries must have the form subject=role1|role2, got: %s", entry)
+		}
+		for _, role := range strings.Split(list, "|") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles[subject] = append(roles[subject], role)
+			}
+		}
+		if len(roles[subject]) == 0 {
+			return nil, fmt.Errorf("auth-roles entry for %s assigns no roles", subject)
+		}
+	}
+	return roles, nil
 }
 
 // BasicAuthSettings configuration for basic auth
 type BasicAuthSettings struct {
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
+	Username string `mapstructure:"username" yaml:"username"`
+	Password string `mapstructure:"password" yaml:"password"`
 }
 
 // Settings application settings
 type Settings struct {
-	ContentDir string         `mapstructure:"content_dir"`
-	Transport  string         `mapstructure:"transport"`
-	Host       string         `mapstructure:"host"`
-	Port       int            `mapstructure:"port"`
-	Scheme     string         `mapstructure:"uri_scheme"`
-	CrossRef   bool           `mapstructure:"cross_ref"`
-	Search     SearchSettings `mapstructure:"search"`
-	Auth       AuthSettings   `mapstructure:"auth"`
+	ContentDir                 string         `mapstructure:"content_dir" yaml:"content_dir"`
+	Transport                  string         `mapstructure:"transport" yaml:"transport"`
+	Host                       string         `mapstructure:"host" yaml:"host"`
+	Port                       int            `mapstructure:"port" yaml:"port"`
+	BasePath                   string         `mapstructure:"base_path" yaml:"base_path"`
+	Scheme                     string         `mapstructure:"uri_scheme" yaml:"uri_scheme"`
+	URITemplate                string         `mapstructure:"uri_template" yaml:"uri_template"`
+	FollowSymlinks             bool           `mapstructure:"follow_symlinks" yaml:"follow_symlinks"`
+	DetectEncoding             bool           `mapstructure:"detect_encoding" yaml:"detect_encoding"`
+	DeriveMetadata             bool           `mapstructure:"derive_metadata" yaml:"derive_metadata"`
+	DefaultMIMEType            string         `mapstructure:"default_mime_type" yaml:"default_mime_type"`
+	TrailingNewline            string         `mapstructure:"trailing_newline" yaml:"trailing_newline"` // TrailingNewlinePreserve, TrailingNewlineSingle, or TrailingNewlineNone
+	CrossRef                   bool           `mapstructure:"cross_ref" yaml:"cross_ref"`
+	CrossRefIndexFiles         []string       `mapstructure:"cross_ref_index_files" yaml:"cross_ref_index_files"`
+	CrossRefPreserveOriginal   bool           `mapstructure:"cross_ref_preserve_original" yaml:"cross_ref_preserve_original"`
+	ImageBaseURL               string         `mapstructure:"image_base_url" yaml:"image_base_url"`
+	ServeAssets                bool           `mapstructure:"serve_assets" yaml:"serve_assets"`
+	SelfTest                   bool           `mapstructure:"self_test" yaml:"self_test"`
+	RefreshInterval            time.Duration  `mapstructure:"refresh_interval" yaml:"refresh_interval"`
+	ToolPrefix                 string         `mapstructure:"tool_prefix" yaml:"tool_prefix"`
+	MetadataToolsOnly          bool           `mapstructure:"metadata_tools_only" yaml:"metadata_tools_only"`
+	NotFoundFallback           string         `mapstructure:"not_found_fallback" yaml:"not_found_fallback"`
+	IntegrityManifest          string         `mapstructure:"integrity_manifest" yaml:"integrity_manifest"`
+	AuditLog                   string         `mapstructure:"audit_log" yaml:"audit_log"` // AuditLogStdout or a file path; empty disables auditing
+	DeprecationBanner          bool           `mapstructure:"deprecation_banner" yaml:"deprecation_banner"`
+	DetectDuplicates           bool           `mapstructure:"detect_duplicates" yaml:"detect_duplicates"`
+	CanonicalNotice            bool           `mapstructure:"canonical_notice" yaml:"canonical_notice"`
+	ListPageSize               int            `mapstructure:"list_page_size" yaml:"list_page_size"`
+	ListOrder                  string         `mapstructure:"list_order" yaml:"list_order"` // ListOrderDiscovery, ListOrderName, or ListOrderModified
+	MaxReadBytes               int            `mapstructure:"max_read_bytes" yaml:"max_read_bytes"`
+	ExposeInstructionsResource bool           `mapstructure:"expose_instructions_resource" yaml:"expose_instructions_resource"`
+	InstructionsURI            string         `mapstructure:"instructions_uri" yaml:"instructions_uri"`
+	Compression                bool           `mapstructure:"compression" yaml:"compression"`
+	MaxConcurrentSessions      int            `mapstructure:"max_concurrent_sessions" yaml:"max_concurrent_sessions"`
+	RedactPatterns             []string       `mapstructure:"redact_patterns" yaml:"redact_patterns"`
+	IndexPrompts               bool           `mapstructure:"index_prompts" yaml:"index_prompts"`
+	Search                     SearchSettings `mapstructure:"search" yaml:"search"`
+	Prompts                    PromptSettings `mapstructure:"prompts" yaml:"prompts"`
+	Auth                       AuthSettings   `mapstructure:"auth" yaml:"auth"`
 }
 
 // LoadSettings loads settings from environment variables and optional .env file
@@ -74,12 +234,47 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 	v.SetDefault("transport", "stdio")
 	v.SetDefault("host", "0.0.0.0")
 	v.SetDefault("port", 8080)
+	v.SetDefault("base_path", "")
 	v.SetDefault("uri_scheme", "acdc")
+	v.SetDefault("uri_template", "{scheme}://{path}")
+	v.SetDefault("search.backend", "bleve")
+	v.SetDefault("search.ranking", SearchRankingTFIDF)
 	v.SetDefault("search.max_results", 10)
+	v.SetDefault("search.max_terms", 32)
 	v.SetDefault("search.keywords_boost", 3.0)
 	v.SetDefault("search.name_boost", 2.0)
 	v.SetDefault("search.content_boost", 1.0)
+	v.SetDefault("search.headings_boost", 2.0)
+	v.SetDefault("search.fields_boost", 1.0)
+	v.SetDefault("search.timeout", 0)
+	v.SetDefault("search.suggestions", false)
+	v.SetDefault("search.output_style", SearchOutputMarkdown)
+	v.SetDefault("search.low_memory", false)
+	v.SetDefault("index_prompts", false)
+	v.SetDefault("metadata_tools_only", false)
+	v.SetDefault("prompts.strict", false)
+	v.SetDefault("prompts.missing_key_error", false)
+	v.SetDefault("prompts.trim_output", false)
+	v.SetDefault("prompts.engine", PromptEngineGo)
+	v.SetDefault("prompts.default_locale", "en")
 	v.SetDefault("cross_ref", false)
+	v.SetDefault("cross_ref_index_files", []string{"index.md", "README.md"})
+	v.SetDefault("cross_ref_preserve_original", false)
+	v.SetDefault("follow_symlinks", false)
+	v.SetDefault("detect_encoding", false)
+	v.SetDefault("derive_metadata", false)
+	v.SetDefault("default_mime_type", "text/markdown")
+	v.SetDefault("trailing_newline", TrailingNewlinePreserve)
+	v.SetDefault("deprecation_banner", true)
+	v.SetDefault("detect_duplicates", false)
+	v.SetDefault("canonical_notice", false)
+	v.SetDefault("compression", false)
+	v.SetDefault("max_concurrent_sessions", 0)
+	v.SetDefault("list_page_size", 0)
+	v.SetDefault("list_order", ListOrderDiscovery)
+	v.SetDefault("max_read_bytes", 0)
+	v.SetDefault("refresh_interval", 0)
+	v.SetDefault("expose_instructions_resource", false)
 	v.SetDefault("auth.type", AuthTypeNone)
 
 	// Environment variables
@@ -90,18 +285,68 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 	// Bind specific env vars for nested config.
 	// BindEnv only returns an error if the key is empty, which cannot happen
 	// with hardcoded keys. Errors are intentionally discarded here.
+	_ = v.BindEnv("search.backend", "ACDC_MCP_SEARCH_BACKEND")
+	_ = v.BindEnv("search.ranking", "ACDC_MCP_SEARCH_RANKING")
+	_ = v.BindEnv("search.synonyms", "ACDC_MCP_SEARCH_SYNONYMS")
 	_ = v.BindEnv("search.max_results", "ACDC_MCP_SEARCH_MAX_RESULTS")
+	_ = v.BindEnv("search.max_terms", "ACDC_MCP_SEARCH_MAX_TERMS")
 	_ = v.BindEnv("search.keywords_boost", "ACDC_MCP_SEARCH_KEYWORDS_BOOST")
 	_ = v.BindEnv("search.name_boost", "ACDC_MCP_SEARCH_NAME_BOOST")
 	_ = v.BindEnv("search.content_boost", "ACDC_MCP_SEARCH_CONTENT_BOOST")
+	_ = v.BindEnv("search.headings_boost", "ACDC_MCP_SEARCH_HEADINGS_BOOST")
+	_ = v.BindEnv("search.fields", "ACDC_MCP_SEARCH_FIELDS")
+	_ = v.BindEnv("search.fields_boost", "ACDC_MCP_SEARCH_FIELDS_BOOST")
+	_ = v.BindEnv("search.index_mime_types", "ACDC_MCP_SEARCH_INDEX_MIME_TYPES")
+	_ = v.BindEnv("search.exclude_mime_types", "ACDC_MCP_SEARCH_EXCLUDE_MIME_TYPES")
+	_ = v.BindEnv("search.timeout", "ACDC_MCP_SEARCH_TIMEOUT")
+	_ = v.BindEnv("search.suggestions", "ACDC_MCP_SEARCH_SUGGESTIONS")
+	_ = v.BindEnv("search.output_style", "ACDC_MCP_SEARCH_OUTPUT_STYLE")
+	_ = v.BindEnv("search.low_memory", "ACDC_MCP_SEARCH_LOW_MEMORY")
+
+	_ = v.BindEnv("index_prompts", "ACDC_MCP_INDEX_PROMPTS")
+	_ = v.BindEnv("prompts.strict", "ACDC_MCP_PROMPTS_STRICT")
+	_ = v.BindEnv("prompts.missing_key_error", "ACDC_MCP_PROMPTS_MISSING_KEY_ERROR")
+	_ = v.BindEnv("prompts.trim_output", "ACDC_MCP_PROMPTS_TRIM_OUTPUT")
+	_ = v.BindEnv("prompts.engine", "ACDC_MCP_PROMPTS_ENGINE")
+	_ = v.BindEnv("prompts.default_locale", "ACDC_MCP_PROMPTS_DEFAULT_LOCALE")
+	_ = v.BindEnv("prompts.allowed_functions", "ACDC_MCP_PROMPTS_ALLOWED_FUNCTIONS")
 
+	_ = v.BindEnv("base_path", "ACDC_MCP_BASE_PATH")
 	_ = v.BindEnv("uri_scheme", "ACDC_MCP_URI_SCHEME")
+	_ = v.BindEnv("uri_template", "ACDC_MCP_URI_TEMPLATE")
 	_ = v.BindEnv("cross_ref", "ACDC_MCP_CROSS_REF")
+	_ = v.BindEnv("cross_ref_index_files", "ACDC_MCP_CROSS_REF_INDEX_FILES")
+	_ = v.BindEnv("cross_ref_preserve_original", "ACDC_MCP_CROSS_REF_PRESERVE_ORIGINAL")
+	_ = v.BindEnv("image_base_url", "ACDC_MCP_IMAGE_BASE_URL")
+	_ = v.BindEnv("serve_assets", "ACDC_MCP_SERVE_ASSETS")
+	_ = v.BindEnv("self_test", "ACDC_MCP_SELF_TEST")
+	_ = v.BindEnv("refresh_interval", "ACDC_MCP_REFRESH_INTERVAL")
+	_ = v.BindEnv("tool_prefix", "ACDC_MCP_TOOL_PREFIX")
+	_ = v.BindEnv("metadata_tools_only", "ACDC_MCP_METADATA_TOOLS_ONLY")
+	_ = v.BindEnv("follow_symlinks", "ACDC_MCP_FOLLOW_SYMLINKS")
+	_ = v.BindEnv("detect_encoding", "ACDC_MCP_DETECT_ENCODING")
+	_ = v.BindEnv("derive_metadata", "ACDC_MCP_DERIVE_METADATA")
+	_ = v.BindEnv("default_mime_type", "ACDC_MCP_DEFAULT_MIME_TYPE")
+	_ = v.BindEnv("trailing_newline", "ACDC_MCP_TRAILING_NEWLINE")
+	_ = v.BindEnv("not_found_fallback", "ACDC_MCP_NOT_FOUND_FALLBACK")
+	_ = v.BindEnv("integrity_manifest", "ACDC_MCP_INTEGRITY_MANIFEST")
+	_ = v.BindEnv("audit_log", "ACDC_MCP_AUDIT_LOG")
+	_ = v.BindEnv("deprecation_banner", "ACDC_MCP_DEPRECATION_BANNER")
+	_ = v.BindEnv("detect_duplicates", "ACDC_MCP_DETECT_DUPLICATES")
+	_ = v.BindEnv("canonical_notice", "ACDC_MCP_CANONICAL_NOTICE")
+	_ = v.BindEnv("compression", "ACDC_MCP_COMPRESSION")
+	_ = v.BindEnv("max_concurrent_sessions", "ACDC_MCP_MAX_CONCURRENT_SESSIONS")
+	_ = v.BindEnv("list_page_size", "ACDC_MCP_LIST_PAGE_SIZE")
+	_ = v.BindEnv("list_order", "ACDC_MCP_LIST_ORDER")
+	_ = v.BindEnv("max_read_bytes", "ACDC_MCP_MAX_READ_BYTES")
+	_ = v.BindEnv("expose_instructions_resource", "ACDC_MCP_EXPOSE_INSTRUCTIONS_RESOURCE")
+	_ = v.BindEnv("instructions_uri", "ACDC_MCP_INSTRUCTIONS_URI")
 
 	_ = v.BindEnv("auth.type", "ACDC_MCP_AUTH_TYPE")
 	_ = v.BindEnv("auth.basic.username", "ACDC_MCP_AUTH_BASIC_USERNAME")
 	_ = v.BindEnv("auth.basic.password", "ACDC_MCP_AUTH_BASIC_PASSWORD")
 	_ = v.BindEnv("auth.api_keys", "ACDC_MCP_AUTH_API_KEYS")
+	_ = v.BindEnv("auth.roles", "ACDC_MCP_AUTH_ROLES")
 
 	// Bind CLI flags if provided (highest priority)
 	if flags != nil {
@@ -109,16 +354,65 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 		_ = v.BindPFlag("transport", flags.Lookup("transport"))
 		_ = v.BindPFlag("host", flags.Lookup("host"))
 		_ = v.BindPFlag("port", flags.Lookup("port"))
+		_ = v.BindPFlag("base_path", flags.Lookup("base-path"))
 		_ = v.BindPFlag("uri_scheme", flags.Lookup("uri-scheme"))
+		_ = v.BindPFlag("uri_template", flags.Lookup("uri-template"))
 		_ = v.BindPFlag("cross_ref", flags.Lookup("cross-ref"))
+		_ = v.BindPFlag("cross_ref_index_files", flags.Lookup("cross-ref-index-files"))
+		_ = v.BindPFlag("cross_ref_preserve_original", flags.Lookup("cross-ref-preserve-original"))
+		_ = v.BindPFlag("image_base_url", flags.Lookup("image-base-url"))
+		_ = v.BindPFlag("serve_assets", flags.Lookup("serve-assets"))
+		_ = v.BindPFlag("self_test", flags.Lookup("self-test"))
+		_ = v.BindPFlag("refresh_interval", flags.Lookup("refresh-interval"))
+		_ = v.BindPFlag("tool_prefix", flags.Lookup("tool-prefix"))
+		_ = v.BindPFlag("metadata_tools_only", flags.Lookup("metadata-tools-only"))
+		_ = v.BindPFlag("follow_symlinks", flags.Lookup("follow-symlinks"))
+		_ = v.BindPFlag("detect_encoding", flags.Lookup("detect-encoding"))
+		_ = v.BindPFlag("derive_metadata", flags.Lookup("derive-metadata"))
+		_ = v.BindPFlag("default_mime_type", flags.Lookup("default-mime-type"))
+		_ = v.BindPFlag("trailing_newline", flags.Lookup("trailing-newline"))
+		_ = v.BindPFlag("not_found_fallback", flags.Lookup("not-found-fallback"))
+		_ = v.BindPFlag("integrity_manifest", flags.Lookup("integrity-manifest"))
+		_ = v.BindPFlag("audit_log", flags.Lookup("audit-log"))
+		_ = v.BindPFlag("deprecation_banner", flags.Lookup("deprecation-banner"))
+		_ = v.BindPFlag("detect_duplicates", flags.Lookup("detect-duplicates"))
+		_ = v.BindPFlag("canonical_notice", flags.Lookup("canonical-notice"))
+		_ = v.BindPFlag("compression", flags.Lookup("compression"))
+		_ = v.BindPFlag("max_concurrent_sessions", flags.Lookup("max-concurrent-sessions"))
+		_ = v.BindPFlag("list_page_size", flags.Lookup("list-page-size"))
+		_ = v.BindPFlag("list_order", flags.Lookup("list-order"))
+		_ = v.BindPFlag("max_read_bytes", flags.Lookup("max-read-bytes"))
+		_ = v.BindPFlag("expose_instructions_resource", flags.Lookup("expose-instructions-resource"))
+		_ = v.BindPFlag("instructions_uri", flags.Lookup("instructions-uri"))
+		_ = v.BindPFlag("search.backend", flags.Lookup("search-backend"))
+		_ = v.BindPFlag("search.ranking", flags.Lookup("search-ranking"))
+		_ = v.BindPFlag("search.synonyms", flags.Lookup("search-synonyms"))
 		_ = v.BindPFlag("search.max_results", flags.Lookup("search-max-results"))
+		_ = v.BindPFlag("search.max_terms", flags.Lookup("search-max-terms"))
 		_ = v.BindPFlag("search.keywords_boost", flags.Lookup("search-keywords-boost"))
 		_ = v.BindPFlag("search.name_boost", flags.Lookup("search-name-boost"))
 		_ = v.BindPFlag("search.content_boost", flags.Lookup("search-content-boost"))
+		_ = v.BindPFlag("search.headings_boost", flags.Lookup("search-headings-boost"))
+		_ = v.BindPFlag("search.fields", flags.Lookup("search-fields"))
+		_ = v.BindPFlag("search.fields_boost", flags.Lookup("search-fields-boost"))
+		_ = v.BindPFlag("search.index_mime_types", flags.Lookup("search-index-mime-types"))
+		_ = v.BindPFlag("search.exclude_mime_types", flags.Lookup("search-exclude-mime-types"))
+		_ = v.BindPFlag("search.timeout", flags.Lookup("search-timeout"))
+		_ = v.BindPFlag("search.suggestions", flags.Lookup("search-suggestions"))
+		_ = v.BindPFlag("search.output_style", flags.Lookup("search-output-style"))
+		_ = v.BindPFlag("search.low_memory", flags.Lookup("search-low-memory"))
+		_ = v.BindPFlag("index_prompts", flags.Lookup("index-prompts"))
+		_ = v.BindPFlag("prompts.strict", flags.Lookup("prompts-strict"))
+		_ = v.BindPFlag("prompts.missing_key_error", flags.Lookup("prompts-missing-key-error"))
+		_ = v.BindPFlag("prompts.trim_output", flags.Lookup("prompts-trim-output"))
+		_ = v.BindPFlag("prompts.engine", flags.Lookup("prompts-engine"))
+		_ = v.BindPFlag("prompts.default_locale", flags.Lookup("prompts-default-locale"))
+		_ = v.BindPFlag("prompts.allowed_functions", flags.Lookup("prompts-allowed-functions"))
 		_ = v.BindPFlag("auth.type", flags.Lookup("auth-type"))
 		_ = v.BindPFlag("auth.basic.username", flags.Lookup("auth-basic-username"))
 		_ = v.BindPFlag("auth.basic.password", flags.Lookup("auth-basic-password"))
 		_ = v.BindPFlag("auth.api_keys", flags.Lookup("auth-api-keys"))
+		_ = v.BindPFlag("auth.roles", flags.Lookup("auth-roles"))
 	}
 
 	// Helper to look for .env file
@@ -148,9 +442,58 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 		settings.Auth.APIKeys[i] = strings.TrimSpace(settings.Auth.APIKeys[i])
 	}
 
+	// Search fields, MIME types, synonyms, template functions, index files, and role assignments come from a comma-separated env var or slice flag; trim them the same way
+	for _, list := range [][]string{settings.Search.Fields, settings.Search.IndexMIMETypes, settings.Search.ExcludeMIMETypes, settings.Search.Synonyms, settings.Prompts.AllowedFunctions, settings.CrossRefIndexFiles, settings.Auth.Roles} {
+		for i := range list {
+			list[i] = strings.TrimSpace(list[i])
+		}
+	}
+
+	// Redaction patterns are regular expressions, which commonly contain commas
+	// (e.g. {20,}), so they are read verbatim instead of being comma-split by Viper.
+	// The env var separates patterns with newlines; the CLI flag is repeatable.
+	settings.RedactPatterns = redactPatterns(flags)
+
 	return &settings, nil
 }
 
+// redactPatterns resolves redaction patterns with CLI flags taking precedence
+// over the ACDC_MCP_REDACT_PATTERNS environment variable.
+func redactPatterns(flags *pflag.FlagSet) []string {
+	if flags != nil {
+		if f := flags.Lookup("redact-pattern"); f != nil && f.Changed {
+			patterns, _ := flags.GetStringArray("redact-pattern")
+			return patterns
+		}
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(os.Getenv("ACDC_MCP_REDACT_PATTERNS"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns
+}
+
+// validateURITemplate requires the template to start with the scheme, so that
+// links using the scheme are still recognized, and to place the resource path
+// exactly once. {scheme} and {path} are the only supported placeholders.
+func validateURITemplate(template string) error {
+	if !strings.HasPrefix(template, "{scheme}://") {
+		return errors.New("uri-template must start with '{scheme}://', got: " + template)
+	}
+	if strings.Count(template, "{path}") != 1 {
+		return errors.New("uri-template must contain '{path}' exactly once, got: " + template)
+	}
+	for _, p := range uriTemplatePlaceholderRegexp.FindAllString(template, -1) {
+		if p != "{scheme}" && p != "{path}" {
+			return fmt.Errorf("uri-template placeholder %s is not supported, got: %s", p, template)
+		}
+	}
+	return nil
+}
+
 // ValidateSettings checks for conflicting configurations.
 // Returns an error if the settings contain mutually exclusive or incomplete auth config.
 func ValidateSettings(s *Settings) error {
@@ -162,19 +505,146 @@ func ValidateSettings(s *Settings) error {
 		return errors.New("transport must be 'stdio' or 'sse', got: " + s.Transport)
 	}
 
+	// Standard output carries the protocol messages of the stdio transport
+	if s.AuditLog == AuditLogStdout && s.Transport == "stdio" {
+		return errors.New("audit-log cannot be stdout with the stdio transport; use a file path")
+	}
+
 	// Validate URI scheme (RFC 3986: ALPHA *( ALPHA / DIGIT / "+" / "-" / "." ))
 	if !schemeRegexp.MatchString(s.Scheme) {
 		return errors.New("scheme must match RFC 3986 (start with a letter, contain only letters, digits, +, -, .), got: " + s.Scheme)
 	}
 
+	if s.BasePath != "" && !basePathRegexp.MatchString(s.BasePath) {
+		return errors.New("base-path must be an absolute URL path such as /mcp/docs, got: " + s.BasePath)
+	}
+
+	if s.URITemplate != "" {
+		if err := validateURITemplate(s.URITemplate); err != nil {
+			return err
+		}
+	}
+
+	if s.Search.MaxTerms < 0 {
+		return fmt.Errorf("search-max-terms must not be negative, got: %d", s.Search.MaxTerms)
+	}
+
+	if s.Search.Timeout < 0 {
+		return fmt.Errorf("search-timeout must not be negative, got: %s", s.Search.Timeout)
+	}
+
+	if _, err := s.Search.SynonymMap(); err != nil {
+		return err
+	}
+
+	switch s.Search.OutputStyle {
+	case SearchOutputMarkdown, SearchOutputCompact, "":
+		// valid
+	default:
+		return errors.New("search-output-style must be 'markdown' or 'compact', got: " + s.Search.OutputStyle)
+	}
+
+	switch s.Search.Ranking {
+	case SearchRankingTFIDF, SearchRankingBM25, "":
+		// valid
+	default:
+		return errors.New("search-ranking must be 'tf-idf' or 'bm25', got: " + s.Search.Ranking)
+	}
+
+	for _, f := range s.Search.Fields {
+		if !searchFieldRegexp.MatchString(f) {
+			return errors.New("search-fields entries must start with a letter and contain only letters, digits, '_' and '-', got: " + f)
+		}
+	}
+
+	if s.DefaultMIMEType != "" && !mimeTypeRegexp.MatchString(s.DefaultMIMEType) {
+		return errors.New("default-mime-type must have the form type/subtype, got: " + s.DefaultMIMEType)
+	}
+
+	switch s.TrailingNewline {
+	case TrailingNewlinePreserve, TrailingNewlineSingle, TrailingNewlineNone, "":
+		// valid
+	default:
+		return errors.New("trailing-newline must be 'preserve', 'single', or 'none', got: " + s.TrailingNewline)
+	}
+
+	for _, m := range append(append([]string{}, s.Search.IndexMIMETypes...), s.Search.ExcludeMIMETypes...) {
+		if !mimeTypeRegexp.MatchString(m) {
+			return errors.New("search MIME type entries must have the form type/subtype, got: " + m)
+		}
+	}
+
+	for _, name := range s.CrossRefIndexFiles {
+		if name == "" || name != filepath.Base(name) || filepath.Ext(name) != ".md" {
+			return errors.New("cross-ref-index-files entries must be markdown file names without a directory, got: " + name)
+		}
+	}
+
+	switch s.Prompts.Engine {
+	case PromptEngineGo, PromptEngineSimple, PromptEngineMustache, "":
+		// valid
+	default:
+		return errors.New("prompts-engine must be 'go', 'simple', or 'mustache', got: " + s.Prompts.Engine)
+	}
+
+	if s.Prompts.DefaultLocale != "" && !localeRegexp.MatchString(s.Prompts.DefaultLocale) {
+		return errors.New("prompts-default-locale must be a locale tag such as 'en' or 'de-AT', got: " + s.Prompts.DefaultLocale)
+	}
+
+	if s.ImageBaseURL != "" {
+		if u, err := url.Parse(s.ImageBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("image-base-url must be an absolute URL, got: " + s.ImageBaseURL)
+		}
+	}
+
+	if !toolPrefixRegexp.MatchString(s.ToolPrefix) {
+		return errors.New("tool-prefix may only contain letters, digits, '_', '-', and '.', got: " + s.ToolPrefix)
+	}
+
+	if s.ListPageSize < 0 {
+		return fmt.Errorf("list-page-size must not be negative, got: %d", s.ListPageSize)
+	}
+
+	switch s.ListOrder {
+	case ListOrderDiscovery, ListOrderName, ListOrderModified, "":
+		// valid
+	default:
+		return errors.New("list-order must be 'discovery', 'name', or 'modified', got: " + s.ListOrder)
+	}
+
+	if s.MaxReadBytes < 0 {
+		return fmt.Errorf("max-read-bytes must not be negative, got: %d", s.MaxReadBytes)
+	}
+
+	if s.RefreshInterval < 0 {
+		return fmt.Errorf("refresh-interval must not be negative, got: %s", s.RefreshInterval)
+	}
+
+	if s.MaxConcurrentSessions < 0 {
+		return fmt.Errorf("max-concurrent-sessions must not be negative, got: %d", s.MaxConcurrentSessions)
+	}
+
+	for _, p := range s.RedactPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+	}
+
 	hasBasicCreds := s.Auth.Basic.Username != "" || s.Auth.Basic.Password != ""
 	hasAPIKeys := len(s.Auth.APIKeys) > 0
 
+	if _, err := s.Auth.RoleMap(); err != nil {
+		return err
+	}
+
 	switch s.Auth.Type {
 	case AuthTypeNone, "":
 		if hasBasicCreds || hasAPIKeys {
 			return errors.New("auth-type 'none' is incompatible with auth credentials")
 		}
+		if len(s.Auth.Roles) > 0 {
+			return errors.New("auth-type 'none' is incompatible with auth-roles")
+		}
 	case AuthTypeBasic:
 		if hasAPIKeys {
 			return errors.New("auth-type 'basic' is mutually exclusive with auth-api-keys")
@@ -2,8 +2,10 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -41,6 +43,9 @@ func TestLoadSettings_Defaults(t *testing.T) {
 	if settings.CrossRef != false {
 		t.Errorf("Expected default cross_ref false, got %v", settings.CrossRef)
 	}
+	if settings.Compression != false {
+		t.Errorf("Expected default compression false, got %v", settings.Compression)
+	}
 }
 
 func TestLoadSettings_EnvVars(t *testing.T) {
@@ -559,83 +564,1485 @@ func TestLoadSettingsWithFlags_CrossRefCLIOverridesEnv(t *testing.T) {
 	}
 }
 
-// --- Scheme Tests ---
+// --- Compression Tests ---
 
-func TestLoadSettings_SchemeEnvVar(t *testing.T) {
-	t.Setenv("ACDC_MCP_URI_SCHEME", "custom")
+func TestLoadSettings_CompressionEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_COMPRESSION", "true")
 
 	settings, err := LoadSettings()
 	if err != nil {
 		t.Fatalf("Failed to load settings: %v", err)
 	}
 
-	if settings.Scheme != "custom" {
-		t.Errorf("Expected scheme 'custom', got '%s'", settings.Scheme)
+	if !settings.Compression {
+		t.Errorf("Expected compression true, got %v", settings.Compression)
 	}
 }
 
-func TestLoadSettingsWithFlags_SchemeCLIOverridesEnv(t *testing.T) {
-	t.Setenv("ACDC_MCP_URI_SCHEME", "from-env")
+func TestLoadSettingsWithFlags_CompressionCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_COMPRESSION", "false")
 
 	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
-	flags.String("uri-scheme", "", "")
-	_ = flags.Set("uri-scheme", "from-cli")
+	flags.Bool("compression", false, "")
+	_ = flags.Set("compression", "true")
 
 	settings, err := LoadSettingsWithFlags(flags)
 	if err != nil {
 		t.Fatalf("Failed to load settings: %v", err)
 	}
 
-	if settings.Scheme != "from-cli" {
-		t.Errorf("Expected scheme 'from-cli', got '%s'", settings.Scheme)
+	if !settings.Compression {
+		t.Errorf("Expected compression true from CLI flag, got %v", settings.Compression)
 	}
 }
 
-func TestValidateSettings_ValidSchemes(t *testing.T) {
-	tests := []struct {
-		name   string
-		scheme string
-	}{
-		{"default acdc", "acdc"},
-		{"with hyphen", "my-scheme"},
-		{"with dot and plus", "x.y+z"},
-		{"uppercase start", "A1"},
-		{"single letter", "x"},
+// --- Prompts Tests ---
+
+func TestLoadSettings_PromptsStrictEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_PROMPTS_STRICT", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &Settings{Transport: "stdio", Scheme: tt.scheme, Auth: AuthSettings{Type: AuthTypeNone}}
-			if err := ValidateSettings(s); err != nil {
-				t.Errorf("Expected no error for scheme %q, got: %v", tt.scheme, err)
-			}
-		})
+	if !settings.Prompts.Strict {
+		t.Errorf("Expected prompts.strict true, got %v", settings.Prompts.Strict)
 	}
 }
 
-func TestValidateSettings_InvalidSchemes(t *testing.T) {
-	tests := []struct {
-		name   string
-		scheme string
-	}{
-		{"empty", ""},
-		{"starts with digit", "123"},
-		{"contains space", "a b"},
-		{"colon-slash-slash", "://"},
-		{"contains colon-slash", "a://b"},
-		{"contains bang", "a!b"},
+func TestLoadSettingsWithFlags_PromptsStrictCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_PROMPTS_STRICT", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("prompts-strict", false, "")
+	_ = flags.Set("prompts-strict", "true")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &Settings{Transport: "stdio", Scheme: tt.scheme, Auth: AuthSettings{Type: AuthTypeNone}}
-			err := ValidateSettings(s)
-			if err == nil {
-				t.Fatalf("Expected error for scheme %q", tt.scheme)
-			}
-			if !strings.Contains(err.Error(), "scheme must match") {
-				t.Errorf("Expected 'scheme must match' in error, got: %v", err)
-			}
-		})
+	if !settings.Prompts.Strict {
+		t.Errorf("Expected prompts.strict true from CLI flag, got %v", settings.Prompts.Strict)
+	}
+}
+
+func TestLoadSettings_PromptsMissingKeyErrorEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_PROMPTS_MISSING_KEY_ERROR", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.Prompts.MissingKeyError {
+		t.Errorf("Expected prompts.missing_key_error true, got %v", settings.Prompts.MissingKeyError)
+	}
+}
+
+func TestLoadSettings_IndexPromptsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_INDEX_PROMPTS", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.IndexPrompts {
+		t.Errorf("Expected index_prompts true, got %v", settings.IndexPrompts)
+	}
+}
+
+func TestLoadSettingsWithFlags_IndexPromptsCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_INDEX_PROMPTS", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("index-prompts", false, "")
+	_ = flags.Parse([]string{"--index-prompts"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.IndexPrompts {
+		t.Errorf("Expected index_prompts true from CLI flag, got %v", settings.IndexPrompts)
+	}
+}
+
+// --- Encoding Detection Tests ---
+
+func TestLoadSettings_DetectEncodingEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_DETECT_ENCODING", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.DetectEncoding {
+		t.Errorf("Expected detect_encoding true, got %v", settings.DetectEncoding)
+	}
+}
+
+func TestLoadSettingsWithFlags_DetectEncodingCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_DETECT_ENCODING", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("detect-encoding", false, "")
+	_ = flags.Parse([]string{"--detect-encoding"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.DetectEncoding {
+		t.Errorf("Expected detect_encoding true from CLI flag, got %v", settings.DetectEncoding)
+	}
+}
+
+// --- Tool Prefix Tests ---
+
+func TestLoadSettings_ToolPrefixEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_TOOL_PREFIX", "docs")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.ToolPrefix != "docs" {
+		t.Errorf("Expected tool prefix 'docs', got '%s'", settings.ToolPrefix)
+	}
+}
+
+func TestLoadSettingsWithFlags_ToolPrefixCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_TOOL_PREFIX", "docs")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("tool-prefix", "", "")
+	_ = flags.Parse([]string{"--tool-prefix", "kb"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	if settings.ToolPrefix != "kb" {
+		t.Errorf("Expected tool prefix 'kb' (CLI override), got '%s'", settings.ToolPrefix)
+	}
+}
+
+func TestValidateSettings_ToolPrefix(t *testing.T) {
+	for _, prefix := range []string{"", "docs", "team-a.kb_1"} {
+		s := &Settings{Transport: "stdio", Scheme: "acdc", ToolPrefix: prefix}
+		if err := ValidateSettings(s); err != nil {
+			t.Errorf("Expected no error for tool prefix %q, got: %v", prefix, err)
+		}
+	}
+
+	for _, prefix := range []string{"my docs", "docs/kb", "docs:"} {
+		s := &Settings{Transport: "stdio", Scheme: "acdc", ToolPrefix: prefix}
+		if err := ValidateSettings(s); err == nil {
+			t.Errorf("Expected error for tool prefix %q", prefix)
+		}
+	}
+}
+
+// --- Follow Symlinks Tests ---
+
+func TestLoadSettings_FollowSymlinksEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_FOLLOW_SYMLINKS", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.FollowSymlinks {
+		t.Errorf("Expected follow_symlinks true, got %v", settings.FollowSymlinks)
+	}
+}
+
+func TestLoadSettingsWithFlags_FollowSymlinksCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_FOLLOW_SYMLINKS", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("follow-symlinks", false, "")
+	_ = flags.Parse([]string{"--follow-symlinks"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.FollowSymlinks {
+		t.Errorf("Expected follow_symlinks true from CLI flag, got %v", settings.FollowSymlinks)
+	}
+}
+
+// --- Not Found Fallback Tests ---
+
+func TestLoadSettings_NotFoundFallbackEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_NOT_FOUND_FALLBACK", "acdc://index")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.NotFoundFallback != "acdc://index" {
+		t.Errorf("Expected not found fallback 'acdc://index', got '%s'", settings.NotFoundFallback)
+	}
+}
+
+func TestLoadSettingsWithFlags_NotFoundFallbackCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_NOT_FOUND_FALLBACK", "acdc://index")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("not-found-fallback", "", "")
+	_ = flags.Parse([]string{"--not-found-fallback", "acdc://help"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	if settings.NotFoundFallback != "acdc://help" {
+		t.Errorf("Expected not found fallback 'acdc://help' (CLI override), got '%s'", settings.NotFoundFallback)
+	}
+}
+
+// --- List Page Size Tests ---
+
+func TestLoadSettings_ListPageSizeEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_LIST_PAGE_SIZE", "200")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.ListPageSize != 200 {
+		t.Errorf("Expected list page size 200, got %d", settings.ListPageSize)
+	}
+}
+
+func TestLoadSettingsWithFlags_ListPageSizeCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_LIST_PAGE_SIZE", "200")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Int("list-page-size", 0, "")
+	_ = flags.Parse([]string{"--list-page-size", "50"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	if settings.ListPageSize != 50 {
+		t.Errorf("Expected list page size 50 (CLI override), got %d", settings.ListPageSize)
+	}
+}
+
+func TestValidateSettings_NegativeListPageSize(t *testing.T) {
+	s := &Settings{Transport: "sse", Scheme: "acdc", ListPageSize: -1}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for negative list page size")
+	}
+}
+
+// --- Session Limit Tests ---
+
+func TestLoadSettings_MaxConcurrentSessionsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_MAX_CONCURRENT_SESSIONS", "25")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.MaxConcurrentSessions != 25 {
+		t.Errorf("Expected max concurrent sessions 25, got %d", settings.MaxConcurrentSessions)
+	}
+}
+
+func TestLoadSettingsWithFlags_MaxConcurrentSessionsCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_MAX_CONCURRENT_SESSIONS", "25")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Int("max-concurrent-sessions", 0, "")
+	_ = flags.Parse([]string{"--max-concurrent-sessions", "3"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	if settings.MaxConcurrentSessions != 3 {
+		t.Errorf("Expected max concurrent sessions 3 (CLI override), got %d", settings.MaxConcurrentSessions)
+	}
+}
+
+func TestValidateSettings_NegativeMaxConcurrentSessions(t *testing.T) {
+	s := &Settings{Transport: "sse", Scheme: "acdc", MaxConcurrentSessions: -1}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for negative max concurrent sessions")
+	}
+}
+
+// --- Search Backend Tests ---
+
+func TestLoadSettings_SearchBackendDefault(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.Search.Backend != "bleve" {
+		t.Errorf("Expected default search backend 'bleve', got '%s'", settings.Search.Backend)
+	}
+}
+
+func TestLoadSettingsWithFlags_SearchBackendCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_BACKEND", "remote")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Search.Backend != "remote" {
+		t.Errorf("Expected search backend 'remote' from env, got '%s'", settings.Search.Backend)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("search-backend", "", "")
+	_ = flags.Parse([]string{"--search-backend", "custom"})
+
+	settings, err = LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+	if settings.Search.Backend != "custom" {
+		t.Errorf("Expected search backend 'custom' (CLI override), got '%s'", settings.Search.Backend)
+	}
+}
+
+// --- Search Fields Tests ---
+
+func TestLoadSettings_SearchFieldsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_FIELDS", "category, audience")
+	t.Setenv("ACDC_MCP_SEARCH_FIELDS_BOOST", "1.5")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(settings.Search.Fields, []string{"category", "audience"}) {
+		t.Errorf("Expected search fields [category audience], got %v", settings.Search.Fields)
+	}
+	if settings.Search.FieldsBoost != 1.5 {
+		t.Errorf("Expected fields boost 1.5, got %v", settings.Search.FieldsBoost)
+	}
+}
+
+func TestLoadSettingsWithFlags_SearchFieldsCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_FIELDS", "category")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringSlice("search-fields", nil, "")
+	_ = flags.Parse([]string{"--search-fields", "audience,team"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(settings.Search.Fields, []string{"audience", "team"}) {
+		t.Errorf("Expected search fields [audience team] (CLI override), got %v", settings.Search.Fields)
+	}
+	if settings.Search.FieldsBoost != 1.0 {
+		t.Errorf("Expected default fields boost 1.0, got %v", settings.Search.FieldsBoost)
+	}
+}
+
+func TestValidateSettings_InvalidSearchField(t *testing.T) {
+	s := &Settings{Transport: "sse", Scheme: "acdc", Search: SearchSettings{Fields: []string{"category", "bad.field"}}}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for invalid search field name")
+	}
+}
+
+// --- Search Timeout Tests ---
+
+func TestLoadSettings_SearchTimeoutEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_TIMEOUT", "5s")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.Search.Timeout != 5*time.Second {
+		t.Errorf("Expected search timeout 5s, got %s", settings.Search.Timeout)
+	}
+}
+
+func TestLoadSettingsWithFlags_SearchTimeoutCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_TIMEOUT", "5s")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Duration("search-timeout", 0, "")
+	_ = flags.Parse([]string{"--search-timeout", "250ms"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	if settings.Search.Timeout != 250*time.Millisecond {
+		t.Errorf("Expected search timeout 250ms (CLI override), got %s", settings.Search.Timeout)
+	}
+}
+
+func TestValidateSettings_NegativeSearchTimeout(t *testing.T) {
+	s := &Settings{Transport: "sse", Scheme: "acdc", Search: SearchSettings{Timeout: -time.Second}}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for negative search timeout")
+	}
+}
+
+// --- Search Suggestions Tests ---
+
+func TestLoadSettings_SearchSuggestionsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_SUGGESTIONS", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if !settings.Search.Suggestions {
+		t.Error("Expected search suggestions to be enabled")
+	}
+}
+
+func TestLoadSettingsWithFlags_SearchSuggestionsCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_SUGGESTIONS", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("search-suggestions", false, "")
+	_ = flags.Parse([]string{"--search-suggestions"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	if !settings.Search.Suggestions {
+		t.Error("Expected search suggestions to be enabled (CLI override)")
+	}
+}
+
+// --- Redaction Tests ---
+
+func TestLoadSettings_RedactPatternsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_REDACT_PATTERNS", "sk-[A-Za-z0-9]{20,}\n\n  token:\\s*\\S+  ")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	want := []string{`sk-[A-Za-z0-9]{20,}`, `token:\s*\S+`}
+	if !reflect.DeepEqual(settings.RedactPatterns, want) {
+		t.Errorf("Expected redact patterns %q, got %q", want, settings.RedactPatterns)
+	}
+}
+
+func TestLoadSettingsWithFlags_RedactPatternsCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_REDACT_PATTERNS", "from-env")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringArray("redact-pattern", nil, "")
+	_ = flags.Parse([]string{"--redact-pattern", "a{2,3}", "--redact-pattern", "b+"})
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+
+	want := []string{"a{2,3}", "b+"}
+	if !reflect.DeepEqual(settings.RedactPatterns, want) {
+		t.Errorf("Expected redact patterns %q, got %q", want, settings.RedactPatterns)
+	}
+}
+
+func TestValidateSettings_InvalidRedactPattern(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", RedactPatterns: []string{"[unclosed"}}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for invalid redaction pattern")
+	}
+}
+
+// --- Scheme Tests ---
+
+func TestLoadSettings_SchemeEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_URI_SCHEME", "custom")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if settings.Scheme != "custom" {
+		t.Errorf("Expected scheme 'custom', got '%s'", settings.Scheme)
+	}
+}
+
+func TestLoadSettingsWithFlags_SchemeCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_URI_SCHEME", "from-env")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("uri-scheme", "", "")
+	_ = flags.Set("uri-scheme", "from-cli")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if settings.Scheme != "from-cli" {
+		t.Errorf("Expected scheme 'from-cli', got '%s'", settings.Scheme)
+	}
+}
+
+func TestValidateSettings_ValidSchemes(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme string
+	}{
+		{"default acdc", "acdc"},
+		{"with hyphen", "my-scheme"},
+		{"with dot and plus", "x.y+z"},
+		{"uppercase start", "A1"},
+		{"single letter", "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Settings{Transport: "stdio", Scheme: tt.scheme, Auth: AuthSettings{Type: AuthTypeNone}}
+			if err := ValidateSettings(s); err != nil {
+				t.Errorf("Expected no error for scheme %q, got: %v", tt.scheme, err)
+			}
+		})
+	}
+}
+
+func TestValidateSettings_InvalidSchemes(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme string
+	}{
+		{"empty", ""},
+		{"starts with digit", "123"},
+		{"contains space", "a b"},
+		{"colon-slash-slash", "://"},
+		{"contains colon-slash", "a://b"},
+		{"contains bang", "a!b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Settings{Transport: "stdio", Scheme: tt.scheme, Auth: AuthSettings{Type: AuthTypeNone}}
+			err := ValidateSettings(s)
+			if err == nil {
+				t.Fatalf("Expected error for scheme %q", tt.scheme)
+			}
+			if !strings.Contains(err.Error(), "scheme must match") {
+				t.Errorf("Expected 'scheme must match' in error, got: %v", err)
+			}
+		})
+	}
+}
+
+// --- URI Template Tests ---
+
+func TestLoadSettings_URITemplateDefault(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if settings.URITemplate != "{scheme}://{path}" {
+		t.Errorf("Expected default URI template '{scheme}://{path}', got '%s'", settings.URITemplate)
+	}
+}
+
+func TestLoadSettingsWithFlags_URITemplateCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_URI_TEMPLATE", "{scheme}://env/{path}")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("uri-template", "", "")
+	_ = flags.Set("uri-template", "{scheme}://cli/{path}")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if settings.URITemplate != "{scheme}://cli/{path}" {
+		t.Errorf("Expected URI template '{scheme}://cli/{path}', got '%s'", settings.URITemplate)
+	}
+}
+
+func TestValidateSettings_URITemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{"source-less", "{scheme}://{path}", ""},
+		{"with prefix", "{scheme}://handbook/{path}", ""},
+		{"missing scheme", "acdc://{path}", "must start with '{scheme}://'"},
+		{"missing path", "{scheme}://docs", "must contain '{path}' exactly once"},
+		{"repeated path", "{scheme}://{path}/{path}", "must contain '{path}' exactly once"},
+		{"unsupported source", "{scheme}://{source}/{path}", "placeholder {source} is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Settings{Transport: "stdio", Scheme: "acdc", URITemplate: tt.template}
+			err := ValidateSettings(s)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error for template %q, got: %v", tt.template, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// --- Instructions Resource Tests ---
+
+func TestLoadSettings_InstructionsResourceEnvVars(t *testing.T) {
+	t.Setenv("ACDC_MCP_EXPOSE_INSTRUCTIONS_RESOURCE", "true")
+	t.Setenv("ACDC_MCP_INSTRUCTIONS_URI", "acdc://about")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.ExposeInstructionsResource {
+		t.Error("Expected instructions resource to be exposed")
+	}
+	if settings.InstructionsURI != "acdc://about" {
+		t.Errorf("Expected instructions URI 'acdc://about', got '%s'", settings.InstructionsURI)
+	}
+}
+
+func TestLoadSettingsWithFlags_InstructionsResourceCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_EXPOSE_INSTRUCTIONS_RESOURCE", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("expose-instructions-resource", false, "")
+	_ = flags.Set("expose-instructions-resource", "true")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.ExposeInstructionsResource {
+		t.Error("Expected CLI flag to expose the instructions resource")
+	}
+}
+
+// --- Index MIME Types Tests ---
+
+func TestLoadSettings_IndexMIMETypesEnvVars(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_INDEX_MIME_TYPES", "text/markdown, text/plain")
+	t.Setenv("ACDC_MCP_SEARCH_EXCLUDE_MIME_TYPES", "application/json")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !reflect.DeepEqual(settings.Search.IndexMIMETypes, []string{"text/markdown", "text/plain"}) {
+		t.Errorf("Expected trimmed index MIME types, got %v", settings.Search.IndexMIMETypes)
+	}
+	if !reflect.DeepEqual(settings.Search.ExcludeMIMETypes, []string{"application/json"}) {
+		t.Errorf("Expected exclude MIME types [application/json], got %v", settings.Search.ExcludeMIMETypes)
+	}
+}
+
+func TestLoadSettingsWithFlags_ExcludeMIMETypesCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_EXCLUDE_MIME_TYPES", "application/json")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringSlice("search-exclude-mime-types", nil, "")
+	_ = flags.Set("search-exclude-mime-types", "application/yaml")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !reflect.DeepEqual(settings.Search.ExcludeMIMETypes, []string{"application/yaml"}) {
+		t.Errorf("Expected exclude MIME types [application/yaml], got %v", settings.Search.ExcludeMIMETypes)
+	}
+}
+
+func TestValidateSettings_InvalidMIMEType(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", Search: SearchSettings{ExcludeMIMETypes: []string{"application/json", "json"}}}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "must have the form type/subtype, got: json") {
+		t.Errorf("Expected MIME type validation error, got %v", err)
+	}
+}
+
+// --- Cross-Ref Index Files Tests ---
+
+func TestLoadSettings_CrossRefIndexFilesDefault(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !reflect.DeepEqual(settings.CrossRefIndexFiles, []string{"index.md", "README.md"}) {
+		t.Errorf("Expected default index files [index.md README.md], got %v", settings.CrossRefIndexFiles)
+	}
+}
+
+func TestLoadSettingsWithFlags_CrossRefIndexFilesCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_CROSS_REF_INDEX_FILES", "home.md")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringSlice("cross-ref-index-files", nil, "")
+	_ = flags.Set("cross-ref-index-files", "overview.md, index.md")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !reflect.DeepEqual(settings.CrossRefIndexFiles, []string{"overview.md", "index.md"}) {
+		t.Errorf("Expected index files [overview.md index.md], got %v", settings.CrossRefIndexFiles)
+	}
+}
+
+func TestValidateSettings_InvalidCrossRefIndexFiles(t *testing.T) {
+	for _, name := range []string{"", "docs/index.md", "index.txt"} {
+		s := &Settings{Transport: "stdio", Scheme: "acdc", CrossRefIndexFiles: []string{name}}
+		err := ValidateSettings(s)
+		if err == nil || !strings.Contains(err.Error(), "cross-ref-index-files entries must be markdown file names") {
+			t.Errorf("Expected index file validation error for %q, got %v", name, err)
+		}
+	}
+}
+
+func TestLoadSettingsWithFlags_CrossRefPreserveOriginalCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_CROSS_REF_PRESERVE_ORIGINAL", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("cross-ref-preserve-original", false, "")
+	_ = flags.Set("cross-ref-preserve-original", "true")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.CrossRefPreserveOriginal {
+		t.Error("Expected CLI flag to enable preserving original links")
+	}
+}
+
+// --- Deprecation Banner Tests ---
+
+func TestLoadSettings_DeprecationBannerDefault(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.DeprecationBanner {
+		t.Error("Expected deprecation banner to be enabled by default")
+	}
+}
+
+func TestLoadSettingsWithFlags_DeprecationBannerCLIOverridesEnv(t *testing.T) {
+	t.Setenv("ACDC_MCP_DEPRECATION_BANNER", "true")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("deprecation-banner", true, "")
+	_ = flags.Set("deprecation-banner", "false")
+
+	settings, err := LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if settings.DeprecationBanner {
+		t.Error("Expected CLI flag to disable the deprecation banner")
+	}
+}
+
+// --- Search Max Terms Tests ---
+
+func TestLoadSettings_SearchMaxTermsDefault(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.Search.MaxTerms != 32 {
+		t.Errorf("Expected default search max terms 32, got %d", settings.Search.MaxTerms)
+	}
+}
+
+func TestLoadSettings_SearchMaxTermsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_MAX_TERMS", "8")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.Search.MaxTerms != 8 {
+		t.Errorf("Expected search max terms 8, got %d", settings.Search.MaxTerms)
+	}
+}
+
+func TestValidateSettings_NegativeSearchMaxTerms(t *testing.T) {
+	s := &Settings{Transport: "sse", Scheme: "acdc", Search: SearchSettings{MaxTerms: -1}}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for negative search max terms")
+	}
+}
+
+// --- Integrity Manifest Tests ---
+
+func TestLoadSettings_IntegrityManifestEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_INTEGRITY_MANIFEST", "/etc/acdc/SHA256SUMS")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.IntegrityManifest != "/etc/acdc/SHA256SUMS" {
+		t.Errorf("Expected integrity manifest /etc/acdc/SHA256SUMS, got %q", settings.IntegrityManifest)
+	}
+}
+
+// --- Prompt Engine Tests ---
+
+func TestLoadSettings_PromptsEngine(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Prompts.Engine != PromptEngineGo {
+		t.Errorf("Expected default prompts engine %q, got %q", PromptEngineGo, settings.Prompts.Engine)
+	}
+
+	t.Setenv("ACDC_MCP_PROMPTS_ENGINE", "mustache")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Prompts.Engine != PromptEngineMustache {
+		t.Errorf("Expected prompts engine %q, got %q", PromptEngineMustache, settings.Prompts.Engine)
+	}
+}
+
+func TestValidateSettings_InvalidPromptsEngine(t *testing.T) {
+	s := &Settings{Transport: "sse", Scheme: "acdc", Prompts: PromptSettings{Engine: "jinja"}}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for invalid prompts engine")
+	}
+}
+
+// --- Image Base URL Tests ---
+
+func TestLoadSettings_ImageBaseURLEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_IMAGE_BASE_URL", "https://cdn.example.com/docs")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.ImageBaseURL != "https://cdn.example.com/docs" {
+		t.Errorf("Expected image base URL https://cdn.example.com/docs, got %q", settings.ImageBaseURL)
+	}
+}
+
+func TestValidateSettings_ImageBaseURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://cdn.example.com/docs", false},
+		{"http://localhost:8080", false},
+		{"/static", true},
+		{"cdn.example.com", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			s := &Settings{Transport: "sse", Scheme: "acdc", ImageBaseURL: tt.url}
+			if err := ValidateSettings(s); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSettings(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// --- Serve Assets Tests ---
+
+func TestLoadSettings_ServeAssetsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SERVE_ASSETS", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if !settings.ServeAssets {
+		t.Error("Expected serve assets to be enabled")
+	}
+}
+
+// --- Self-Test Tests ---
+
+func TestLoadSettings_SelfTestEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SELF_TEST", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if !settings.SelfTest {
+		t.Error("Expected self-test to be enabled")
+	}
+}
+
+// --- Auth Roles Tests ---
+
+func TestLoadSettings_AuthRolesEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_AUTH_ROLES", "alice=internal|ops, apikey-1=partners")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	expected := []string{"alice=internal|ops", "apikey-1=partners"}
+	if !reflect.DeepEqual(settings.Auth.Roles, expected) {
+		t.Errorf("Expected auth roles %v, got %v", expected, settings.Auth.Roles)
+	}
+}
+
+func TestAuthSettings_RoleMap(t *testing.T) {
+	roles, err := AuthSettings{Roles: []string{"alice=internal| ops", "bob=partners"}}.RoleMap()
+	if err != nil {
+		t.Fatalf("RoleMap failed: %v", err)
+	}
+
+	expected := map[string][]string{"alice": {"internal", "ops"}, "bob": {"partners"}}
+	if !reflect.DeepEqual(roles, expected) {
+		t.Errorf("Expected role map %v, got %v", expected, roles)
+	}
+}
+
+func TestValidateSettings_AuthRoles(t *testing.T) {
+	basic := BasicAuthSettings{Username: "alice", Password: "secret"}
+	tests := []struct {
+		name    string
+		auth    AuthSettings
+		wantErr bool
+	}{
+		{name: "Valid", auth: AuthSettings{Type: AuthTypeBasic, Basic: basic, Roles: []string{"alice=internal"}}, wantErr: false},
+		{name: "Missing Separator", auth: AuthSettings{Type: AuthTypeBasic, Basic: basic, Roles: []string{"alice"}}, wantErr: true},
+		{name: "No Roles", auth: AuthSettings{Type: AuthTypeBasic, Basic: basic, Roles: []string{"alice= | "}}, wantErr: true},
+		{name: "Auth None", auth: AuthSettings{Type: AuthTypeNone, Roles: []string{"alice=internal"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Settings{Transport: "sse", Scheme: "acdc", Auth: tt.auth}
+			if err := ValidateSettings(s); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// --- Refresh Interval Tests ---
+
+func TestLoadSettings_RefreshIntervalEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_REFRESH_INTERVAL", "30s")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if settings.RefreshInterval != 30*time.Second {
+		t.Errorf("Expected refresh interval 30s, got %s", settings.RefreshInterval)
+	}
+}
+
+func TestValidateSettings_NegativeRefreshInterval(t *testing.T) {
+	s := &Settings{Transport: "sse", Scheme: "acdc", RefreshInterval: -time.Second}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for negative refresh interval")
+	}
+}
+
+// --- Search Low Memory Tests ---
+
+func TestLoadSettings_SearchLowMemoryEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_LOW_MEMORY", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if !settings.Search.LowMemory {
+		t.Error("Expected search low memory mode to be enabled")
+	}
+}
+
+// --- Derive Metadata Tests ---
+
+func TestLoadSettings_DeriveMetadataEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_DERIVE_METADATA", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+
+	if !settings.DeriveMetadata {
+		t.Error("Expected derive metadata to be enabled")
+	}
+}
+
+// --- Default MIME Type Tests ---
+
+func TestLoadSettings_DefaultMIMEType(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.DefaultMIMEType != "text/markdown" {
+		t.Errorf("Expected default MIME type text/markdown, got %q", settings.DefaultMIMEType)
+	}
+
+	t.Setenv("ACDC_MCP_DEFAULT_MIME_TYPE", "text/plain")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.DefaultMIMEType != "text/plain" {
+		t.Errorf("Expected default MIME type text/plain, got %q", settings.DefaultMIMEType)
+	}
+}
+
+func TestValidateSettings_InvalidDefaultMIMEType(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", DefaultMIMEType: "plain"}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "default-mime-type must have the form type/subtype") {
+		t.Errorf("Expected default MIME type validation error, got %v", err)
+	}
+}
+
+// --- Search Headings Boost Tests ---
+
+func TestLoadSettings_SearchHeadingsBoost(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Search.HeadingsBoost != 2.0 {
+		t.Errorf("Expected default headings boost 2.0, got %v", settings.Search.HeadingsBoost)
+	}
+
+	t.Setenv("ACDC_MCP_SEARCH_HEADINGS_BOOST", "0")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Search.HeadingsBoost != 0 {
+		t.Errorf("Expected headings boost 0, got %v", settings.Search.HeadingsBoost)
+	}
+}
+
+// --- Prompt Output Trimming Tests ---
+
+func TestLoadSettings_PromptsTrimOutput(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Prompts.TrimOutput {
+		t.Error("Expected prompt output trimming to be disabled by default")
+	}
+
+	t.Setenv("ACDC_MCP_PROMPTS_TRIM_OUTPUT", "true")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if !settings.Prompts.TrimOutput {
+		t.Error("Expected prompt output trimming to be enabled")
+	}
+}
+
+// --- Search Ranking Tests ---
+
+func TestLoadSettings_SearchRanking(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Search.Ranking != SearchRankingTFIDF {
+		t.Errorf("Expected default search ranking %q, got %q", SearchRankingTFIDF, settings.Search.Ranking)
+	}
+
+	t.Setenv("ACDC_MCP_SEARCH_RANKING", "bm25")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Search.Ranking != SearchRankingBM25 {
+		t.Errorf("Expected search ranking %q, got %q", SearchRankingBM25, settings.Search.Ranking)
+	}
+}
+
+func TestValidateSettings_InvalidSearchRanking(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", Search: SearchSettings{Ranking: "pagerank"}}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "search-ranking must be 'tf-idf' or 'bm25'") {
+		t.Errorf("Expected search ranking validation error, got %v", err)
+	}
+}
+
+// --- Search Synonyms Tests ---
+
+func TestSearchSettings_SynonymMap(t *testing.T) {
+	s := SearchSettings{Synonyms: []string{"K8s=kubernetes| kube ", "pg=postgres", "k8s=k3s"}}
+	synonyms, err := s.SynonymMap()
+	if err != nil {
+		t.Fatalf("SynonymMap failed: %v", err)
+	}
+	want := map[string][]string{"k8s": {"kubernetes", "kube", "k3s"}, "pg": {"postgres"}}
+	if !reflect.DeepEqual(synonyms, want) {
+		t.Errorf("Expected %v, got %v", want, synonyms)
+	}
+
+	for _, entry := range []string{"k8s", "=kubernetes", "k8s=|", "kube ctl=kubectl"} {
+		s := SearchSettings{Synonyms: []string{entry}}
+		if _, err := s.SynonymMap(); err == nil {
+			t.Errorf("Expected an error for entry %q", entry)
+		}
+	}
+}
+
+func TestLoadSettings_SearchSynonyms(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_SYNONYMS", "k8s=kubernetes, pg=postgres")
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	want := []string{"k8s=kubernetes", "pg=postgres"}
+	if !reflect.DeepEqual(settings.Search.Synonyms, want) {
+		t.Errorf("Expected synonyms %v, got %v", want, settings.Search.Synonyms)
+	}
+}
+
+func TestValidateSettings_InvalidSearchSynonyms(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", Search: SearchSettings{Synonyms: []string{"k8s"}}}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "search-synonyms entries must have the form term=synonym1|synonym2") {
+		t.Errorf("Expected search synonyms validation error, got %v", err)
+	}
+}
+
+// --- Prompt Default Locale Tests ---
+
+func TestLoadSettings_PromptsDefaultLocale(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Prompts.DefaultLocale != "en" {
+		t.Errorf("Expected default prompts locale en, got %q", settings.Prompts.DefaultLocale)
+	}
+
+	t.Setenv("ACDC_MCP_PROMPTS_DEFAULT_LOCALE", "de-AT")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Prompts.DefaultLocale != "de-AT" {
+		t.Errorf("Expected prompts locale de-AT, got %q", settings.Prompts.DefaultLocale)
+	}
+}
+
+func TestValidateSettings_InvalidPromptsDefaultLocale(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", Prompts: PromptSettings{DefaultLocale: "german language"}}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "prompts-default-locale must be a locale tag") {
+		t.Errorf("Expected prompts default locale validation error, got %v", err)
+	}
+}
+
+// --- Prompt Allowed Functions Tests ---
+
+func TestLoadSettings_PromptsAllowedFunctions(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if len(settings.Prompts.AllowedFunctions) != 0 {
+		t.Errorf("Expected no function restriction by default, got %v", settings.Prompts.AllowedFunctions)
+	}
+
+	t.Setenv("ACDC_MCP_PROMPTS_ALLOWED_FUNCTIONS", "eq, printf")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if want := []string{"eq", "printf"}; !reflect.DeepEqual(settings.Prompts.AllowedFunctions, want) {
+		t.Errorf("Expected allowed functions %v, got %v", want, settings.Prompts.AllowedFunctions)
+	}
+}
+
+// --- Trailing Newline Tests ---
+
+func TestLoadSettings_TrailingNewline(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.TrailingNewline != TrailingNewlinePreserve {
+		t.Errorf("Expected trailing newline %q by default, got %q", TrailingNewlinePreserve, settings.TrailingNewline)
+	}
+
+	t.Setenv("ACDC_MCP_TRAILING_NEWLINE", "single")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.TrailingNewline != TrailingNewlineSingle {
+		t.Errorf("Expected trailing newline %q, got %q", TrailingNewlineSingle, settings.TrailingNewline)
+	}
+}
+
+func TestValidateSettings_InvalidTrailingNewline(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", TrailingNewline: "always"}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "trailing-newline must be") {
+		t.Errorf("Expected trailing newline validation error, got %v", err)
+	}
+}
+
+// --- Base Path Tests ---
+
+func TestLoadSettings_BasePath(t *testing.T) {
+	t.Setenv("ACDC_MCP_BASE_PATH", "/mcp/docs")
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.BasePath != "/mcp/docs" {
+		t.Errorf("Expected base path /mcp/docs, got %q", settings.BasePath)
+	}
+}
+
+func TestValidateSettings_BasePath(t *testing.T) {
+	for _, basePath := range []string{"", "/", "/mcp", "/mcp/docs", "/mcp/docs/"} {
+		s := &Settings{Transport: "stdio", Scheme: "acdc", BasePath: basePath}
+		if err := ValidateSettings(s); err != nil {
+			t.Errorf("Expected base path %q to be valid, got %v", basePath, err)
+		}
+	}
+	for _, basePath := range []string{"mcp", "/mcp//docs", "/mcp?x=1", "http://host/mcp"} {
+		s := &Settings{Transport: "stdio", Scheme: "acdc", BasePath: basePath}
+		err := ValidateSettings(s)
+		if err == nil || !strings.Contains(err.Error(), "base-path must be") {
+			t.Errorf("Expected base path validation error for %q, got %v", basePath, err)
+		}
+	}
+}
+
+// --- Max Read Bytes Tests ---
+
+func TestLoadSettings_MaxReadBytes(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.MaxReadBytes != 0 {
+		t.Errorf("Expected unlimited reads by default, got %d", settings.MaxReadBytes)
+	}
+
+	t.Setenv("ACDC_MCP_MAX_READ_BYTES", "65536")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.MaxReadBytes != 65536 {
+		t.Errorf("Expected max read bytes 65536, got %d", settings.MaxReadBytes)
+	}
+}
+
+func TestValidateSettings_NegativeMaxReadBytes(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", MaxReadBytes: -1}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "max-read-bytes must not be negative") {
+		t.Errorf("Expected max read bytes validation error, got %v", err)
+	}
+}
+
+// --- Search Output Style Tests ---
+
+func TestLoadSettings_SearchOutputStyle(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Search.OutputStyle != SearchOutputMarkdown {
+		t.Errorf("Expected markdown output by default, got %q", settings.Search.OutputStyle)
+	}
+
+	t.Setenv("ACDC_MCP_SEARCH_OUTPUT_STYLE", "compact")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Search.OutputStyle != SearchOutputCompact {
+		t.Errorf("Expected compact output, got %q", settings.Search.OutputStyle)
+	}
+}
+
+func TestValidateSettings_InvalidSearchOutputStyle(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", Search: SearchSettings{OutputStyle: "html"}}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "search-output-style must be") {
+		t.Errorf("Expected search output style validation error, got %v", err)
+	}
+}
+
+// --- List Order Tests ---
+
+func TestLoadSettings_ListOrder(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.ListOrder != ListOrderDiscovery {
+		t.Errorf("Expected discovery order by default, got %q", settings.ListOrder)
+	}
+
+	t.Setenv("ACDC_MCP_LIST_ORDER", "modified")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.ListOrder != ListOrderModified {
+		t.Errorf("Expected modified order, got %q", settings.ListOrder)
+	}
+}
+
+func TestValidateSettings_InvalidListOrder(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", ListOrder: "size"}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "list-order must be") {
+		t.Errorf("Expected list order validation error, got %v", err)
+	}
+}
+
+// --- Audit Log Tests ---
+
+func TestLoadSettings_AuditLog(t *testing.T) {
+	t.Setenv("ACDC_MCP_AUDIT_LOG", "/var/log/acdc/audit.jsonl")
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.AuditLog != "/var/log/acdc/audit.jsonl" {
+		t.Errorf("Expected audit log path, got %q", settings.AuditLog)
+	}
+}
+
+func TestValidateSettings_AuditLogStdout(t *testing.T) {
+	s := &Settings{Transport: "stdio", Scheme: "acdc", AuditLog: AuditLogStdout}
+	err := ValidateSettings(s)
+	if err == nil || !strings.Contains(err.Error(), "audit-log cannot be stdout") {
+		t.Errorf("Expected audit log validation error, got %v", err)
+	}
+
+	s = &Settings{Transport: "sse", Port: 8080, Scheme: "acdc", AuditLog: AuditLogStdout, Auth: AuthSettings{Type: AuthTypeNone}}
+	if err := ValidateSettings(s); err != nil {
+		t.Errorf("Expected stdout audit log to be valid with SSE, got %v", err)
+	}
+}
+
+// --- Metadata Tools Only Tests ---
+
+func TestLoadSettings_MetadataToolsOnly(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.MetadataToolsOnly {
+		t.Error("Expected all tools to be registered by default")
+	}
+
+	t.Setenv("ACDC_MCP_METADATA_TOOLS_ONLY", "true")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if !settings.MetadataToolsOnly {
+		t.Error("Expected metadata tools only to be enabled")
+	}
+}
+
+// --- Duplicate Detection Tests ---
+
+func TestLoadSettings_DuplicateDetection(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.DetectDuplicates || settings.CanonicalNotice {
+		t.Error("Expected duplicate detection and the canonical notice to be disabled by default")
+	}
+
+	t.Setenv("ACDC_MCP_DETECT_DUPLICATES", "true")
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("canonical-notice", false, "")
+	_ = flags.Set("canonical-notice", "true")
+	settings, err = LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("LoadSettingsWithFlags failed: %v", err)
+	}
+	if !settings.DetectDuplicates || !settings.CanonicalNotice {
+		t.Error("Expected duplicate detection and the canonical notice to be enabled")
 	}
 }
@@ -0,0 +1,92 @@
+package content
+
+import (
+	"bytes"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Byte order marks
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// utf16SampleSize is the number of leading bytes inspected to detect BOM-less UTF-16
+const utf16SampleSize = 512
+
+// decodeText converts raw file bytes to a UTF-8 string. UTF-8 and UTF-16 are
+// recognized by their byte order marks, BOM-less UTF-16 by the position of NUL
+// bytes in ASCII-range text, and valid UTF-8 is returned unchanged. Anything
+// else is assumed to be Latin-1 (ISO-8859-1).
+func decodeText(b []byte) string {
+	switch {
+	case bytes.HasPrefix(b, bomUTF8):
+		return string(b[len(bomUTF8):])
+	case bytes.HasPrefix(b, bomUTF16LE):
+		return decodeUTF16(b[len(bomUTF16LE):], false)
+	case bytes.HasPrefix(b, bomUTF16BE):
+		return decodeUTF16(b[len(bomUTF16BE):], true)
+	}
+
+	if bigEndian, ok := looksLikeUTF16(b); ok {
+		return decodeUTF16(b, bigEndian)
+	}
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	return decodeLatin1(b)
+}
+
+// looksLikeUTF16 reports whether b appears to be BOM-less UTF-16 text, based on
+// NUL bytes consistently occupying one half of each code unit
+func looksLikeUTF16(b []byte) (bigEndian bool, ok bool) {
+	sample := b[:min(len(b), utf16SampleSize)]
+	pairs := len(sample) / 2
+	if pairs == 0 {
+		return false, false
+	}
+
+	var evenNUL, oddNUL int
+	for i := 0; i+1 < len(sample); i += 2 {
+		if sample[i] == 0 {
+			evenNUL++
+		}
+		if sample[i+1] == 0 {
+			oddNUL++
+		}
+	}
+
+	// Mostly-ASCII text has a NUL in the high byte of nearly every code unit
+	threshold := pairs * 3 / 4
+	switch {
+	case oddNUL >= threshold && evenNUL == 0:
+		return false, true
+	case evenNUL >= threshold && oddNUL == 0:
+		return true, true
+	}
+	return false, false
+}
+
+// decodeUTF16 decodes UTF-16 code units to UTF-8. A trailing odd byte is dropped.
+func decodeUTF16(b []byte, bigEndian bool) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+		} else {
+			units[i] = uint16(b[2*i+1])<<8 | uint16(b[2*i])
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// decodeLatin1 decodes ISO-8859-1, where every byte maps to the code point of the same value
+func decodeLatin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
@@ -0,0 +1,84 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+)
+
+// latin1Markdown is "Café crème à la façon de Noël" with frontmatter, encoded as ISO-8859-1
+var latin1Markdown = []byte("---\nname: Caf\xe9\ndescription: Recette\n---\nCaf\xe9 cr\xe8me \xe0 la fa\xe7on de No\xebl\n")
+
+func encodeUTF16(s string, bigEndian bool, bom bool) []byte {
+	var b []byte
+	if bom {
+		if bigEndian {
+			b = append(b, bomUTF16BE...)
+		} else {
+			b = append(b, bomUTF16LE...)
+		}
+	}
+	for _, u := range utf16.Encode([]rune(s)) {
+		if bigEndian {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+	return b
+}
+
+func TestDecodeText(t *testing.T) {
+	const text = "---\nname: Café\n---\nNaïve résumé ✓\n"
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{"UTF-8", []byte(text), text},
+		{"UTF-8 BOM", append(append([]byte{}, bomUTF8...), text...), text},
+		{"UTF-16LE BOM", encodeUTF16(text, false, true), text},
+		{"UTF-16BE BOM", encodeUTF16(text, true, true), text},
+		{"UTF-16LE without BOM", encodeUTF16(text, false, false), text},
+		{"UTF-16BE without BOM", encodeUTF16(text, true, false), text},
+		{"Latin-1", []byte("Caf\xe9 cr\xe8me"), "Café crème"},
+		{"Empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeText(tt.input); got != tt.want {
+				t.Errorf("decodeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMarkdownWithFrontmatter_Latin1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "latin1.md")
+	if err := os.WriteFile(path, latin1Markdown, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Detection Enabled", func(t *testing.T) {
+		md, err := NewContentProvider("", WithEncodingDetection()).LoadMarkdownWithFrontmatter(path)
+		if err != nil {
+			t.Fatalf("LoadMarkdownWithFrontmatter failed: %v", err)
+		}
+		if name := md.Metadata["name"]; name != "Café" {
+			t.Errorf("Expected name 'Café', got %q", name)
+		}
+		if want := "Café crème à la façon de Noël\n"; md.Content != want {
+			t.Errorf("Expected content %q, got %q", want, md.Content)
+		}
+	})
+
+	t.Run("Detection Disabled", func(t *testing.T) {
+		// Without detection the Latin-1 frontmatter is not valid UTF-8 YAML
+		if _, err := NewContentProvider("").LoadMarkdownWithFrontmatter(path); err == nil {
+			t.Error("Expected an error when loading Latin-1 content as UTF-8")
+		}
+	})
+}
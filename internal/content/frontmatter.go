@@ -0,0 +1,140 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatterFormat is a frontmatter syntax enclosed in delimiter lines
+type frontmatterFormat struct {
+	name      string
+	delimiter string
+	unmarshal func([]byte, interface{}) error
+}
+
+// delimitedFormats are the supported delimited frontmatter syntaxes: YAML
+// between --- lines and TOML between +++ lines. JSON frontmatter is a bare
+// object and is parsed by parseJSONFrontmatter.
+var delimitedFormats = []frontmatterFormat{
+	{name: "YAML", delimiter: "---", unmarshal: yaml.Unmarshal},
+	{name: "TOML", delimiter: "+++", unmarshal: unmarshalTOML},
+}
+
+// splitFrontmatter detects the frontmatter format from the start of text and
+// parses the frontmatter, returning it along with the content that follows.
+// found is false when text does not start with frontmatter.
+func splitFrontmatter(text, filePath string) (metadata map[string]interface{}, rest string, found bool, err error) {
+	if startsJSONObject(text) {
+		metadata, rest, err = parseJSONFrontmatter(text, filePath)
+		return metadata, rest, true, err
+	}
+	for _, format := range delimitedFormats {
+		if remainder, ok := strings.CutPrefix(text, format.delimiter+"\n"); ok {
+			metadata, rest, err = parseDelimitedFrontmatter(remainder, format, filePath)
+			return metadata, rest, true, err
+		}
+	}
+	return nil, "", false, nil
+}
+
+// parseDelimitedFrontmatter parses frontmatter that starts after the opening
+// delimiter line of the given format, and returns it along with the content
+// following the closing delimiter line
+func parseDelimitedFrontmatter(remainder string, format frontmatterFormat, filePath string) (map[string]interface{}, string, error) {
+	// Empty frontmatter closes immediately
+	if rest, found := strings.CutPrefix(remainder, format.delimiter+"\n"); found {
+		return map[string]interface{}{}, rest, nil
+	}
+
+	endIndex := strings.Index(remainder, "\n"+format.delimiter)
+	if endIndex == -1 {
+		return nil, "", fmt.Errorf("invalid frontmatter format - missing closing %s in %s", format.delimiter, filePath)
+	}
+
+	// The closing delimiter must be on its own line
+	afterDelimiter := endIndex + 1 + len(format.delimiter)
+	if afterDelimiter < len(remainder) && remainder[afterDelimiter] != '\n' {
+		return nil, "", fmt.Errorf("closing %s must be on its own line in %s", format.delimiter, filePath)
+	}
+
+	rest := ""
+	if afterDelimiter+1 < len(remainder) {
+		rest = remainder[afterDelimiter+1:]
+	}
+
+	var metadata map[string]interface{}
+	if err := format.unmarshal([]byte(remainder[:endIndex]), &metadata); err != nil {
+		return nil, "", fmt.Errorf("invalid %s in frontmatter of %s: %w", format.name, filePath, err)
+	}
+	return metadata, rest, nil
+}
+
+// startsJSONObject reports whether text starts with what looks like a JSON
+// object: a { followed by a key or the closing }. Template tags such as {{ or
+// {% are not mistaken for JSON frontmatter.
+func startsJSONObject(text string) bool {
+	rest, found := strings.CutPrefix(text, "{")
+	if !found {
+		return false
+	}
+	rest = strings.TrimLeft(rest, " \t\n")
+	return strings.HasPrefix(rest, `"`) || strings.HasPrefix(rest, "}")
+}
+
+// parseJSONFrontmatter parses a JSON object at the start of text and returns
+// it along with the content following the line the object closes on
+func parseJSONFrontmatter(text, filePath string) (map[string]interface{}, string, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	var metadata map[string]interface{}
+	if err := decoder.Decode(&metadata); err != nil {
+		return nil, "", fmt.Errorf("invalid JSON in frontmatter of %s: %w", filePath, err)
+	}
+
+	line, rest, _ := strings.Cut(text[decoder.InputOffset():], "\n")
+	if strings.TrimSpace(line) != "" {
+		return nil, "", fmt.Errorf("closing } must be on its own line in %s", filePath)
+	}
+	return metadata, rest, nil
+}
+
+// unmarshalTOML decodes TOML frontmatter. Local dates and date-times, which
+// have no YAML counterpart, are converted to UTC times so that metadata is
+// interpreted the same regardless of the frontmatter format.
+func unmarshalTOML(data []byte, v interface{}) error {
+	if err := toml.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if metadata, ok := v.(*map[string]interface{}); ok {
+		for key, value := range *metadata {
+			(*metadata)[key] = normalizeTOMLValue(value)
+		}
+	}
+	return nil
+}
+
+// normalizeTOMLValue converts TOML local date and time values, including
+// those nested in tables and arrays
+func normalizeTOMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case toml.LocalDate:
+		return v.AsTime(time.UTC)
+	case toml.LocalDateTime:
+		return v.AsTime(time.UTC)
+	case toml.LocalTime:
+		return v.String()
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = normalizeTOMLValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = normalizeTOMLValue(nested)
+		}
+	}
+	return value
+}
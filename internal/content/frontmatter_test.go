@@ -0,0 +1,110 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadMarkdownWithFrontmatter_Formats(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"yaml.md": "---\nname: Guide\ndescription: How to\nkeywords: [setup, install]\nhidden: false\n---\n\n# Body\n",
+		"toml.md": "+++\nname = \"Guide\"\ndescription = \"How to\"\nkeywords = [\"setup\", \"install\"]\nhidden = false\n+++\n\n# Body\n",
+		"json.md": "{\n  \"name\": \"Guide\",\n  \"description\": \"How to\",\n  \"keywords\": [\"setup\", \"install\"],\n  \"hidden\": false\n}\n\n# Body\n",
+	}
+	p := NewContentProvider(tempDir)
+
+	var want *MarkdownWithFrontmatter
+	for _, name := range []string{"yaml.md", "toml.md", "json.md"} {
+		filePath := filepath.Join(tempDir, name)
+		if err := os.WriteFile(filePath, []byte(files[name]), 0644); err != nil {
+			t.Fatal(err)
+		}
+		md, err := p.LoadMarkdownWithFrontmatter(filePath)
+		if err != nil {
+			t.Fatalf("LoadMarkdownWithFrontmatter(%s) failed: %v", name, err)
+		}
+		if want == nil {
+			want = md
+			continue
+		}
+		if !reflect.DeepEqual(md, want) {
+			t.Errorf("%s: got %#v, want %#v", name, md, want)
+		}
+	}
+	if want.Content != "# Body\n" {
+		t.Errorf("Unexpected content %q", want.Content)
+	}
+}
+
+func TestLoadMarkdownWithFrontmatter_TOMLDates(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "dates.md")
+	content := "+++\npublish_at = 2024-03-01\nexpire_at = 2024-06-01T12:00:00\n[extra]\nseen = [2024-01-02]\n+++\nBody"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	md, err := NewContentProvider(tempDir).LoadMarkdownWithFrontmatter(filePath)
+	if err != nil {
+		t.Fatalf("LoadMarkdownWithFrontmatter failed: %v", err)
+	}
+	if got := md.Metadata["publish_at"]; got != time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("Expected a UTC date for publish_at, got %#v", got)
+	}
+	if got := md.Metadata["expire_at"]; got != time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) {
+		t.Errorf("Expected a UTC time for expire_at, got %#v", got)
+	}
+	extra, _ := md.Metadata["extra"].(map[string]interface{})
+	if seen, _ := extra["seen"].([]interface{}); len(seen) != 1 || seen[0] != time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("Expected nested dates to be converted, got %#v", extra["seen"])
+	}
+}
+
+func TestLoadMarkdownWithFrontmatter_TemplateTagIsNotJSON(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "shortcode.md")
+	content := "{{< note >}}\nBody\n{{< /note >}}\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	md, err := NewContentProvider(tempDir, WithOptionalFrontmatter()).LoadMarkdownWithFrontmatter(filePath)
+	if err != nil {
+		t.Fatalf("LoadMarkdownWithFrontmatter failed: %v", err)
+	}
+	if len(md.Metadata) != 0 || md.Content != content {
+		t.Errorf("Expected the file to be read as content, got metadata %v and content %q", md.Metadata, md.Content)
+	}
+}
+
+func TestLoadMarkdownWithFrontmatter_FormatErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	p := NewContentProvider(tempDir)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"TOML missing closing", "+++\nname = \"x\""},
+		{"Invalid TOML", "+++\nname = \n+++\nContent"},
+		{"Closing +++ not on own line", "+++\nname = \"x\"\n+++foo\nContent"},
+		{"Invalid JSON", "{\"name\": }\nContent"},
+		{"JSON array", "[\"name\"]\nContent"},
+		{"Text after JSON", "{\"name\": \"x\"} text\nContent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, "file.md")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := p.LoadMarkdownWithFrontmatter(filePath); err == nil {
+				t.Errorf("Expected error for %s", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package content
+
+import (
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+)
+
+// skipBlankLines removes the blank lines at the start of content. Leading
+// whitespace of the first non-blank line is kept, so indented code survives.
+func skipBlankLines(content string) string {
+	for {
+		line, rest, found := strings.Cut(content, "\n")
+		if !found || strings.TrimSpace(line) != "" {
+			return content
+		}
+		content = rest
+	}
+}
+
+// applyTrailingNewline normalizes the end of content according to policy.
+// Unknown policies preserve the content.
+func applyTrailingNewline(content, policy string) string {
+	switch policy {
+	case config.TrailingNewlineSingle:
+		content = strings.TrimRight(content, " \t\n")
+		if content == "" {
+			return ""
+		}
+		return content + "\n"
+	case config.TrailingNewlineNone:
+		return strings.TrimRight(content, " \t\n")
+	default:
+		return content
+	}
+}
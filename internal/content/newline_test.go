@@ -0,0 +1,66 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+)
+
+func TestContentProvider_LoadMarkdownWithFrontmatter_TrailingNewline(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		policy string
+		want   string
+	}{
+		{name: "Preserve With Newline", file: "---\nname: n\n---\nBody\n", want: "Body\n"},
+		{name: "Preserve Without Newline", file: "---\nname: n\n---\nBody", want: "Body"},
+		{name: "Preserve Trailing Blank Lines", file: "---\nname: n\n---\nBody\n\n\n", want: "Body\n\n\n"},
+		{name: "Blank Lines After Frontmatter", file: "---\nname: n\n---\n\n\n\nBody\n", want: "Body\n"},
+		{name: "Whitespace Lines After Frontmatter", file: "---\nname: n\n---\n  \n\t\nBody\n", want: "Body\n"},
+		{name: "Blank Lines After Empty Frontmatter", file: "---\n---\n\nBody", want: "Body"},
+		{name: "Indented First Line Kept", file: "---\nname: n\n---\n\n    code\n", want: "    code\n"},
+		{name: "CRLF", file: "---\r\nname: n\r\n---\r\n\r\nBody\r\n", want: "Body\n"},
+		{name: "Single Adds Newline", file: "---\nname: n\n---\nBody", policy: config.TrailingNewlineSingle, want: "Body\n"},
+		{name: "Single Collapses Newlines", file: "---\nname: n\n---\n\nBody  \n\n\n", policy: config.TrailingNewlineSingle, want: "Body\n"},
+		{name: "Single Empty Content", file: "---\nname: n\n---\n\n\n", policy: config.TrailingNewlineSingle, want: ""},
+		{name: "None Strips Newlines", file: "---\nname: n\n---\nBody\n\n", policy: config.TrailingNewlineNone, want: "Body"},
+		{name: "None Without Newline", file: "---\nname: n\n---\nBody", policy: config.TrailingNewlineNone, want: "Body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			filePath := filepath.Join(tempDir, "doc.md")
+			if err := os.WriteFile(filePath, []byte(tt.file), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			md, err := NewContentProvider(tempDir, WithTrailingNewline(tt.policy)).LoadMarkdownWithFrontmatter(filePath)
+			if err != nil {
+				t.Fatalf("LoadMarkdownWithFrontmatter failed: %v", err)
+			}
+			if md.Content != tt.want {
+				t.Errorf("Expected content %q, got %q", tt.want, md.Content)
+			}
+		})
+	}
+}
+
+func TestContentProvider_LoadMarkdownWithFrontmatter_TrailingNewlineWithoutFrontmatter(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "plain.md")
+	if err := os.WriteFile(filePath, []byte("\n# Title\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without frontmatter, leading blank lines are part of the file and kept
+	md, err := NewContentProvider(tempDir, WithOptionalFrontmatter(), WithTrailingNewline(config.TrailingNewlineSingle)).LoadMarkdownWithFrontmatter(filePath)
+	if err != nil {
+		t.Fatalf("LoadMarkdownWithFrontmatter failed: %v", err)
+	}
+	if md.Content != "\n# Title\n" {
+		t.Errorf("Expected content %q, got %q", "\n# Title\n", md.Content)
+	}
+}
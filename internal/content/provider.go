@@ -1,7 +1,9 @@
 package content
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,7 +11,15 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// MarkdownWithFrontmatter parsed markdown file with YAML frontmatter
+// ErrChangedDuringRead is returned by LoadMarkdownSnapshot when a file keeps
+// changing while it is read
+var ErrChangedDuringRead = errors.New("file changed while it was read")
+
+// snapshotRetries is the number of times LoadMarkdownSnapshot re-reads a file
+// that changed while it was read
+const snapshotRetries = 2
+
+// MarkdownWithFrontmatter parsed markdown file with YAML, TOML, or JSON frontmatter
 type MarkdownWithFrontmatter struct {
 	Metadata map[string]interface{}
 	Content  string
@@ -20,15 +30,51 @@ type ContentProvider struct {
 	ContentDir   string
 	ResourcesDir string
 	PromptsDir   string
+
+	detectEncoding      bool
+	optionalFrontmatter bool
+	trailingNewline     string
+}
+
+// Option configures a ContentProvider.
+type Option func(*ContentProvider)
+
+// WithEncodingDetection makes the provider detect UTF-16 and Latin-1 encoded
+// files and transcode them to UTF-8. Without it, files are assumed to be UTF-8.
+func WithEncodingDetection() Option {
+	return func(p *ContentProvider) {
+		p.detectEncoding = true
+	}
+}
+
+// WithOptionalFrontmatter makes the provider load markdown files that do not
+// start with YAML frontmatter, with empty metadata and the whole file as content.
+func WithOptionalFrontmatter() Option {
+	return func(p *ContentProvider) {
+		p.optionalFrontmatter = true
+	}
+}
+
+// WithTrailingNewline sets how the end of markdown content is normalized to
+// one of config.TrailingNewlinePreserve (the default),
+// config.TrailingNewlineSingle, or config.TrailingNewlineNone.
+func WithTrailingNewline(policy string) Option {
+	return func(p *ContentProvider) {
+		p.trailingNewline = policy
+	}
 }
 
 // NewContentProvider creates a new ContentProvider
-func NewContentProvider(contentDir string) *ContentProvider {
-	return &ContentProvider{
+func NewContentProvider(contentDir string, opts ...Option) *ContentProvider {
+	p := &ContentProvider{
 		ContentDir:   contentDir,
 		ResourcesDir: filepath.Join(contentDir, "mcp-resources"),
 		PromptsDir:   filepath.Join(contentDir, "mcp-prompts"),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // GetPath returns a path within the content directory
@@ -43,6 +89,9 @@ func (p *ContentProvider) LoadText(filePath string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if p.detectEncoding {
+		return decodeText(content), nil
+	}
 	return string(content), nil
 }
 
@@ -61,8 +110,23 @@ func (p *ContentProvider) LoadYAML(filePath string) (map[string]interface{}, err
 	return data, nil
 }
 
-// LoadMarkdownWithFrontmatter loads a markdown file with YAML frontmatter
+// LoadMarkdownWithFrontmatter loads a markdown file with YAML, TOML, or JSON
+// frontmatter.
+// Blank lines between the frontmatter and the content are dropped, and the end
+// of the content is normalized according to the trailing newline policy.
 func (p *ContentProvider) LoadMarkdownWithFrontmatter(filePath string) (*MarkdownWithFrontmatter, error) {
+	md, err := p.parseMarkdown(filePath)
+	if err != nil {
+		return nil, err
+	}
+	md.Content = applyTrailingNewline(md.Content, p.trailingNewline)
+	return md, nil
+}
+
+// parseMarkdown splits a markdown file into its frontmatter and content. The
+// frontmatter format is detected from the first line: --- for YAML, +++ for
+// TOML, or { for a JSON object.
+func (p *ContentProvider) parseMarkdown(filePath string) (*MarkdownWithFrontmatter, error) {
 	content, err := p.LoadText(filePath)
 	if err != nil {
 		return nil, err
@@ -71,68 +135,57 @@ func (p *ContentProvider) LoadMarkdownWithFrontmatter(filePath string) (*Markdow
 	// Normalize CRLF to LF to simplify parsing
 	normalized := strings.ReplaceAll(content, "\r\n", "\n")
 
-	if !strings.HasPrefix(normalized, "---\n") {
-		return nil, fmt.Errorf("file must start with YAML frontmatter (---\\n) in %s", filePath)
-	}
-
-	const startDelimiterLen = 4
-	remainder := normalized[startDelimiterLen:]
-
-	// Check if we have an empty frontmatter (immediately closing)
-	if strings.HasPrefix(remainder, "---\n") {
-		// Empty metadata
-		markdownContent := remainder[4:]
-		return &MarkdownWithFrontmatter{
-			Metadata: map[string]interface{}{},
-			Content:  markdownContent,
-		}, nil
-	}
-
-	// Find the closing --- on its own line
-	// We search for "\n---"
-	endIndex := strings.Index(remainder, "\n---")
-	if endIndex == -1 {
-		return nil, fmt.Errorf("invalid frontmatter format - missing closing --- in %s", filePath)
+	metadata, markdownContent, found, err := splitFrontmatter(normalized, filePath)
+	if err != nil {
+		return nil, err
 	}
-
-	// Verify it's a valid closing delimiter (followed by newline or EOF)
-	// endIndex points to the newline before ---
-	// So we check remainder[endIndex+4] (length of "\n---" is 4)
-
-	// Check if it is followed by newline or is end of file
-	afterDelimiter := endIndex + 4
-	if afterDelimiter < len(remainder) && remainder[afterDelimiter] != '\n' {
-		// This might be something like "\n---foo", which is not a delimiter
-		// In a real robust parser we would loop to find the next one, but for now let's assume valid markdown.
-		// Or we can try to find the next one.
-		// For simplicity, let's treat it as error or recurse.
-		// But given the scope, let's just fail if it's not a proper delimiter.
-		// Actually, standard says delimiter must be on its own line.
-		return nil, fmt.Errorf("closing --- must be on its own line in %s", filePath)
+	if !found {
+		if p.optionalFrontmatter {
+			return &MarkdownWithFrontmatter{
+				Metadata: map[string]interface{}{},
+				Content:  normalized,
+			}, nil
+		}
+		return nil, fmt.Errorf("file must start with frontmatter (---, +++, or {) in %s", filePath)
 	}
 
-	frontmatterText := remainder[:endIndex]
+	return &MarkdownWithFrontmatter{
+		Metadata: metadata,
+		Content:  skipBlankLines(markdownContent),
+	}, nil
+}
 
-	var contentStartIndex int
-	if afterDelimiter < len(remainder) {
-		// skip the following newline
-		contentStartIndex = afterDelimiter + 1
-	} else {
-		contentStartIndex = len(remainder)
-	}
+// LoadMarkdownSnapshot loads a markdown file like LoadMarkdownWithFrontmatter
+// and verifies that it did not change while it was read, by comparing its
+// modification time and size before and after the read. A file that changed
+// is re-read, and ErrChangedDuringRead is returned if it keeps changing. The
+// returned FileInfo describes the version that was loaded.
+func (p *ContentProvider) LoadMarkdownSnapshot(filePath string) (*MarkdownWithFrontmatter, fs.FileInfo, error) {
+	return loadSnapshot(filePath, p.LoadMarkdownWithFrontmatter)
+}
 
-	markdownContent := ""
-	if contentStartIndex < len(remainder) {
-		markdownContent = remainder[contentStartIndex:]
+// loadSnapshot loads a file with load until its state before and after the
+// load is the same
+func loadSnapshot(filePath string, load func(string) (*MarkdownWithFrontmatter, error)) (*MarkdownWithFrontmatter, fs.FileInfo, error) {
+	before, err := os.Stat(filePath)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	var metadata map[string]interface{}
-	if err := yaml.Unmarshal([]byte(frontmatterText), &metadata); err != nil {
-		return nil, fmt.Errorf("invalid YAML in frontmatter of %s: %w", filePath, err)
+	for attempt := 0; ; attempt++ {
+		md, err := load(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		after, err := os.Stat(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if after.ModTime().Equal(before.ModTime()) && after.Size() == before.Size() {
+			return md, after, nil
+		}
+		if attempt == snapshotRetries {
+			return nil, nil, fmt.Errorf("%w: %s", ErrChangedDuringRead, filePath)
+		}
+		before = after
 	}
-
-	return &MarkdownWithFrontmatter{
-		Metadata: metadata,
-		Content:  markdownContent,
-	}, nil
 }
@@ -1,9 +1,11 @@
 package content
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestContentProvider_LoadText(t *testing.T) {
@@ -111,6 +113,29 @@ func TestContentProvider_LoadMarkdownWithFrontmatter_EmptyFrontmatter(t *testing
 	}
 }
 
+func TestContentProvider_LoadMarkdownWithFrontmatter_OptionalFrontmatter(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "plain.md")
+	if err := os.WriteFile(filePath, []byte("# Title\r\n\r\nBody"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewContentProvider(tempDir).LoadMarkdownWithFrontmatter(filePath); err == nil {
+		t.Error("Expected error for missing frontmatter by default")
+	}
+
+	md, err := NewContentProvider(tempDir, WithOptionalFrontmatter()).LoadMarkdownWithFrontmatter(filePath)
+	if err != nil {
+		t.Fatalf("LoadMarkdownWithFrontmatter failed without frontmatter: %v", err)
+	}
+	if len(md.Metadata) != 0 {
+		t.Errorf("Expected empty metadata, got %v", md.Metadata)
+	}
+	if md.Content != "# Title\n\nBody" {
+		t.Errorf("Expected the whole file as content, got %q", md.Content)
+	}
+}
+
 func TestContentProvider_LoadText_Error(t *testing.T) {
 	p := NewContentProvider(t.TempDir())
 	_, err := p.LoadText("non-existent.txt")
@@ -173,3 +198,65 @@ func TestContentProvider_GetPath(t *testing.T) {
 		t.Errorf("Expected '%s', got '%s'", expected, path)
 	}
 }
+
+func TestContentProvider_LoadMarkdownSnapshot(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "test.md")
+	data := "---\nname: v1\n---\nBody"
+	if err := os.WriteFile(filePath, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewContentProvider(tempDir)
+	md, info, err := p.LoadMarkdownSnapshot(filePath)
+	if err != nil {
+		t.Fatalf("LoadMarkdownSnapshot failed: %v", err)
+	}
+	if md.Metadata["name"] != "v1" || info.Size() != int64(len(data)) {
+		t.Errorf("Unexpected snapshot: metadata %v, size %d", md.Metadata, info.Size())
+	}
+}
+
+func TestLoadSnapshot_ConcurrentModification(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "test.md")
+	if err := os.WriteFile(filePath, []byte("---\nname: v1\n---\nBody"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p := NewContentProvider(tempDir)
+
+	// modifyOnLoad simulates a writer that updates the file while the first
+	// n loads are in progress
+	modifyOnLoad := func(n int) func(string) (*MarkdownWithFrontmatter, error) {
+		loads := 0
+		return func(path string) (*MarkdownWithFrontmatter, error) {
+			md, err := p.LoadMarkdownWithFrontmatter(path)
+			if loads < n {
+				loads++
+				modTime := time.Now().Add(time.Duration(loads) * time.Hour)
+				if err := os.Chtimes(path, modTime, modTime); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return md, err
+		}
+	}
+
+	t.Run("Retries Until Stable", func(t *testing.T) {
+		_, info, err := loadSnapshot(filePath, modifyOnLoad(1))
+		if err != nil {
+			t.Fatalf("Expected a stable snapshot after a retry, got %v", err)
+		}
+		current, _ := os.Stat(filePath)
+		if !info.ModTime().Equal(current.ModTime()) {
+			t.Errorf("Expected the snapshot of the latest version, got mod time %v", info.ModTime())
+		}
+	})
+
+	t.Run("Keeps Changing", func(t *testing.T) {
+		_, _, err := loadSnapshot(filePath, modifyOnLoad(snapshotRetries+1))
+		if !errors.Is(err, ErrChangedDuringRead) {
+			t.Errorf("Expected ErrChangedDuringRead, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package content
+
+import (
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// WalkDir walks the tree rooted at root like filepath.WalkDir. When
+// followSymlinks is set, a symlinked root and symlinked directories beneath it
+// are descended into, and paths are reported at their logical (unresolved)
+// location. Directories that resolve to an already visited target are skipped
+// with a warning, which breaks symlink loops.
+func WalkDir(root string, followSymlinks bool, fn fs.WalkDirFunc) error {
+	if !followSymlinks {
+		return filepath.WalkDir(root, fn)
+	}
+	return walkFollowingSymlinks(root, make(map[string]bool), fn)
+}
+
+// walkFollowingSymlinks walks the resolved target of logical, recursing into symlinked directories
+func walkFollowingSymlinks(logical string, visited map[string]bool, fn fs.WalkDirFunc) error {
+	target, err := filepath.EvalSymlinks(logical)
+	if err != nil {
+		return fn(logical, nil, err)
+	}
+	if visited[target] {
+		slog.Warn("Skipping already visited directory (symlink loop)", "path", logical, "target", target)
+		return nil
+	}
+
+	return filepath.WalkDir(target, func(path string, d fs.DirEntry, err error) error {
+		logicalPath := logical
+		if rel, relErr := filepath.Rel(target, path); relErr == nil && rel != "." {
+			logicalPath = filepath.Join(logical, rel)
+		}
+		if err != nil {
+			return fn(logicalPath, d, err)
+		}
+
+		// Paths below a resolved target contain no symlinks, so they identify directories uniquely
+		if d.IsDir() {
+			if visited[path] {
+				slog.Warn("Skipping already visited directory (symlink loop)", "path", logicalPath, "target", path)
+				return filepath.SkipDir
+			}
+			visited[path] = true
+			return fn(logicalPath, d, nil)
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			info, statErr := os.Stat(path)
+			if statErr != nil {
+				slog.Warn("Skipping broken symlink", "path", logicalPath, "error", statErr)
+				return nil
+			}
+			if info.IsDir() {
+				return walkFollowingSymlinks(logicalPath, visited, fn)
+			}
+		}
+
+		return fn(logicalPath, d, nil)
+	})
+}
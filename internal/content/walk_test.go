@@ -0,0 +1,123 @@
+package content
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// collectFiles walks root and returns the non-directory paths relative to root
+func collectFiles(t *testing.T, root string, followSymlinks bool) []string {
+	t.Helper()
+
+	var files []string
+	err := WalkDir(root, followSymlinks, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir failed: %v", err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func mustWrite(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWalkDir_SymlinkedSubdirectory(t *testing.T) {
+	tmp := t.TempDir()
+	external := filepath.Join(tmp, "external")
+	root := filepath.Join(tmp, "root")
+	mustWrite(t, filepath.Join(external, "guide.md"))
+	mustWrite(t, filepath.Join(root, "local.md"))
+	if err := os.Symlink(external, filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	if got := collectFiles(t, root, false); len(got) != 2 || got[0] != "linked" || got[1] != "local.md" {
+		t.Errorf("Without following, expected the symlink itself to be reported, got %v", got)
+	}
+
+	got := collectFiles(t, root, true)
+	want := []string{"linked/guide.md", "local.md"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestWalkDir_SymlinkedRoot(t *testing.T) {
+	tmp := t.TempDir()
+	real := filepath.Join(tmp, "real")
+	mustWrite(t, filepath.Join(real, "sub", "doc.md"))
+	root := filepath.Join(tmp, "root")
+	if err := os.Symlink(real, root); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	got := collectFiles(t, root, true)
+	if len(got) != 1 || got[0] != "sub/doc.md" {
+		t.Errorf("Expected [sub/doc.md], got %v", got)
+	}
+}
+
+func TestWalkDir_SymlinkLoop(t *testing.T) {
+	tmp := t.TempDir()
+	root := filepath.Join(tmp, "root")
+	mustWrite(t, filepath.Join(root, "a", "doc.md"))
+	// a/loop points back at root, which would recurse forever without detection
+	if err := os.Symlink(root, filepath.Join(root, "a", "loop")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	got := collectFiles(t, root, true)
+	if len(got) != 1 || got[0] != "a/doc.md" {
+		t.Errorf("Expected [a/doc.md], got %v", got)
+	}
+}
+
+func TestWalkDir_SelfReferencingRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "root")
+	if err := os.Symlink(root, root); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	err := WalkDir(root, true, func(path string, d fs.DirEntry, err error) error {
+		return err
+	})
+	if err == nil {
+		t.Error("Expected error for self-referencing root symlink")
+	}
+}
+
+func TestWalkDir_BrokenSymlinkSkipped(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "doc.md"))
+	if err := os.Symlink(filepath.Join(root, "missing"), filepath.Join(root, "broken")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	got := collectFiles(t, root, true)
+	if len(got) != 1 || got[0] != "doc.md" {
+		t.Errorf("Expected [doc.md], got %v", got)
+	}
+}
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 // ServerMetadata represents the server section of mcp-metadata.yaml
@@ -13,14 +16,22 @@ type ServerMetadata struct {
 
 // ToolMetadata represents a tool definition in mcp-metadata.yaml
 type ToolMetadata struct {
-	Name        string `yaml:"name"`
+	Name            string `yaml:"name"`
+	Description     string `yaml:"description"`
+	DescriptionFile string `yaml:"description_file"`
+}
+
+// ToolDefaultsMetadata represents the tool_defaults section of mcp-metadata.yaml.
+// Its fields are merged into each tool entry that does not set them.
+type ToolDefaultsMetadata struct {
 	Description string `yaml:"description"`
 }
 
 // McpMetadata represents the root of mcp-metadata.yaml
 type McpMetadata struct {
-	Server ServerMetadata `yaml:"server"`
-	Tools  []ToolMetadata `yaml:"tools"`
+	Server       ServerMetadata       `yaml:"server"`
+	ToolDefaults ToolDefaultsMetadata `yaml:"tool_defaults"`
+	Tools        []ToolMetadata       `yaml:"tools"` // nil when the section is absent, empty when it is explicitly empty
 }
 
 // DefaultToolMetadata provides sensible defaults for known tools
@@ -41,6 +52,46 @@ WHEN TO USE: Use after you have found a relevant resource URI (e.g., via the sea
 
 HOW IT WORKS: Provide the URI of the resource you wish to read (e.g., 'acdc://guides/getting-started.md'). The tool returns the full markdown content of the resource with frontmatter removed.`,
 	},
+	"related": {
+		Name: "related",
+		Description: `Find resources related to a given resource. This tool returns other resources that share keywords with the specified resource, ranked by the number of shared keywords.
+
+WHEN TO USE: Use after reading a resource to discover adjacent documentation that may also apply to your task.
+
+HOW IT WORKS: Provide the URI of a resource (e.g., 'acdc://guides/getting-started'). The tool returns related resources with their URIs, descriptions, and the keywords they share.`,
+	},
+	"links": {
+		Name: "links",
+		Description: `List the resources a given resource links to, and optionally the resources that link to it. This tool follows the markdown links between resources.
+
+WHEN TO USE: Use after reading a resource to navigate to the documents it references, or to find which documents reference it.
+
+HOW IT WORKS: Provide the URI of a resource (e.g., 'acdc://guides/getting-started'). The tool returns the resources it links to with their URIs and descriptions. Set 'inbound' to also list the resources that link to it.`,
+	},
+	"stat": {
+		Name: "stat",
+		Description: `Check whether a resource exists and get its metadata without reading its content. This tool is a lightweight alternative to the read tool.
+
+WHEN TO USE: Use to confirm that a URI you found (e.g., in a link or an earlier conversation) is valid, or to check a resource's size and last modification time before reading it.
+
+HOW IT WORKS: Provide the URI of a resource (e.g., 'acdc://guides/getting-started'). The tool returns a JSON object with 'exists' and, for existing resources, the name, title, description, MIME type, size in bytes, and last modification time.`,
+	},
+	"list": {
+		Name: "list",
+		Description: `List resources, optionally only those carrying given tags. This tool supports browsing the available documentation by topic.
+
+WHEN TO USE: Use to explore which resources exist on a topic when you know its tag rather than search terms, or to narrow a tag down by combining it with further tags.
+
+HOW IT WORKS: Provide zero or more tags (e.g., ['onboarding', 'backend']). The tool returns the resources carrying all of them, with their URIs, descriptions, and tags. Tags are compared case-insensitively; without tags, every resource is listed.`,
+	},
+	"describe": {
+		Name: "describe",
+		Description: `Describe this server and the content it offers. This tool returns the server name and version, the transport in use, the number of available resources and prompts, and the names of the enabled tools.
+
+WHEN TO USE: Use to get an overview of the server's capabilities without listing resources, prompts, and tools separately.
+
+HOW IT WORKS: Takes no arguments and returns a JSON object with the server's details and content statistics.`,
+	},
 }
 
 // GetToolMetadata returns metadata for the specified tool name, using overrides if provided
@@ -54,6 +105,73 @@ func (m *McpMetadata) GetToolMetadata(name string) ToolMetadata {
 	return DefaultToolMetadata[name]
 }
 
+// HasTool reports whether the tools section lists the given tool
+func (m *McpMetadata) HasTool(name string) bool {
+	for _, t := range m.Tools {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyToolDefaults merges the tool_defaults section into each tool entry,
+// keeping any field the tool sets explicitly.
+func (m *McpMetadata) ApplyToolDefaults() {
+	for i := range m.Tools {
+		if m.Tools[i].Description == "" {
+			m.Tools[i].Description = m.ToolDefaults.Description
+		}
+	}
+}
+
+// Merge applies a metadata fragment: non-empty server and tool_defaults fields
+// override the current values, and tools are appended. An explicitly empty
+// tools section in the fragment counts as present.
+func (m *McpMetadata) Merge(fragment McpMetadata) {
+	if fragment.Server.Name != "" {
+		m.Server.Name = fragment.Server.Name
+	}
+	if fragment.Server.Version != "" {
+		m.Server.Version = fragment.Server.Version
+	}
+	if fragment.Server.Instructions != "" {
+		m.Server.Instructions = fragment.Server.Instructions
+	}
+	if fragment.ToolDefaults.Description != "" {
+		m.ToolDefaults.Description = fragment.ToolDefaults.Description
+	}
+	if fragment.Tools != nil && m.Tools == nil {
+		m.Tools = []ToolMetadata{}
+	}
+	m.Tools = append(m.Tools, fragment.Tools...)
+}
+
+// ResolveDescriptionFiles loads the description of each tool that sets
+// description_file from that markdown file. Relative paths are resolved
+// against baseDir. A tool may not set both description and description_file.
+func (m *McpMetadata) ResolveDescriptionFiles(baseDir string) error {
+	for i, t := range m.Tools {
+		if t.DescriptionFile == "" {
+			continue
+		}
+		if t.Description != "" {
+			return fmt.Errorf("tool %s sets both description and description_file", t.Name)
+		}
+
+		path := t.DescriptionFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read description file for tool %s: %w", t.Name, err)
+		}
+		m.Tools[i].Description = strings.TrimSpace(string(data))
+	}
+	return nil
+}
+
 // ToolsMap returns tools as a map for easy lookup
 func (m *McpMetadata) ToolsMap() (map[string]ToolMetadata, error) {
 	tools := make(map[string]ToolMetadata)
@@ -1,6 +1,11 @@
 package domain
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
 
 func TestMcpMetadata_Validate(t *testing.T) {
 	tests := []struct {
@@ -166,3 +171,138 @@ func TestToolsMap(t *testing.T) {
 		}
 	})
 }
+
+func TestApplyToolDefaults(t *testing.T) {
+	meta := McpMetadata{
+		Server:       ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+		ToolDefaults: ToolDefaultsMetadata{Description: "default description"},
+		Tools: []ToolMetadata{
+			{Name: "search"},
+			{Name: "read", Description: "custom read"},
+		},
+	}
+
+	meta.ApplyToolDefaults()
+
+	if got := meta.GetToolMetadata("search").Description; got != "default description" {
+		t.Errorf("expected default description applied, got %s", got)
+	}
+	if got := meta.GetToolMetadata("read").Description; got != "custom read" {
+		t.Errorf("expected overridden description kept, got %s", got)
+	}
+	if err := meta.Validate(); err != nil {
+		t.Errorf("expected merged metadata to validate, got %v", err)
+	}
+}
+
+func TestApplyToolDefaults_NoDefaults(t *testing.T) {
+	meta := McpMetadata{
+		Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+		Tools:  []ToolMetadata{{Name: "search"}},
+	}
+
+	meta.ApplyToolDefaults()
+
+	if err := meta.Validate(); err == nil {
+		t.Error("expected validation error for tool without description and no default")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	meta := McpMetadata{
+		Server:       ServerMetadata{Name: "base", Version: "1", Instructions: "base instructions"},
+		ToolDefaults: ToolDefaultsMetadata{Description: "base default"},
+		Tools:        []ToolMetadata{{Name: "search", Description: "base search"}},
+	}
+
+	meta.Merge(McpMetadata{
+		Server: ServerMetadata{Version: "2"},
+		Tools:  []ToolMetadata{{Name: "read", Description: "fragment read"}},
+	})
+
+	want := ServerMetadata{Name: "base", Version: "2", Instructions: "base instructions"}
+	if meta.Server != want {
+		t.Errorf("expected server %+v, got %+v", want, meta.Server)
+	}
+	if meta.ToolDefaults.Description != "base default" {
+		t.Errorf("expected tool defaults kept, got %s", meta.ToolDefaults.Description)
+	}
+	if len(meta.Tools) != 2 || meta.Tools[1].Name != "read" {
+		t.Errorf("expected fragment tools appended, got %+v", meta.Tools)
+	}
+}
+
+func TestMerge_EmptyTools(t *testing.T) {
+	var meta McpMetadata
+	meta.Merge(McpMetadata{})
+	if meta.Tools != nil {
+		t.Errorf("expected absent tools to stay absent, got %+v", meta.Tools)
+	}
+
+	meta.Merge(McpMetadata{Tools: []ToolMetadata{}})
+	if meta.Tools == nil || len(meta.Tools) != 0 {
+		t.Errorf("expected an explicitly empty tools section to be kept, got %#v", meta.Tools)
+	}
+}
+
+func TestHasTool(t *testing.T) {
+	meta := McpMetadata{Tools: []ToolMetadata{{Name: "search"}}}
+	if !meta.HasTool("search") {
+		t.Error("expected search to be listed")
+	}
+	if meta.HasTool("read") {
+		t.Error("expected read not to be listed")
+	}
+}
+
+func TestResolveDescriptionFiles(t *testing.T) {
+	baseDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(baseDir, "tools"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(baseDir, "tools", "read.md"), []byte("Read a resource.\n\nUse after searching.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := McpMetadata{
+		Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+		Tools: []ToolMetadata{
+			{Name: "search", Description: "inline search"},
+			{Name: "read", DescriptionFile: "tools/read.md"},
+		},
+	}
+
+	if err := meta.ResolveDescriptionFiles(baseDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := meta.GetToolMetadata("search").Description; got != "inline search" {
+		t.Errorf("expected inline description kept, got %q", got)
+	}
+	if got := meta.GetToolMetadata("read").Description; got != "Read a resource.\n\nUse after searching." {
+		t.Errorf("expected description loaded from file, got %q", got)
+	}
+	if err := meta.Validate(); err != nil {
+		t.Errorf("expected resolved metadata to validate, got %v", err)
+	}
+}
+
+func TestResolveDescriptionFiles_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    ToolMetadata
+		wantErr string
+	}{
+		{name: "Missing File", tool: ToolMetadata{Name: "read", DescriptionFile: "missing.md"}, wantErr: "failed to read description file for tool read"},
+		{name: "Both Set", tool: ToolMetadata{Name: "read", Description: "inline", DescriptionFile: "read.md"}, wantErr: "sets both description and description_file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := McpMetadata{Tools: []ToolMetadata{tt.tool}}
+			err := meta.ResolveDescriptionFiles(t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
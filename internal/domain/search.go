@@ -1,17 +1,31 @@
 package domain
 
+import "time"
+
 // Field name constants for indexed documents
 const (
-	FieldURI      = "uri"
-	FieldName     = "name"
-	FieldContent  = "content"
-	FieldKeywords = "keywords"
+	FieldURI          = "uri"
+	FieldName         = "name"
+	FieldTitle        = "title"
+	FieldDescription  = "description"
+	FieldContent      = "content"
+	FieldKeywords     = "keywords"
+	FieldHeadings     = "headings"
+	FieldFields       = "fields"
+	FieldLastModified = "last_modified"
+	FieldBoost        = "boost"
 )
 
 // Document represents a document to index
 type Document struct {
-	URI      string   `json:"uri"`
-	Name     string   `json:"name"`
-	Content  string   `json:"content"`
-	Keywords []string `json:"keywords,omitempty"`
+	URI          string            `json:"uri"`
+	Name         string            `json:"name"`
+	Title        string            `json:"title,omitempty"`
+	Description  string            `json:"description,omitempty"` // Stored for snippets, not searched
+	Content      string            `json:"content"`
+	Keywords     []string          `json:"keywords,omitempty"`
+	Headings     []string          `json:"headings,omitempty"`      // Top-level markdown headings, extracted from Content at index time
+	Fields       map[string]string `json:"fields,omitempty"`        // Configured scalar frontmatter fields, e.g. category
+	LastModified *time.Time        `json:"last_modified,omitempty"` // Modification time of the source file, if known
+	Boost        float64           `json:"boost,omitempty"`         // Search score multiplier; 0 means unboosted
 }
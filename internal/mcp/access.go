@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+)
+
+// checkAccess reports a restricted resource as unknown to callers without a
+// matching role, so its existence is not disclosed
+func checkAccess(ctx context.Context, resourceProvider *resources.ResourceProvider, uri string) error {
+	if resourceProvider.AccessibleBy(uri, auth.RolesFromContext(ctx)) {
+		return nil
+	}
+	slog.Warn("Resource access denied", "uri", uri, "subject", auth.SubjectFromContext(ctx))
+	return fmt.Errorf("%w: %s", resources.ErrUnknownResource, uri)
+}
+
+// accessible returns the items whose resource the caller in ctx may access
+func accessible[T any](ctx context.Context, resourceProvider *resources.ResourceProvider, items []T, uriOf func(T) string) []T {
+	roles := auth.RolesFromContext(ctx)
+	var allowed []T
+	for _, item := range items {
+		if resourceProvider.AccessibleBy(uriOf(item), roles) {
+			allowed = append(allowed, item)
+		}
+	}
+	return allowed
+}
+
+// resourceListMiddleware serves resources/list from the resource provider,
+// one page of pageSize resources at a time. Hidden resources and resources the
+// caller may not access are skipped before the page is cut, so pages stay
+// full. The extra resources, such as the instructions resource, are listed on
+// the first page in addition to the content resources.
+func resourceListMiddleware(resourceProvider *resources.ResourceProvider, pageSize int, extra []*mcp.Resource) mcp.Middleware {
+	if pageSize <= 0 {
+		pageSize = mcp.DefaultPageSize
+	}
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "resources/list" {
+				return next(ctx, method, req)
+			}
+			cursor := ""
+			if params, ok := req.GetParams().(*mcp.ListResourcesParams); ok && params != nil {
+				cursor = params.Cursor
+			}
+
+			page, nextCursor, err := resourceProvider.ListResourcesPage(cursor, pageSize, auth.RolesFromContext(ctx))
+			if err != nil {
+				return nil, invalidParamsError(err)
+			}
+			result := &mcp.ListResourcesResult{NextCursor: nextCursor, Resources: []*mcp.Resource{}}
+			if cursor == "" {
+				result.Resources = append(result.Resources, extra...)
+			}
+			for i := range page {
+				result.Resources = append(result.Resources, &page[i])
+			}
+			return result, nil
+		}
+	}
+}
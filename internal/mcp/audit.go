@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
+)
+
+// AuditEntry records a tool call that accessed content
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Tool    string    `json:"tool"`
+	Subject string    `json:"subject,omitempty"` // authenticated caller, empty when unauthenticated
+	URI     string    `json:"uri,omitempty"`     // resource requested by a read
+	Query   string    `json:"query,omitempty"`   // query of a search
+	Results []string  `json:"results,omitempty"` // URIs returned by a search
+	Error   string    `json:"error,omitempty"`   // why the call failed, if it did
+}
+
+// AuditLog writes audit entries to a writer as JSON lines. A nil AuditLog
+// discards entries, so handlers can record unconditionally.
+type AuditLog struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	now     func() time.Time
+}
+
+// NewAuditLog creates an audit log writing to w
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{encoder: json.NewEncoder(w), now: time.Now}
+}
+
+// Record writes an entry stamped with the current time and the subject
+// authenticated in ctx. Write failures are logged, not returned, so auditing
+// never fails a tool call.
+func (l *AuditLog) Record(ctx context.Context, entry AuditEntry) {
+	if l == nil {
+		return
+	}
+	entry.Time = l.now().UTC()
+	entry.Subject = auth.SubjectFromContext(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.encoder.Encode(entry); err != nil {
+		slog.Error("Failed to write audit entry", "tool", entry.Tool, "error", err)
+	}
+}
+
+// errorText returns the message of err, or an empty string when it is nil
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
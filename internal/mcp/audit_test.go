@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAuditLog returns an audit log with a fixed clock and a function
+// decoding the entries written so far
+func newTestAuditLog(t *testing.T) (*AuditLog, func() []AuditEntry) {
+	t.Helper()
+	var buf bytes.Buffer
+	log := NewAuditLog(&buf)
+	log.now = func() time.Time { return time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC) }
+	return log, func() []AuditEntry {
+		var entries []AuditEntry
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry AuditEntry
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+}
+
+func TestAuditLog_Search(t *testing.T) {
+	log, entries := newTestAuditLog(t)
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			return []search.SearchResult{{Name: "A", URI: "acdc://a"}, {Name: "B", URI: "acdc://b"}}, nil
+		},
+	}
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{Audit: log})
+
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "alice"})
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, SearchToolArgument{Query: "deploy"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []AuditEntry{{
+		Time:    time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC),
+		Tool:    ToolNameSearch,
+		Subject: "alice",
+		Query:   "deploy",
+		Results: []string{"acdc://a", "acdc://b"},
+	}}, entries())
+}
+
+func TestAuditLog_Read(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "doc.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("---\nname: Doc\ndescription: D\n---\nBody"), 0644))
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://doc", Name: "Doc", FilePath: filePath},
+	})
+	log, entries := newTestAuditLog(t)
+	handler := NewReadToolHandler(resourceProvider, ReadToolOptions{Audit: log})
+
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "bob"})
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://doc"})
+	require.NoError(t, err)
+	_, _, err = handler(context.Background(), &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://missing"})
+	require.Error(t, err)
+
+	got := entries()
+	require.Len(t, got, 2)
+	assert.Equal(t, AuditEntry{Time: got[0].Time, Tool: ToolNameRead, Subject: "bob", URI: "acdc://doc"}, got[0])
+	assert.Equal(t, ToolNameRead, got[1].Tool)
+	assert.Empty(t, got[1].Subject)
+	assert.Equal(t, "acdc://missing", got[1].URI)
+	assert.Contains(t, got[1].Error, resources.ErrUnknownResource.Error())
+}
+
+func TestAuditLog_Nil(t *testing.T) {
+	var log *AuditLog
+	assert.NotPanics(t, func() {
+		log.Record(context.Background(), AuditEntry{Tool: ToolNameRead})
+	})
+}
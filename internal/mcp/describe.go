@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+// DescribeToolArgument represents arguments for describe tool (none)
+type DescribeToolArgument struct{}
+
+// ServerDescription summarizes what the server offers
+type ServerDescription struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Transport string   `json:"transport,omitempty"`
+	Resources int      `json:"resources"`
+	Prompts   int      `json:"prompts"`
+	Tools     []string `json:"tools"`
+}
+
+// RegisterDescribeTool registers the describe tool with the server.
+// countResources, when set, counts the resources a caller holding the given
+// roles may access, replacing description.Resources on each call.
+func RegisterDescribeTool(s *mcp.Server, description ServerDescription, countResources func(roles []string) int, metadata domain.ToolMetadata) {
+	mcp.AddTool(s,
+		&mcp.Tool{
+			Name:        metadata.Name,
+			Description: metadata.Description,
+			// InputSchema auto-generated from DescribeToolArgument
+		},
+		NewDescribeToolHandler(description, countResources),
+	)
+}
+
+// NewDescribeToolHandler creates the handler for the describe tool. The
+// resource count is computed per call with countResources, when set, since
+// access depends on the caller's roles and on publishing windows.
+func NewDescribeToolHandler(description ServerDescription, countResources func(roles []string) int) mcp.ToolHandlerFor[DescribeToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args DescribeToolArgument) (*mcp.CallToolResult, any, error) {
+		slog.Info("Describe request", "subject", auth.SubjectFromContext(ctx))
+
+		description := description
+		if countResources != nil {
+			description.Resources = countResources(auth.RolesFromContext(ctx))
+		}
+
+		data, err := json.MarshalIndent(description, "", "  ")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: string(data)},
+			},
+		}, nil, nil
+	}
+}
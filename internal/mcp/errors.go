@@ -0,0 +1,37 @@
+package mcp
+
+import (
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+)
+
+// toolError converts a tool handler error into a JSON-RPC error with a code
+// clients can branch on. Errors caused by the arguments, such as an unknown or
+// ambiguous resource URI, are reported as invalid params, and all other
+// failures, such as unreadable content files, as internal errors.
+//
+// The SDK returns JSON-RPC errors to the client as is, instead of wrapping
+// them in a tool result, so err must not be nil.
+func toolError(err error) error {
+	code := int64(jsonrpc.CodeInternalError)
+	if isInvalidParams(err) {
+		code = jsonrpc.CodeInvalidParams
+	}
+	return &jsonrpc.Error{Code: code, Message: err.Error()}
+}
+
+// invalidParamsError reports err as caused by invalid tool arguments
+func invalidParamsError(err error) error {
+	return &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: err.Error()}
+}
+
+// isInvalidParams reports whether err was caused by the tool arguments
+func isInvalidParams(err error) bool {
+	return errors.Is(err, resources.ErrUnknownResource) ||
+		errors.Is(err, resources.ErrAmbiguousResource) ||
+		errors.Is(err, search.ErrUnknownSnippetSource) ||
+		errors.Is(err, search.ErrUnknownFilterField)
+}
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+)
+
+// ListToolArgument represents arguments for list tool
+type ListToolArgument struct {
+	Tags []string `json:"tags,omitempty" jsonschema_description:"Optional tags a resource must all carry to be listed, compared case-insensitively. Omit to list every resource."`
+}
+
+// RegisterListTool registers the list tool with the server
+func RegisterListTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata) {
+	mcp.AddTool(s,
+		&mcp.Tool{
+			Name:        metadata.Name,
+			Description: metadata.Description,
+			// InputSchema auto-generated from ListToolArgument
+		},
+		NewListToolHandler(resourceProvider),
+	)
+}
+
+// NewListToolHandler creates the handler for the list tool
+func NewListToolHandler(resourceProvider *resources.ResourceProvider) mcp.ToolHandlerFor[ListToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListToolArgument) (*mcp.CallToolResult, any, error) {
+		slog.Info("List resources request", "tags", args.Tags, "subject", auth.SubjectFromContext(ctx))
+
+		listed := accessible(ctx, resourceProvider, resourceProvider.ListResources(args.Tags...), func(r mcp.Resource) string { return r.URI })
+
+		var sb strings.Builder
+		switch {
+		case len(listed) == 0 && len(args.Tags) > 0:
+			fmt.Fprintf(&sb, "No resources tagged '%s'", strings.Join(args.Tags, ", "))
+		case len(listed) == 0:
+			sb.WriteString("No resources found")
+		default:
+			if len(args.Tags) > 0 {
+				fmt.Fprintf(&sb, "Resources tagged '%s':\n\n", strings.Join(args.Tags, ", "))
+			} else {
+				sb.WriteString("Resources:\n\n")
+			}
+			for _, r := range listed {
+				fmt.Fprintf(&sb, "- [%s](%s): %s\n", r.Name, r.URI, r.Description)
+				if tags, ok := r.Meta["tags"].([]string); ok {
+					fmt.Fprintf(&sb, "  Tags: %s\n", strings.Join(tags, ", "))
+				}
+				sb.WriteString("\n")
+			}
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: sb.String()},
+			},
+		}, nil, nil
+	}
+}
@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+)
+
+// Supported search tool output styles
+const (
+	SearchOutputMarkdown = "markdown" // a markdown list with blank lines between results
+	SearchOutputCompact  = "compact"  // one line per result, for token efficiency
+)
+
+// renderSearchOutput renders a search response in the given style, noting
+// the number of matches left out by the result limit when more is positive.
+// Unknown styles render as SearchOutputMarkdown.
+func renderSearchOutput(query string, response search.SearchResponse, more int, style string) string {
+	compact := style == SearchOutputCompact
+	separator := "\n\n"
+	if compact {
+		separator = "\n"
+	}
+
+	var sb strings.Builder
+	if response.DroppedTerms > 0 {
+		fmt.Fprintf(&sb, "Note: the query was too long; the last %d term(s) were ignored.%s", response.DroppedTerms, separator)
+	}
+	if len(response.Results) == 0 {
+		fmt.Fprintf(&sb, "No results found for '%s'", query)
+		if len(response.Suggestions) > 0 {
+			fmt.Fprintf(&sb, ". Did you mean: %s?", strings.Join(response.Suggestions, ", "))
+		}
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "Search results for '%s':%s", query, separator)
+	for _, r := range response.Results {
+		if compact {
+			fmt.Fprintf(&sb, "- [%s](%s): %s", r.Name, r.URI, strings.Join(strings.Fields(r.Snippet), " "))
+			if len(r.MatchedKeywords) > 0 {
+				fmt.Fprintf(&sb, " [keywords: %s]", strings.Join(r.MatchedKeywords, ", "))
+			}
+			sb.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(&sb, "- [%s](%s): %s\n", r.Name, r.URI, r.Snippet)
+		if len(r.MatchedKeywords) > 0 {
+			fmt.Fprintf(&sb, "  Matched keywords: %s\n", strings.Join(r.MatchedKeywords, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	if more > 0 {
+		fmt.Fprintf(&sb, "(%d more results not shown; refine your query)\n", more)
+	}
+	return sb.String()
+}
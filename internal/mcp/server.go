@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
@@ -15,35 +16,193 @@ const (
 	ToolNameSearch = "search"
 	// ToolNameRead is the name of the read tool
 	ToolNameRead = "read"
+	// ToolNameRelated is the name of the related tool
+	ToolNameRelated = "related"
+	// ToolNameLinks is the name of the links tool
+	ToolNameLinks = "links"
+	// ToolNameStat is the name of the stat tool
+	ToolNameStat = "stat"
+	// ToolNameList is the name of the list tool
+	ToolNameList = "list"
+	// ToolNameDescribe is the name of the describe tool
+	ToolNameDescribe = "describe"
 )
 
+// ServerOption configures optional server details
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	transport  string
+	toolPrefix string
+	search     SearchToolOptions
+	read       ReadToolOptions
+	pageSize   int
+
+	instructionsURI   string
+	metadataToolsOnly bool
+}
+
+// toolName returns the registered name of a built-in tool, applying the tool prefix if set
+func (o serverOptions) toolName(name string) string {
+	if o.toolPrefix == "" {
+		return name
+	}
+	return o.toolPrefix + "_" + name
+}
+
+// toolEnabled reports whether a built-in tool is registered. All tools are,
+// unless only the tools listed in the metadata are enabled and the metadata
+// has a tools section; an explicitly empty section then enables none.
+func (o serverOptions) toolEnabled(metadata domain.McpMetadata, name string) bool {
+	if !o.metadataToolsOnly || metadata.Tools == nil {
+		return true
+	}
+	return metadata.HasTool(name)
+}
+
+// toolMetadata resolves metadata for a built-in tool by its unprefixed name
+// and applies the tool prefix to the registered name
+func (o serverOptions) toolMetadata(metadata domain.McpMetadata, name string) domain.ToolMetadata {
+	md := metadata.GetToolMetadata(name)
+	md.Name = o.toolName(name)
+	return md
+}
+
+// WithTransport records the transport the server is exposed over, for introspection
+func WithTransport(transport string) ServerOption {
+	return func(o *serverOptions) {
+		o.transport = transport
+	}
+}
+
+// WithToolPrefix namespaces the built-in tool names (e.g. "docs" registers
+// "docs_search"), avoiding collisions when several servers are aggregated.
+// Metadata overrides still refer to tools by their unprefixed names.
+func WithToolPrefix(prefix string) ServerOption {
+	return func(o *serverOptions) {
+		o.toolPrefix = prefix
+	}
+}
+
+// WithSearchTimeout bounds the execution time of each search tool call.
+// A non-positive timeout disables the limit.
+func WithSearchTimeout(timeout time.Duration) ServerOption {
+	return func(o *serverOptions) {
+		o.search.Timeout = timeout
+	}
+}
+
+// WithSearchSuggestions makes the search tool suggest alternative query terms
+// when a search finds nothing.
+func WithSearchSuggestions() ServerOption {
+	return func(o *serverOptions) {
+		o.search.Suggest = true
+	}
+}
+
+// WithMaxReadBytes truncates content returned by the read tool to maxBytes
+// bytes, with a notice telling the caller how to continue. A non-positive
+// value disables the limit.
+func WithMaxReadBytes(maxBytes int) ServerOption {
+	return func(o *serverOptions) {
+		o.read.MaxBytes = maxBytes
+	}
+}
+
+// WithSearchOutputStyle sets how the search tool formats its results, one of
+// SearchOutputMarkdown (the default) or SearchOutputCompact.
+func WithSearchOutputStyle(style string) ServerOption {
+	return func(o *serverOptions) {
+		o.search.OutputStyle = style
+	}
+}
+
+// WithAuditLog records search and read tool calls, with the authenticated
+// caller, in the given audit log.
+func WithAuditLog(log *AuditLog) ServerOption {
+	return func(o *serverOptions) {
+		o.search.Audit = log
+		o.read.Audit = log
+	}
+}
+
+// WithPageSize sets the maximum number of items returned per page by the
+// list methods (resources, prompts, and tools). Clients page through larger
+// lists with the returned cursor. A non-positive size uses the SDK default.
+func WithPageSize(size int) ServerOption {
+	return func(o *serverOptions) {
+		o.pageSize = max(size, 0)
+	}
+}
+
+// WithInstructionsResource publishes the server instructions as a readable
+// resource at the given URI, for clients that do not surface them.
+func WithInstructionsResource(uri string) ServerOption {
+	return func(o *serverOptions) {
+		o.instructionsURI = uri
+	}
+}
+
+// WithMetadataToolsOnly registers only the built-in tools listed in the
+// metadata tools section, so an explicitly empty section registers no tools.
+// Without a tools section, all tools are still registered.
+func WithMetadataToolsOnly() ServerOption {
+	return func(o *serverOptions) {
+		o.metadataToolsOnly = true
+	}
+}
+
 // CreateServer creates and configures the MCP server
 func CreateServer(
 	metadata domain.McpMetadata,
 	resourceProvider *resources.ResourceProvider,
 	promptProvider *prompts.PromptProvider,
 	searchService search.Searcher,
+	opts ...ServerOption,
 ) *mcp.Server {
+	var options serverOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Create server with official SDK
 	s := mcp.NewServer(&mcp.Implementation{
 		Name:    metadata.Server.Name,
 		Version: metadata.Server.Version,
-	}, nil)
+	}, &mcp.ServerOptions{PageSize: options.pageSize})
 	// Note: Instructions are stored in metadata but not directly supported by official SDK
 
-	// Register Resources
-	for _, res := range resourceProvider.ListResources() {
+	// Register Resources. Hidden resources are registered so they can be
+	// read by URI, and are left out of listings by resourceListMiddleware.
+	for _, res := range append(resourceProvider.ListResources(), resourceProvider.HiddenResources()...) {
 		// Capture uri for closure
 		uri := res.URI
 
 		s.AddResource(&mcp.Resource{
 			URI:         uri,
 			Name:        res.Name,
+			Title:       res.Title,
 			Description: res.Description,
 			MIMEType:    res.MIMEType,
-		}, makeResourceHandler(resourceProvider, uri))
+			Meta:        res.Meta,
+		}, makeResourceHandler(resourceProvider, uri, res.MIMEType))
 	}
 
+	var extraResources []*mcp.Resource
+	if options.instructionsURI != "" {
+		instructions := &mcp.Resource{
+			URI:         options.instructionsURI,
+			Name:        "instructions",
+			Title:       "Server Instructions",
+			Description: "Usage instructions for this server",
+			MIMEType:    "text/markdown",
+		}
+		s.AddResource(instructions, makeInstructionsHandler(options.instructionsURI, metadata.Server.Instructions))
+		extraResources = append(extraResources, instructions)
+		slog.Info("Registered instructions resource", "uri", options.instructionsURI)
+	}
+	s.AddReceivingMiddleware(resourceListMiddleware(resourceProvider, options.pageSize, extraResources))
+
 	// Register Prompts
 	for _, p := range promptProvider.ListPrompts() {
 		// Capture name for closure
@@ -53,17 +212,47 @@ func CreateServer(
 			Name:        name,
 			Description: p.Description,
 			Arguments:   p.Arguments,
+			Meta:        p.Meta,
 		}, makePromptHandler(promptProvider, name))
 
 		slog.Info("Registered prompt", "name", name)
 	}
 
 	// Register Tools
-	RegisterSearchTool(s, searchService, metadata.GetToolMetadata(ToolNameSearch))
-	slog.Info("Registered tool", "name", ToolNameSearch)
-
-	RegisterReadTool(s, resourceProvider, metadata.GetToolMetadata(ToolNameRead))
-	slog.Info("Registered tool", "name", ToolNameRead)
+	options.search.AccessibleBy = resourceProvider.AccessibleBy
+	options.search.Restricted = resourceProvider.HasRestricted()
+	var toolNames []string
+	tools := []struct {
+		name     string
+		register func(domain.ToolMetadata)
+	}{
+		{ToolNameSearch, func(md domain.ToolMetadata) { RegisterSearchTool(s, searchService, md, options.search) }},
+		{ToolNameRead, func(md domain.ToolMetadata) { RegisterReadTool(s, resourceProvider, md, options.read) }},
+		{ToolNameRelated, func(md domain.ToolMetadata) { RegisterRelatedTool(s, resourceProvider, md) }},
+		{ToolNameLinks, func(md domain.ToolMetadata) { RegisterLinksTool(s, resourceProvider, md) }},
+		{ToolNameStat, func(md domain.ToolMetadata) { RegisterStatTool(s, resourceProvider, md) }},
+		{ToolNameList, func(md domain.ToolMetadata) { RegisterListTool(s, resourceProvider, md) }},
+		{ToolNameDescribe, func(md domain.ToolMetadata) {
+			RegisterDescribeTool(s, ServerDescription{
+				Name:      metadata.Server.Name,
+				Version:   metadata.Server.Version,
+				Transport: options.transport,
+				Prompts:   len(promptProvider.ListPrompts()),
+				Tools:     toolNames,
+			}, resourceProvider.CountListed, md)
+		}},
+	}
+	for _, t := range tools {
+		if options.toolEnabled(metadata, t.name) {
+			toolNames = append(toolNames, options.toolName(t.name))
+		}
+	}
+	for _, t := range tools {
+		if options.toolEnabled(metadata, t.name) {
+			t.register(options.toolMetadata(metadata, t.name))
+			slog.Info("Registered tool", "name", options.toolName(t.name))
+		}
+	}
 
 	return s
 }
@@ -6,13 +6,17 @@ import (
 	"log/slog"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
 	"github.com/sha1n/mcp-acdc-server/internal/prompts"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
 )
 
-func makeResourceHandler(resourceProvider *resources.ResourceProvider, uri string) mcp.ResourceHandler {
+func makeResourceHandler(resourceProvider *resources.ResourceProvider, uri, mimeType string) mcp.ResourceHandler {
 	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-		slog.Info("Resource request", "uri", uri)
+		slog.Info("Resource request", "uri", uri, "subject", auth.SubjectFromContext(ctx))
+		if err := checkAccess(ctx, resourceProvider, uri); err != nil {
+			return nil, err
+		}
 		content, err := resourceProvider.ReadResource(uri)
 		if err != nil {
 			slog.Error("Resource read failed", "uri", uri, "error", err)
@@ -21,8 +25,32 @@ func makeResourceHandler(resourceProvider *resources.ResourceProvider, uri strin
 		return &mcp.ReadResourceResult{
 			Contents: []*mcp.ResourceContents{{
 				URI:      uri,
-				MIMEType: "text/markdown",
+				MIMEType: mimeType,
 				Text:     content,
+				Meta:     idMeta(resourceProvider, uri),
+			}},
+		}, nil
+	}
+}
+
+// idMeta returns response metadata carrying the stable ID of the resource at
+// uri, or nil when the resource has none
+func idMeta(resourceProvider *resources.ResourceProvider, uri string) mcp.Meta {
+	defn, err := resourceProvider.StatResource(uri)
+	if err != nil || defn.ID == "" {
+		return nil
+	}
+	return mcp.Meta{"id": defn.ID}
+}
+
+func makeInstructionsHandler(uri string, instructions string) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		slog.Info("Resource request", "uri", uri, "subject", auth.SubjectFromContext(ctx))
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{
+				URI:      uri,
+				MIMEType: "text/markdown",
+				Text:     instructions,
 			}},
 		}, nil
 	}
@@ -30,7 +58,7 @@ func makeResourceHandler(resourceProvider *resources.ResourceProvider, uri strin
 
 func makePromptHandler(promptProvider *prompts.PromptProvider, name string) mcp.PromptHandler {
 	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-		slog.Info("Prompt request", "name", name)
+		slog.Info("Prompt request", "name", name, "subject", auth.SubjectFromContext(ctx))
 		messages, err := promptProvider.GetPrompt(name, req.Params.Arguments)
 		if err != nil {
 			slog.Error("Prompt retrieval failed", "name", name, "error", err)
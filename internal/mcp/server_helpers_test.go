@@ -33,7 +33,7 @@ func TestMakeResourceHandler_Success(t *testing.T) {
 		},
 	})
 
-	handler := makeResourceHandler(resourceProvider, "acdc://test-resource")
+	handler := makeResourceHandler(resourceProvider, "acdc://test-resource", "text/markdown")
 	require.NotNil(t, handler)
 
 	ctx := context.Background()
@@ -56,7 +56,7 @@ func TestMakeResourceHandler_Success(t *testing.T) {
 func TestMakeResourceHandler_Error_NotFound(t *testing.T) {
 	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{})
 
-	handler := makeResourceHandler(resourceProvider, "acdc://nonexistent")
+	handler := makeResourceHandler(resourceProvider, "acdc://nonexistent", "text/markdown")
 	require.NotNil(t, handler)
 
 	ctx := context.Background()
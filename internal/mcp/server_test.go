@@ -2,8 +2,15 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/sha1n/mcp-acdc-server/internal/prompts"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
@@ -35,10 +42,181 @@ func TestCreateServer(t *testing.T) {
 	}
 }
 
+func TestCreateServer_ToolPrefix(t *testing.T) {
+	metadata := domain.McpMetadata{
+		Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0", Instructions: "Run tests"},
+		Tools: []domain.ToolMetadata{
+			{Name: "search", Description: "Custom search description"},
+		},
+	}
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{})
+	promptProvider := prompts.NewPromptProvider([]prompts.PromptDefinition{}, nil)
+
+	tools := listTools(t, CreateServer(metadata, resourceProvider, promptProvider, &mockSearcher{}, WithToolPrefix("docs")))
+
+	for _, name := range []string{"docs_search", "docs_read", "docs_related", "docs_links", "docs_stat", "docs_describe"} {
+		if _, ok := tools[name]; !ok {
+			t.Errorf("Expected tool %q to be registered, got %v", name, tools)
+		}
+	}
+	if _, ok := tools["search"]; ok {
+		t.Error("Unprefixed tool name should not be registered")
+	}
+
+	// Overrides are resolved by the unprefixed name
+	if got := tools["docs_search"].Description; got != "Custom search description" {
+		t.Errorf("Expected overridden description, got %q", got)
+	}
+	// Defaults are resolved by the unprefixed name
+	if got := tools["docs_read"].Description; got != domain.DefaultToolMetadata[ToolNameRead].Description {
+		t.Errorf("Expected default read description, got %q", got)
+	}
+}
+
+func TestCreateServer_MetadataToolsOnly(t *testing.T) {
+	allTools := []string{"search", "read", "related", "links", "stat", "list", "describe"}
+	tests := []struct {
+		name      string
+		tools     []domain.ToolMetadata
+		toolsOnly bool
+		want      []string
+	}{
+		{name: "Absent Uses Defaults", tools: nil, want: allTools},
+		{name: "Empty Uses Defaults", tools: []domain.ToolMetadata{}, want: allTools},
+		{name: "Listed Overrides Only", tools: []domain.ToolMetadata{{Name: "search", Description: "S"}}, want: allTools},
+		{name: "Tools Only Absent Uses Defaults", tools: nil, toolsOnly: true, want: allTools},
+		{name: "Tools Only Empty Registers Nothing", tools: []domain.ToolMetadata{}, toolsOnly: true, want: nil},
+		{name: "Tools Only Listed", tools: []domain.ToolMetadata{{Name: "read", Description: "R"}, {Name: "describe", Description: "D"}}, toolsOnly: true, want: []string{"read", "describe"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := domain.McpMetadata{
+				Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0", Instructions: "Run tests"},
+				Tools:  tt.tools,
+			}
+			var opts []ServerOption
+			if tt.toolsOnly {
+				opts = append(opts, WithMetadataToolsOnly())
+			}
+			server := CreateServer(metadata, resources.NewResourceProvider(nil), prompts.NewPromptProvider(nil, nil), &mockSearcher{}, opts...)
+
+			session := connectClient(t, server)
+			if len(tt.want) == 0 {
+				if caps := session.InitializeResult().Capabilities; caps.Tools != nil {
+					t.Errorf("Expected no tools capability, got %+v", caps.Tools)
+				}
+				return
+			}
+			result, err := session.ListTools(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("ListTools failed: %v", err)
+			}
+			var got []string
+			for _, tool := range result.Tools {
+				got = append(got, tool.Name)
+			}
+			slices.Sort(got)
+			want := slices.Sorted(slices.Values(tt.want))
+			if !slices.Equal(got, want) {
+				t.Errorf("Expected tools %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestCreateServer_ResourceListPagination(t *testing.T) {
+	metadata := domain.McpMetadata{
+		Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0", Instructions: "Run tests"},
+	}
+	var defs []resources.ResourceDefinition
+	for i := 0; i < 5; i++ {
+		defs = append(defs, resources.ResourceDefinition{URI: fmt.Sprintf("acdc://doc-%d", i), Name: fmt.Sprintf("doc-%d", i)})
+	}
+	defs = append(defs,
+		resources.ResourceDefinition{URI: "acdc://doc-hidden", Name: "doc-hidden", Hidden: true},
+		resources.ResourceDefinition{URI: "acdc://doc-internal-1", Name: "doc-internal-1", Roles: []string{"internal"}},
+		resources.ResourceDefinition{URI: "acdc://doc-internal-2", Name: "doc-internal-2", Roles: []string{"internal"}},
+	)
+	resourceProvider := resources.NewResourceProvider(defs)
+	promptProvider := prompts.NewPromptProvider([]prompts.PromptDefinition{}, nil)
+
+	session := connectClient(t, CreateServer(metadata, resourceProvider, promptProvider, &mockSearcher{}, WithPageSize(2)))
+
+	var uris []string
+	params := &mcp.ListResourcesParams{}
+	pages := 0
+	for {
+		result, err := session.ListResources(context.Background(), params)
+		if err != nil {
+			t.Fatalf("ListResources failed: %v", err)
+		}
+		pages++
+		if len(result.Resources) > 2 || (result.NextCursor != "" && len(result.Resources) != 2) {
+			t.Fatalf("Expected full pages of 2 resources, got %d", len(result.Resources))
+		}
+		for _, r := range result.Resources {
+			uris = append(uris, r.URI)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		params.Cursor = result.NextCursor
+	}
+
+	want := []string{"acdc://doc-0", "acdc://doc-1", "acdc://doc-2", "acdc://doc-3", "acdc://doc-4"}
+	if pages != 3 || !slices.Equal(uris, want) {
+		t.Errorf("Expected %v over 3 pages, got %v over %d", want, uris, pages)
+	}
+}
+
+// connectClient connects an in-memory client to the server, closing both sessions when the test ends
+func connectClient(t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	return connectClientWithContext(t, server, context.Background())
+}
+
+// connectClientWithContext connects an in-memory client to a server session
+// created with ctx, e.g. to carry an authenticated identity
+func connectClientWithContext(t *testing.T, server *mcp.Server, ctx context.Context) *mcp.ClientSession {
+	t.Helper()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Server connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Client connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = clientSession.Close() })
+
+	return clientSession
+}
+
+// listTools connects an in-memory client to the server and returns its tools by name
+func listTools(t *testing.T, server *mcp.Server) map[string]*mcp.Tool {
+	t.Helper()
+
+	result, err := connectClient(t, server).ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+
+	tools := make(map[string]*mcp.Tool, len(result.Tools))
+	for _, tool := range result.Tools {
+		tools[tool.Name] = tool
+	}
+	return tools
+}
+
 type mockSearcher struct{}
 
-func (m *mockSearcher) Search(query string, options *int) ([]search.SearchResult, error) {
-	return nil, nil
+func (m *mockSearcher) Search(ctx context.Context, query string, opts search.SearchOptions) (search.SearchResponse, error) {
+	return search.SearchResponse{}, nil
 }
 
 func (m *mockSearcher) Close() {}
@@ -49,3 +227,188 @@ func (m *mockSearcher) Index(ctx context.Context, docs <-chan domain.Document) e
 	}
 	return nil
 }
+
+func TestCreateServer_InstructionsResource(t *testing.T) {
+	metadata := domain.McpMetadata{
+		Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0", Instructions: "Search before you code"},
+	}
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{{URI: "acdc://doc", Name: "doc"}})
+	promptProvider := prompts.NewPromptProvider([]prompts.PromptDefinition{}, nil)
+
+	session := connectClient(t, CreateServer(metadata, resourceProvider, promptProvider, &mockSearcher{}, WithInstructionsResource("acdc://instructions")))
+
+	list, err := session.ListResources(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	found := false
+	for _, r := range list.Resources {
+		found = found || r.URI == "acdc://instructions"
+	}
+	if !found || len(list.Resources) != 2 {
+		t.Fatalf("Expected the instructions resource alongside the content resource, got %d resources", len(list.Resources))
+	}
+
+	result, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "acdc://instructions"})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if len(result.Contents) != 1 || result.Contents[0].Text != metadata.Server.Instructions {
+		t.Errorf("Expected instructions %q, got %+v", metadata.Server.Instructions, result.Contents)
+	}
+}
+
+func TestCreateServer_NoInstructionsResourceByDefault(t *testing.T) {
+	metadata := domain.McpMetadata{
+		Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0", Instructions: "Search before you code"},
+	}
+	resourceProvider := resources.NewResourceProvider(nil)
+	promptProvider := prompts.NewPromptProvider([]prompts.PromptDefinition{}, nil)
+
+	list, err := connectClient(t, CreateServer(metadata, resourceProvider, promptProvider, &mockSearcher{})).ListResources(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(list.Resources) != 0 {
+		t.Errorf("Expected no resources, got %d", len(list.Resources))
+	}
+}
+
+func TestCreateServer_PublishingWindow(t *testing.T) {
+	metadata := domain.McpMetadata{Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0"}}
+	file := filepath.Join(t.TempDir(), "launch.md")
+	_ = os.WriteFile(file, []byte("---\nname: launch\n---\nLaunch notes"), 0644)
+	publishAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expireAt := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		wantList int
+	}{
+		{name: "Before", now: publishAt.Add(-time.Hour), wantList: 1},
+		{name: "Within", now: publishAt.Add(time.Hour), wantList: 2},
+		{name: "After", now: expireAt.Add(time.Hour), wantList: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+				{URI: "acdc://always", Name: "always"},
+				{URI: "acdc://launch", Name: "launch", FilePath: file, PublishAt: publishAt, ExpireAt: expireAt},
+			}, resources.WithClock(func() time.Time { return tt.now }))
+			server := CreateServer(metadata, resourceProvider, prompts.NewPromptProvider(nil, nil), &mockSearcher{})
+			session := connectClientWithContext(t, server, context.Background())
+
+			list, err := session.ListResources(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("ListResources failed: %v", err)
+			}
+			if len(list.Resources) != tt.wantList {
+				t.Errorf("Expected %d listed resources, got %d", tt.wantList, len(list.Resources))
+			}
+
+			_, err = session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "acdc://launch"})
+			if wantRead := tt.wantList == 2; (err == nil) != wantRead {
+				t.Errorf("Expected read allowed=%v, got error %v", wantRead, err)
+			}
+		})
+	}
+}
+
+func TestCreateServer_ResourceRoles(t *testing.T) {
+	metadata := domain.McpMetadata{Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0"}}
+	file := filepath.Join(t.TempDir(), "internal.md")
+	_ = os.WriteFile(file, []byte("---\nname: internal\n---\nInternal notes"), 0644)
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://public", Name: "public"},
+		{URI: "acdc://internal", Name: "internal", FilePath: file, Roles: []string{"internal"}},
+	})
+	server := CreateServer(metadata, resourceProvider, prompts.NewPromptProvider(nil, nil), &mockSearcher{})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantURIs int
+		wantRead bool
+	}{
+		{name: "Anonymous", ctx: context.Background(), wantURIs: 1, wantRead: false},
+		{name: "Without Role", ctx: auth.WithIdentity(context.Background(), auth.Identity{Subject: "bob", Roles: []string{"external"}}), wantURIs: 1, wantRead: false},
+		{name: "With Role", ctx: auth.WithIdentity(context.Background(), auth.Identity{Subject: "alice", Roles: []string{"internal"}}), wantURIs: 2, wantRead: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := connectClientWithContext(t, server, tt.ctx)
+
+			list, err := session.ListResources(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("ListResources failed: %v", err)
+			}
+			if len(list.Resources) != tt.wantURIs {
+				t.Errorf("Expected %d listed resources, got %d", tt.wantURIs, len(list.Resources))
+			}
+
+			_, err = session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "acdc://internal"})
+			if (err == nil) != tt.wantRead {
+				t.Errorf("Expected read allowed=%v, got error %v", tt.wantRead, err)
+			}
+		})
+	}
+}
+
+func TestCreateServer_HiddenResource(t *testing.T) {
+	metadata := domain.McpMetadata{Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0"}}
+	file := filepath.Join(t.TempDir(), "partial.md")
+	_ = os.WriteFile(file, []byte("---\nname: partial\nhidden: true\n---\nShared snippet"), 0644)
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://guide", Name: "guide"},
+		{URI: "acdc://partial", Name: "partial", FilePath: file, Hidden: true},
+	})
+	session := connectClient(t, CreateServer(metadata, resourceProvider, prompts.NewPromptProvider(nil, nil), &mockSearcher{}))
+
+	list, err := session.ListResources(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(list.Resources) != 1 || list.Resources[0].URI != "acdc://guide" {
+		t.Errorf("Expected only the visible resource to be listed, got %+v", list.Resources)
+	}
+
+	result, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "acdc://partial"})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if len(result.Contents) != 1 || result.Contents[0].Text != "Shared snippet" {
+		t.Errorf("Expected hidden resource content, got %+v", result.Contents)
+	}
+}
+
+func TestCreateServer_PromptArgumentSchema(t *testing.T) {
+	metadata := domain.McpMetadata{Server: domain.ServerMetadata{Name: "test-server", Version: "1.0.0"}}
+	promptProvider := prompts.NewPromptProvider([]prompts.PromptDefinition{
+		{
+			Name:        "deploy",
+			Description: "Deploy a service",
+			Arguments: []prompts.PromptArgument{
+				{Name: "env", Description: "Target environment", Required: true, Enum: []string{"staging", "production"}},
+			},
+		},
+	}, nil)
+	session := connectClient(t, CreateServer(metadata, resources.NewResourceProvider(nil), promptProvider, &mockSearcher{}))
+
+	list, err := session.ListPrompts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListPrompts failed: %v", err)
+	}
+	if len(list.Prompts) != 1 {
+		t.Fatalf("Expected 1 prompt, got %d", len(list.Prompts))
+	}
+	schema, ok := list.Prompts[0].Meta["argument_schema"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected an argument schema in the prompt meta, got %+v", list.Prompts[0].Meta)
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	env, _ := properties["env"].(map[string]any)
+	if enum, _ := env["enum"].([]any); len(enum) != 2 {
+		t.Errorf("Expected the env argument enum in the schema, got %+v", schema)
+	}
+}
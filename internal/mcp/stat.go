@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+)
+
+// StatToolArgument represents arguments for stat tool
+type StatToolArgument struct {
+	URI string `json:"uri" jsonschema_description:"The acdc:// URI of the resource to check. A unique resource name is also accepted."`
+}
+
+// ResourceStat reports whether a resource exists and, if so, its metadata
+type ResourceStat struct {
+	URI          string     `json:"uri"`
+	ID           string     `json:"id,omitempty"`
+	Exists       bool       `json:"exists"`
+	Name         string     `json:"name,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	MIMEType     string     `json:"mime_type,omitempty"`
+	Size         int64      `json:"size,omitempty"`
+	LastModified *time.Time `json:"last_modified,omitempty"`
+}
+
+// RegisterStatTool registers the stat tool with the server
+func RegisterStatTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata) {
+	mcp.AddTool(s,
+		&mcp.Tool{
+			Name:        metadata.Name,
+			Description: metadata.Description,
+			// InputSchema auto-generated from StatToolArgument
+		},
+		NewStatToolHandler(resourceProvider),
+	)
+}
+
+// NewStatToolHandler creates the handler for the stat tool.
+// Metadata is served from the definitions recorded at discovery, so the
+// resource content is never read. Unknown resources are reported as not
+// existing rather than as errors.
+func NewStatToolHandler(resourceProvider *resources.ResourceProvider) mcp.ToolHandlerFor[StatToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args StatToolArgument) (*mcp.CallToolResult, any, error) {
+		slog.Info("Stat request", "uri", args.URI, "subject", auth.SubjectFromContext(ctx))
+
+		stat := ResourceStat{URI: args.URI}
+		defn, err := resourceProvider.StatResource(args.URI)
+		if err == nil {
+			err = checkAccess(ctx, resourceProvider, args.URI)
+		}
+		switch {
+		case errors.Is(err, resources.ErrUnknownResource):
+			// reported as not existing
+		case err != nil:
+			slog.Error("Stat failed", "uri", args.URI, "error", err)
+			return nil, nil, toolError(err)
+		default:
+			stat = ResourceStat{
+				URI:         defn.URI,
+				ID:          defn.ID,
+				Exists:      true,
+				Name:        defn.Name,
+				Title:       defn.Title,
+				Description: defn.Description,
+				MIMEType:    defn.MIMEType,
+				Size:        defn.Size,
+			}
+			if !defn.LastModified.IsZero() {
+				lastModified := defn.LastModified
+				stat.LastModified = &lastModified
+			}
+		}
+
+		data, err := json.MarshalIndent(stat, "", "  ")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: string(data)},
+			},
+		}, nil, nil
+	}
+}
@@ -2,11 +2,16 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
 	"github.com/sha1n/mcp-acdc-server/internal/search"
@@ -14,84 +19,363 @@ import (
 
 // SearchToolArgument represents arguments for search tool
 type SearchToolArgument struct {
-	Query string `json:"query" jsonschema_description:"The search query. Use natural language or keywords."`
+	Query         string            `json:"query" jsonschema_description:"The search query. Use natural language or keywords."`
+	Limit         *int              `json:"limit,omitempty" jsonschema_description:"Optional maximum number of results to return. Capped by the server's configured maximum."`
+	Filters       map[string]string `json:"filters,omitempty" jsonschema_description:"Optional exact-match filters on frontmatter fields configured for search, e.g. {\"category\": \"runbook\"}."`
+	Since         string            `json:"since,omitempty" jsonschema_description:"Optional cutoff excluding resources last modified before it. Accepts a date (2006-01-02), an RFC 3339 timestamp, or a relative age such as 7d, 2w, or 36h."`
+	SnippetSource string            `json:"snippet_source,omitempty" jsonschema_description:"Optional snippet content: body (default) for an excerpt around the match, description for the resource description, or auto for an excerpt when the body matched and the description otherwise."`
+	URIs          []string          `json:"uris,omitempty" jsonschema_description:"Optional resource URIs to search within. Only these resources are returned; an empty list matches nothing."`
 }
 
 // ReadToolArgument represents arguments for read tool
 type ReadToolArgument struct {
-	URI string `json:"uri" jsonschema_description:"The acdc:// URI of the resource to fetch"`
+	URI         string `json:"uri" jsonschema_description:"The acdc:// URI of the resource to fetch. A unique resource name is also accepted."`
+	IfNoneMatch string `json:"if_none_match,omitempty" jsonschema_description:"Optional ETag from a previous read. If the content has not changed, a short unchanged marker is returned instead of the content."`
+	Offset      int    `json:"offset,omitempty" jsonschema_description:"Optional byte offset to start reading at, to continue a read whose content was truncated."`
+}
+
+// RelatedToolArgument represents arguments for related tool
+type RelatedToolArgument struct {
+	URI   string `json:"uri" jsonschema_description:"The acdc:// URI of the resource to find related resources for"`
+	Limit *int   `json:"limit,omitempty" jsonschema_description:"Optional maximum number of related resources to return (default: 5)"`
+}
+
+// LinksToolArgument represents arguments for links tool
+type LinksToolArgument struct {
+	URI     string `json:"uri" jsonschema_description:"The acdc:// URI of the resource to list links for"`
+	Inbound bool   `json:"inbound,omitempty" jsonschema_description:"Also list the resources that link to this resource"`
+}
+
+// truncatedNoticeFormat is appended by the read tool to content cut at the read budget
+const truncatedNoticeFormat = "\n\n...[truncated, use offset %d to continue]"
+
+// unchangedMarkerFormat is returned by the read tool when if_none_match matches the current ETag
+const unchangedMarkerFormat = "Resource '%s' is unchanged."
+
+// sinceDayUnits maps relative age suffixes not supported by time.ParseDuration to their length
+var sinceDayUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// parseSince converts a search since argument to an absolute cutoff.
+// It accepts a date, an RFC 3339 timestamp, or an age relative to now
+// given in days (7d), weeks (2w), or as a Go duration (36h).
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, now.Location()); err == nil {
+		return t, nil
+	}
+	if unit, ok := sinceDayUnits[value[len(value)-1:]]; ok {
+		if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n >= 0 {
+			return now.Add(-time.Duration(n) * unit), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid since value %q: expected a date, an RFC 3339 timestamp, or a relative age such as 7d", value)
+}
+
+// defaultRelatedLimit is the number of related resources returned when no limit is given
+const defaultRelatedLimit = 5
+
+// SearchToolOptions configures the behavior of the search tool
+type SearchToolOptions struct {
+	// Timeout bounds the execution time of each search when positive
+	Timeout time.Duration
+	// Suggest includes alternative query terms when a search finds nothing
+	Suggest bool
+	// AccessibleBy, when set, drops results the caller's roles do not grant access to
+	AccessibleBy func(uri string, roles []string) bool
+	// Restricted withholds the number of results cut off by the result limit
+	// and query suggestions, since they could disclose resources the caller
+	// may not access
+	Restricted bool
+	// OutputStyle is one of the SearchOutput constants; empty means SearchOutputMarkdown
+	OutputStyle string
+	// Audit, when set, records every search with the URIs it returned
+	Audit *AuditLog
+}
+
+// ReadToolOptions configures the read tool
+type ReadToolOptions struct {
+	// MaxBytes truncates returned content to this many bytes when positive
+	MaxBytes int
+	// Audit, when set, records every read
+	Audit *AuditLog
 }
 
 // RegisterSearchTool registers the search tool with the server
-func RegisterSearchTool(s *mcp.Server, searchService search.Searcher, metadata domain.ToolMetadata) {
+func RegisterSearchTool(s *mcp.Server, searchService search.Searcher, metadata domain.ToolMetadata, options SearchToolOptions) {
 	mcp.AddTool(s,
 		&mcp.Tool{
 			Name:        metadata.Name,
 			Description: metadata.Description,
 			// InputSchema auto-generated from SearchToolArgument
 		},
-		NewSearchToolHandler(searchService),
+		NewSearchToolHandler(searchService, options),
 	)
 }
 
 // RegisterReadTool registers the read tool with the server
-func RegisterReadTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata) {
+func RegisterReadTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata, options ReadToolOptions) {
 	mcp.AddTool(s,
 		&mcp.Tool{
 			Name:        metadata.Name,
 			Description: metadata.Description,
 			// InputSchema auto-generated from ReadToolArgument
 		},
-		NewReadToolHandler(resourceProvider),
+		NewReadToolHandler(resourceProvider, options),
+	)
+}
+
+// RegisterRelatedTool registers the related tool with the server
+func RegisterRelatedTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata) {
+	mcp.AddTool(s,
+		&mcp.Tool{
+			Name:        metadata.Name,
+			Description: metadata.Description,
+			// InputSchema auto-generated from RelatedToolArgument
+		},
+		NewRelatedToolHandler(resourceProvider),
+	)
+}
+
+// RegisterLinksTool registers the links tool with the server
+func RegisterLinksTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata) {
+	mcp.AddTool(s,
+		&mcp.Tool{
+			Name:        metadata.Name,
+			Description: metadata.Description,
+			// InputSchema auto-generated from LinksToolArgument
+		},
+		NewLinksToolHandler(resourceProvider),
 	)
 }
 
 // NewSearchToolHandler creates the handler for the search tool
-func NewSearchToolHandler(searchService search.Searcher) mcp.ToolHandlerFor[SearchToolArgument, any] {
-	return func(ctx context.Context, req *mcp.CallToolRequest, args SearchToolArgument) (*mcp.CallToolResult, any, error) {
+func NewSearchToolHandler(searchService search.Searcher, options SearchToolOptions) mcp.ToolHandlerFor[SearchToolArgument, any] {
+	timeout := options.Timeout
+	return func(ctx context.Context, req *mcp.CallToolRequest, args SearchToolArgument) (_ *mcp.CallToolResult, _ any, err error) {
 		// Args are already validated and unmarshaled by SDK via jsonschema tags
-		slog.Info("Search request", "query", args.Query)
+		slog.Info("Search request", "query", args.Query, "subject", auth.SubjectFromContext(ctx))
+		var returned []string
+		defer func() {
+			options.Audit.Record(ctx, AuditEntry{Tool: ToolNameSearch, Query: args.Query, Results: returned, Error: errorText(err)})
+		}()
+
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		opts := search.SearchOptions{Limit: args.Limit, Filters: args.Filters, Suggest: options.Suggest && !options.Restricted, SnippetSource: args.SnippetSource, URIs: args.URIs}
+		if args.Since != "" {
+			since, err := parseSince(args.Since, time.Now())
+			if err != nil {
+				return nil, nil, invalidParamsError(err)
+			}
+			opts.Since = since
+		}
 
-		results, err := searchService.Search(args.Query, nil)
+		response, err := searchService.Search(ctx, args.Query, opts)
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.Error("Search timed out", "query", args.Query, "timeout", timeout)
+			return nil, nil, toolError(fmt.Errorf("search timed out after %s", timeout))
+		}
 		if err != nil {
 			slog.Error("Search failed", "query", args.Query, "error", err)
-			return nil, nil, err
+			return nil, nil, toolError(err)
 		}
-
-		var sb strings.Builder
-		if len(results) == 0 {
-			fmt.Fprintf(&sb, "No results found for '%s'", args.Query)
-		} else {
-			fmt.Fprintf(&sb, "Search results for '%s':\n\n", args.Query)
-			for _, r := range results {
-				fmt.Fprintf(&sb, "- [%s](%s): %s\n\n", r.Name, r.URI, r.Snippet)
+		// Matches beyond the page cannot be checked for access, so they are
+		// not counted when some resources are restricted
+		more := 0
+		if !options.Restricted {
+			more = response.Total - len(response.Results)
+		}
+		if options.AccessibleBy != nil {
+			roles := auth.RolesFromContext(ctx)
+			var results []search.SearchResult
+			for _, r := range response.Results {
+				if options.AccessibleBy(r.URI, roles) {
+					results = append(results, r)
+				}
 			}
+			response.Results = results
+		}
+		for _, r := range response.Results {
+			returned = append(returned, r.URI)
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: sb.String()},
+				&mcp.TextContent{Text: renderSearchOutput(args.Query, response, more, options.OutputStyle)},
 			},
 		}, nil, nil
 	}
 }
 
 // NewReadToolHandler creates the handler for the read tool
-func NewReadToolHandler(resourceProvider *resources.ResourceProvider) mcp.ToolHandlerFor[ReadToolArgument, any] {
-	return func(ctx context.Context, req *mcp.CallToolRequest, args ReadToolArgument) (*mcp.CallToolResult, any, error) {
+func NewReadToolHandler(resourceProvider *resources.ResourceProvider, options ReadToolOptions) mcp.ToolHandlerFor[ReadToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ReadToolArgument) (_ *mcp.CallToolResult, _ any, err error) {
 		// Args are already validated and unmarshaled by SDK via jsonschema tags
-		slog.Info("Get resource request", "uri", args.URI)
+		slog.Info("Get resource request", "uri", args.URI, "subject", auth.SubjectFromContext(ctx))
+		defer func() {
+			options.Audit.Record(ctx, AuditEntry{Tool: ToolNameRead, URI: args.URI, Error: errorText(err)})
+		}()
+		if err := checkAccess(ctx, resourceProvider, args.URI); err != nil {
+			return nil, nil, toolError(err)
+		}
+
+		if args.IfNoneMatch != "" {
+			if etag, err := resourceProvider.ETag(args.URI); err == nil && etag == args.IfNoneMatch {
+				return &mcp.CallToolResult{
+					Meta: mcp.Meta{"etag": etag},
+					Content: []mcp.Content{
+						&mcp.TextContent{Text: fmt.Sprintf(unchangedMarkerFormat, args.URI)},
+					},
+				}, nil, nil
+			}
+		}
 
-		content, err := resourceProvider.ReadResource(args.URI)
+		content, etag, err := resourceProvider.ReadResourceWithETag(args.URI)
 		if err != nil {
 			slog.Error("Get resource failed", "uri", args.URI, "error", err)
-			return nil, nil, err
+			return nil, nil, toolError(err)
 		}
 
+		content, err = readWindow(content, args.Offset, options.MaxBytes)
+		if err != nil {
+			return nil, nil, invalidParamsError(err)
+		}
+
+		meta := mcp.Meta{"etag": etag}
+		if defn, err := resourceProvider.StatResource(args.URI); err == nil && defn.ID != "" {
+			meta["id"] = defn.ID
+		}
 		return &mcp.CallToolResult{
+			Meta: meta,
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: content},
 			},
 		}, nil, nil
 	}
 }
+
+// readWindow returns the part of content starting at offset, cut to at most
+// maxBytes bytes when maxBytes is positive. Both ends are moved back to the
+// start of a UTF-8 sequence, but at least one character is returned, and cut
+// content ends with a notice naming the offset to continue at.
+func readWindow(content string, offset, maxBytes int) (string, error) {
+	if offset < 0 || offset > len(content) {
+		return "", fmt.Errorf("offset %d is outside the content of %d bytes", offset, len(content))
+	}
+	for offset > 0 && offset < len(content) && !utf8.RuneStart(content[offset]) {
+		offset--
+	}
+	content = content[offset:]
+	if maxBytes <= 0 || len(content) <= maxBytes {
+		return content, nil
+	}
+	end := maxBytes
+	for end > 0 && !utf8.RuneStart(content[end]) {
+		end--
+	}
+	if end == 0 {
+		// A budget smaller than the first character still returns it whole,
+		// so every read makes progress
+		_, end = utf8.DecodeRuneInString(content)
+	}
+	return content[:end] + fmt.Sprintf(truncatedNoticeFormat, offset+end), nil
+}
+
+// NewRelatedToolHandler creates the handler for the related tool
+func NewRelatedToolHandler(resourceProvider *resources.ResourceProvider) mcp.ToolHandlerFor[RelatedToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args RelatedToolArgument) (*mcp.CallToolResult, any, error) {
+		slog.Info("Related resources request", "uri", args.URI, "subject", auth.SubjectFromContext(ctx))
+		if err := checkAccess(ctx, resourceProvider, args.URI); err != nil {
+			return nil, nil, toolError(err)
+		}
+
+		limit := defaultRelatedLimit
+		if args.Limit != nil && *args.Limit > 0 {
+			limit = *args.Limit
+		}
+
+		related, err := resourceProvider.RelatedByKeywords(args.URI, limit, auth.RolesFromContext(ctx))
+		if err != nil {
+			slog.Error("Related resources failed", "uri", args.URI, "error", err)
+			return nil, nil, toolError(err)
+		}
+
+		var sb strings.Builder
+		if len(related) == 0 {
+			fmt.Fprintf(&sb, "No related resources found for '%s'", args.URI)
+		} else {
+			fmt.Fprintf(&sb, "Resources related to '%s':\n\n", args.URI)
+			for _, r := range related {
+				fmt.Fprintf(&sb, "- [%s](%s): %s (shared keywords: %s)\n\n", r.Name, r.URI, r.Description, strings.Join(r.SharedKeywords, ", "))
+			}
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: sb.String()},
+			},
+		}, nil, nil
+	}
+}
+
+// NewLinksToolHandler creates the handler for the links tool
+func NewLinksToolHandler(resourceProvider *resources.ResourceProvider) mcp.ToolHandlerFor[LinksToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args LinksToolArgument) (*mcp.CallToolResult, any, error) {
+		slog.Info("Resource links request", "uri", args.URI, "inbound", args.Inbound, "subject", auth.SubjectFromContext(ctx))
+		if err := checkAccess(ctx, resourceProvider, args.URI); err != nil {
+			return nil, nil, toolError(err)
+		}
+		linkURI := func(l resources.LinkedResource) string { return l.URI }
+
+		outbound, err := resourceProvider.LinksFrom(args.URI)
+		if err != nil {
+			slog.Error("Resource links failed", "uri", args.URI, "error", err)
+			return nil, nil, toolError(err)
+		}
+
+		outbound = accessible(ctx, resourceProvider, outbound, linkURI)
+		text := formatLinks(outbound, "No outbound links found for '%s'", "Resources linked from '%s':\n\n", args.URI)
+
+		if args.Inbound {
+			inbound, err := resourceProvider.LinksTo(args.URI)
+			if err != nil {
+				slog.Error("Resource links failed", "uri", args.URI, "error", err)
+				return nil, nil, toolError(err)
+			}
+			inbound = accessible(ctx, resourceProvider, inbound, linkURI)
+			text = strings.TrimRight(text, "\n") + "\n\n" +
+				formatLinks(inbound, "No inbound links found for '%s'", "Resources linking to '%s':\n\n", args.URI)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, nil, nil
+	}
+}
+
+// formatLinks lists linked resources under a header, or returns the empty message if there are none
+func formatLinks(linked []resources.LinkedResource, emptyFormat, headerFormat, uri string) string {
+	if len(linked) == 0 {
+		return fmt.Sprintf(emptyFormat, uri)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, headerFormat, uri)
+	for _, l := range linked {
+		fmt.Fprintf(&sb, "- [%s](%s): %s\n\n", l.Name, l.URI, l.Description)
+	}
+	return sb.String()
+}
@@ -2,12 +2,19 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/auth"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
 	"github.com/sha1n/mcp-acdc-server/internal/search"
@@ -17,14 +24,25 @@ import (
 
 // Mock searcher for testing
 type TestMockSearcher struct {
-	MockSearch func(queryStr string, limit *int) ([]search.SearchResult, error)
+	MockSearch   func(ctx context.Context, queryStr string, opts search.SearchOptions) ([]search.SearchResult, error)
+	Suggestions  []string // Returned when suggestions are requested and there are no results
+	DroppedTerms int      // Reported as the number of truncated query terms
+	Total        int      // Reported as the number of matches
 }
 
-func (m *TestMockSearcher) Search(query string, options *int) ([]search.SearchResult, error) {
+func (m *TestMockSearcher) Search(ctx context.Context, query string, opts search.SearchOptions) (search.SearchResponse, error) {
+	response := search.SearchResponse{DroppedTerms: m.DroppedTerms, Total: m.Total}
 	if m.MockSearch != nil {
-		return m.MockSearch(query, options)
+		results, err := m.MockSearch(ctx, query, opts)
+		if err != nil {
+			return response, err
+		}
+		response.Results = results
 	}
-	return nil, nil
+	if opts.Suggest && len(response.Results) == 0 {
+		response.Suggestions = m.Suggestions
+	}
+	return response, nil
 }
 
 func (m *TestMockSearcher) Close() {}
@@ -39,13 +57,13 @@ func (m *TestMockSearcher) Index(ctx context.Context, docs <-chan domain.Documen
 func TestToolRegistration(t *testing.T) {
 	// Just verify tools can be created without panic
 	mockSearcher := &TestMockSearcher{}
-	searchHandler := NewSearchToolHandler(mockSearcher)
+	searchHandler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
 	if searchHandler == nil {
 		t.Error("Search handler should not be nil")
 	}
 
 	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{})
-	readHandler := NewReadToolHandler(resourceProvider)
+	readHandler := NewReadToolHandler(resourceProvider, ReadToolOptions{})
 	if readHandler == nil {
 		t.Error("Read handler should not be nil")
 	}
@@ -53,7 +71,7 @@ func TestToolRegistration(t *testing.T) {
 
 func TestSearchToolHandler_Success_WithResults(t *testing.T) {
 	mockSearcher := &TestMockSearcher{
-		MockSearch: func(query string, limit *int) ([]search.SearchResult, error) {
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
 			assert.Equal(t, "test query", query)
 			return []search.SearchResult{
 				{
@@ -70,7 +88,7 @@ func TestSearchToolHandler_Success_WithResults(t *testing.T) {
 		},
 	}
 
-	handler := NewSearchToolHandler(mockSearcher)
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
 	require.NotNil(t, handler)
 
 	ctx := context.Background()
@@ -93,14 +111,34 @@ func TestSearchToolHandler_Success_WithResults(t *testing.T) {
 	assert.Contains(t, textContent.Text, "Result 2")
 }
 
+func TestSearchToolHandler_MatchedKeywords(t *testing.T) {
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			return []search.SearchResult{
+				{Name: "Guide", URI: "acdc://guide", Snippet: "Guide (relevance: 1.00)", MatchedKeywords: []string{"golang", "channels"}},
+				{Name: "Other", URI: "acdc://other", Snippet: "Other (relevance: 0.50)"},
+			}, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "golang channels"})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "Search results for 'golang channels':\n\n"+
+		"- [Guide](acdc://guide): Guide (relevance: 1.00)\n  Matched keywords: golang, channels\n\n"+
+		"- [Other](acdc://other): Other (relevance: 0.50)\n\n", textContent.Text)
+}
+
 func TestSearchToolHandler_Success_NoResults(t *testing.T) {
 	mockSearcher := &TestMockSearcher{
-		MockSearch: func(query string, limit *int) ([]search.SearchResult, error) {
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
 			return []search.SearchResult{}, nil
 		},
 	}
 
-	handler := NewSearchToolHandler(mockSearcher)
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
 	ctx := context.Background()
 	req := &mcp.CallToolRequest{}
 	args := SearchToolArgument{Query: "nonexistent"}
@@ -117,27 +155,343 @@ func TestSearchToolHandler_Success_NoResults(t *testing.T) {
 	assert.Contains(t, textContent.Text, "No results found for 'nonexistent'")
 }
 
+func TestSearchToolHandler_PassesLimit(t *testing.T) {
+	var gotLimit *int
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			gotLimit = opts.Limit
+			return []search.SearchResult{}, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	limit := 3
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q", Limit: &limit})
+	require.NoError(t, err)
+	require.NotNil(t, gotLimit)
+	assert.Equal(t, 3, *gotLimit)
+
+	_, _, err = handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q"})
+	require.NoError(t, err)
+	assert.Nil(t, gotLimit)
+}
+
+func TestSearchToolHandler_PassesFilters(t *testing.T) {
+	var gotFilters map[string]string
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			gotFilters = opts.Filters
+			return []search.SearchResult{}, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	args := SearchToolArgument{Query: "q", Filters: map[string]string{"category": "runbook"}}
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, args)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"category": "runbook"}, gotFilters)
+}
+
+func TestSearchToolHandler_PassesSnippetSource(t *testing.T) {
+	var gotSource string
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			gotSource = opts.SnippetSource
+			return []search.SearchResult{}, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q", SnippetSource: search.SnippetSourceAuto})
+	require.NoError(t, err)
+	assert.Equal(t, search.SnippetSourceAuto, gotSource)
+}
+
+func TestSearchToolHandler_PassesURIs(t *testing.T) {
+	var gotURIs []string
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			gotURIs = opts.URIs
+			return []search.SearchResult{}, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q", URIs: []string{"acdc://a", "acdc://b"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acdc://a", "acdc://b"}, gotURIs)
+}
+
+func TestSearchToolHandler_PassesSince(t *testing.T) {
+	var gotSince time.Time
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			gotSince = opts.Since
+			return []search.SearchResult{}, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q", Since: "2026-01-02T03:04:05Z"})
+	require.NoError(t, err)
+	assert.True(t, gotSince.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	_, _, err = handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q", Since: "last week"})
+	assertErrorCode(t, err, jsonrpc.CodeInvalidParams)
+	assert.Contains(t, err.Error(), "invalid since value")
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2026-10-01T08:30:00Z", want: time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "2026-10-01", want: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "7d", want: now.AddDate(0, 0, -7)},
+		{value: "2w", want: now.AddDate(0, 0, -14)},
+		{value: "36h", want: now.Add(-36 * time.Hour)},
+		{value: "-3d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.Equal(tt.want), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestSearchToolHandler_Suggestions(t *testing.T) {
+	mockSearcher := &TestMockSearcher{Suggestions: []string{"kubernetes", "kubectl"}}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{Suggest: true})
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "kuberentes"})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "No results found for 'kuberentes'. Did you mean: kubernetes, kubectl?", textContent.Text)
+
+	handler = NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	result, _, err = handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "kuberentes"})
+	require.NoError(t, err)
+	textContent, ok = result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "No results found for 'kuberentes'", textContent.Text)
+}
+
+func TestSearchToolHandler_SuggestionsWithheldWhenRestricted(t *testing.T) {
+	mockSearcher := &TestMockSearcher{Suggestions: []string{"kubernetes"}}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{Suggest: true, Restricted: true})
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "kuberentes"})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "No results found for 'kuberentes'", textContent.Text)
+}
+
+func TestSearchToolHandler_TruncatedQuery(t *testing.T) {
+	mockSearcher := &TestMockSearcher{DroppedTerms: 4}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "a very long query"})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "Note: the query was too long; the last 4 term(s) were ignored.\n\nNo results found for 'a very long query'", textContent.Text)
+}
+
+func TestSearchToolHandler_TruncatedResults(t *testing.T) {
+	results := []search.SearchResult{{Name: "A", URI: "acdc://a", Snippet: "a"}}
+	tests := []struct {
+		name  string
+		total int
+		want  string
+	}{
+		{name: "Truncated", total: 8, want: "Search results for 'q':\n\n- [A](acdc://a): a\n\n(7 more results not shown; refine your query)\n"},
+		{name: "Complete", total: 1, want: "Search results for 'q':\n\n- [A](acdc://a): a\n\n"},
+		{name: "Uncounted", total: 0, want: "Search results for 'q':\n\n- [A](acdc://a): a\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSearcher := &TestMockSearcher{
+				MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+					return results, nil
+				},
+				Total: tt.total,
+			}
+			result, _, err := NewSearchToolHandler(mockSearcher, SearchToolOptions{})(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q"})
+			require.NoError(t, err)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, textContent.Text)
+		})
+	}
+}
+
+func TestSearchToolHandler_OutputStyle(t *testing.T) {
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			return []search.SearchResult{
+				{Name: "A", URI: "acdc://a", Snippet: "first line\n  second   line", MatchedKeywords: []string{"k1", "k2"}},
+				{Name: "B", URI: "acdc://b", Snippet: "b"},
+			}, nil
+		},
+		Total: 3,
+	}
+	tests := []struct {
+		name  string
+		style string
+		want  string
+	}{
+		{
+			name:  "Markdown",
+			style: SearchOutputMarkdown,
+			want: "Search results for 'q':\n\n" +
+				"- [A](acdc://a): first line\n  second   line\n  Matched keywords: k1, k2\n\n" +
+				"- [B](acdc://b): b\n\n" +
+				"(1 more results not shown; refine your query)\n",
+		},
+		{
+			name:  "Compact",
+			style: SearchOutputCompact,
+			want: "Search results for 'q':\n" +
+				"- [A](acdc://a): first line second line [keywords: k1, k2]\n" +
+				"- [B](acdc://b): b\n" +
+				"(1 more results not shown; refine your query)\n",
+		},
+		{
+			name:  "Default",
+			style: "",
+			want: "Search results for 'q':\n\n" +
+				"- [A](acdc://a): first line\n  second   line\n  Matched keywords: k1, k2\n\n" +
+				"- [B](acdc://b): b\n\n" +
+				"(1 more results not shown; refine your query)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{OutputStyle: tt.style})
+			result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q"})
+			require.NoError(t, err)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, textContent.Text)
+		})
+	}
+}
+
+func TestSearchToolHandler_FiltersInaccessible(t *testing.T) {
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://public", Name: "Public"},
+		{URI: "acdc://internal", Name: "Internal", Roles: []string{"internal"}},
+	})
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			return []search.SearchResult{
+				{Name: "Public", URI: "acdc://public"},
+				{Name: "Internal", URI: "acdc://internal"},
+			}, nil
+		},
+	}
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{AccessibleBy: resourceProvider.AccessibleBy})
+
+	t.Run("Denied", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "docs"})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, textContent.Text, "acdc://public")
+		assert.NotContains(t, textContent.Text, "acdc://internal")
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "alice", Roles: []string{"internal"}})
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, SearchToolArgument{Query: "docs"})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, textContent.Text, "acdc://public")
+		assert.Contains(t, textContent.Text, "acdc://internal")
+	})
+}
+
+func TestSearchToolHandler_RestrictedHitsBeyondLimit(t *testing.T) {
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://public", Name: "Public"},
+		{URI: "acdc://internal-1", Name: "Internal 1", Roles: []string{"internal"}},
+		{URI: "acdc://internal-2", Name: "Internal 2", Roles: []string{"internal"}},
+		{URI: "acdc://internal-3", Name: "Internal 3", Roles: []string{"internal"}},
+	})
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			return []search.SearchResult{{Name: "Public", URI: "acdc://public", Snippet: "p"}}, nil
+		},
+		Total: 4,
+	}
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{
+		AccessibleBy: resourceProvider.AccessibleBy,
+		Restricted:   resourceProvider.HasRestricted(),
+	})
+
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q"})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "Search results for 'q':\n\n- [Public](acdc://public): p\n\n", textContent.Text)
+}
+
 func TestSearchToolHandler_Error(t *testing.T) {
 	expectedErr := errors.New("search service error")
 	mockSearcher := &TestMockSearcher{
-		MockSearch: func(query string, limit *int) ([]search.SearchResult, error) {
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
 			return nil, expectedErr
 		},
 	}
 
-	handler := NewSearchToolHandler(mockSearcher)
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
 	ctx := context.Background()
 	req := &mcp.CallToolRequest{}
 	args := SearchToolArgument{Query: "failing query"}
 
 	result, extra, err := handler(ctx, req, args)
 
-	require.Error(t, err)
-	assert.Equal(t, expectedErr, err)
+	assertErrorCode(t, err, jsonrpc.CodeInternalError)
+	assert.Equal(t, expectedErr.Error(), err.Error())
 	assert.Nil(t, result)
 	assert.Nil(t, extra)
 }
 
+func TestSearchToolHandler_Timeout(t *testing.T) {
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{Timeout: 10 * time.Millisecond})
+	args := SearchToolArgument{Query: "slow query"}
+
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, args)
+
+	assertErrorCode(t, err, jsonrpc.CodeInternalError)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Nil(t, result)
+}
+
 func TestReadToolHandler_Success(t *testing.T) {
 	// Create temp file with markdown content
 	tempDir := t.TempDir()
@@ -156,7 +510,7 @@ func TestReadToolHandler_Success(t *testing.T) {
 		},
 	})
 
-	handler := NewReadToolHandler(resourceProvider)
+	handler := NewReadToolHandler(resourceProvider, ReadToolOptions{})
 	require.NotNil(t, handler)
 
 	ctx := context.Background()
@@ -175,10 +529,71 @@ func TestReadToolHandler_Success(t *testing.T) {
 	assert.Equal(t, "# Test Content\n\nThis is test content.", textContent.Text)
 }
 
+func TestReadToolHandler_ID(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "doc.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("---\nname: Doc\ndescription: D\n---\nBody"), 0644))
+
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{ID: "doc-id", URI: "acdc://doc", Name: "Doc", FilePath: filePath},
+	})
+	result, _, err := NewReadToolHandler(resourceProvider, ReadToolOptions{})(context.Background(), &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://doc"})
+	require.NoError(t, err)
+	assert.Equal(t, "doc-id", result.Meta["id"])
+	assert.NotEmpty(t, result.Meta["etag"])
+}
+
+func TestReadToolHandler_MaxBytes(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "doc.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("---\nname: Doc\ndescription: D\n---\nHello, wörld!"), 0644))
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://doc", Name: "Doc", FilePath: filePath},
+	})
+	read := func(t *testing.T, maxBytes, offset int) (string, error) {
+		t.Helper()
+		result, _, err := NewReadToolHandler(resourceProvider, ReadToolOptions{MaxBytes: maxBytes})(context.Background(), &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://doc", Offset: offset})
+		if err != nil {
+			return "", err
+		}
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		return textContent.Text, nil
+	}
+
+	tests := []struct {
+		name     string
+		maxBytes int
+		offset   int
+		want     string
+	}{
+		{name: "Unlimited", want: "Hello, wörld!"},
+		{name: "Under Budget", maxBytes: 100, want: "Hello, wörld!"},
+		{name: "Exactly Budget", maxBytes: 14, want: "Hello, wörld!"},
+		{name: "Over Budget", maxBytes: 5, want: "Hello\n\n...[truncated, use offset 5 to continue]"},
+		{name: "Cut Inside Character", maxBytes: 9, want: "Hello, w\n\n...[truncated, use offset 8 to continue]"},
+		{name: "Continued", maxBytes: 5, offset: 5, want: ", wö\n\n...[truncated, use offset 10 to continue]"},
+		{name: "Last Part", maxBytes: 5, offset: 10, want: "rld!"},
+		{name: "Budget Below Character", maxBytes: 1, offset: 8, want: "ö\n\n...[truncated, use offset 10 to continue]"},
+		{name: "Offset Without Budget", offset: 7, want: "wörld!"},
+		{name: "Offset At End", offset: 14, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := read(t, tt.maxBytes, tt.offset)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Offset Beyond Content", func(t *testing.T) {
+		_, err := read(t, 5, 15)
+		assertErrorCode(t, err, jsonrpc.CodeInvalidParams)
+	})
+}
+
 func TestReadToolHandler_Error_ResourceNotFound(t *testing.T) {
 	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{})
 
-	handler := NewReadToolHandler(resourceProvider)
+	handler := NewReadToolHandler(resourceProvider, ReadToolOptions{})
 	ctx := context.Background()
 	req := &mcp.CallToolRequest{}
 	args := ReadToolArgument{URI: "acdc://nonexistent"}
@@ -190,3 +605,356 @@ func TestReadToolHandler_Error_ResourceNotFound(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Nil(t, extra)
 }
+
+func TestReadToolHandler_Roles(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "internal.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("---\nname: Internal\n---\nInternal notes"), 0644))
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://internal", Name: "Internal", FilePath: filePath, Roles: []string{"internal"}},
+	})
+	handler := NewReadToolHandler(resourceProvider, ReadToolOptions{})
+	args := ReadToolArgument{URI: "acdc://internal"}
+
+	t.Run("Denied", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "bob"})
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, args)
+		// Reported exactly like an unknown resource
+		assertErrorCode(t, err, jsonrpc.CodeInvalidParams)
+		assert.Contains(t, err.Error(), resources.ErrUnknownResource.Error())
+		assert.Nil(t, result)
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "alice", Roles: []string{"internal"}})
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, args)
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Internal notes", textContent.Text)
+	})
+}
+
+func TestReadToolHandler_IfNoneMatch(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "doc.md")
+	write := func(body string) {
+		require.NoError(t, os.WriteFile(filePath, []byte("---\nname: Doc\ndescription: D\n---\n"+body), 0644))
+	}
+	write("original")
+
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://doc", Name: "Doc", FilePath: filePath},
+	})
+	handler := NewReadToolHandler(resourceProvider, ReadToolOptions{})
+	ctx := context.Background()
+
+	first, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://doc"})
+	require.NoError(t, err)
+	etag, ok := first.Meta["etag"].(string)
+	require.True(t, ok, "read result should carry an etag")
+	require.NotEmpty(t, etag)
+
+	t.Run("Unchanged", func(t *testing.T) {
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://doc", IfNoneMatch: etag})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Resource 'acdc://doc' is unchanged.", textContent.Text)
+		assert.Equal(t, etag, result.Meta["etag"])
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		write("updated content")
+
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://doc", IfNoneMatch: etag})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "updated content", textContent.Text)
+		assert.NotEqual(t, etag, result.Meta["etag"])
+	})
+}
+
+func TestRelatedToolHandler(t *testing.T) {
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://auth", Name: "Auth", Description: "Auth guide", Keywords: []string{"auth", "security"}},
+		{URI: "acdc://oauth", Name: "OAuth", Description: "OAuth guide", Keywords: []string{"auth"}},
+		{URI: "acdc://style", Name: "Style", Description: "Style guide", Keywords: []string{"lint"}},
+	})
+	handler := NewRelatedToolHandler(resourceProvider)
+
+	t.Run("With Results", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, RelatedToolArgument{URI: "acdc://auth"})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, textContent.Text, "[OAuth](acdc://oauth)")
+		assert.Contains(t, textContent.Text, "shared keywords: auth")
+		assert.NotContains(t, textContent.Text, "acdc://style")
+	})
+
+	t.Run("No Results", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, RelatedToolArgument{URI: "acdc://style"})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, textContent.Text, "No related resources found")
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, RelatedToolArgument{URI: "acdc://missing"})
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestLinksToolHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"a.md": "---\nname: A\ndescription: Doc A\n---\nSee [b](b.md).",
+		"b.md": "---\nname: B\ndescription: Doc B\n---\nBack to [a](a.md).",
+		"c.md": "---\nname: C\ndescription: Doc C\n---\nNo links.",
+	}
+	var defs []resources.ResourceDefinition
+	for name, body := range files {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+		base := name[:len(name)-len(".md")]
+		defs = append(defs, resources.ResourceDefinition{URI: "acdc://" + base, Name: strings.ToUpper(base), Description: "Doc " + strings.ToUpper(base), FilePath: path})
+	}
+	graph := resources.BuildLinkGraph(defs, "acdc", content.NewContentProvider(""))
+	handler := NewLinksToolHandler(resources.NewResourceProvider(defs, resources.WithLinkGraph(graph)))
+
+	t.Run("Outbound", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, LinksToolArgument{URI: "acdc://a"})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Resources linked from 'acdc://a':\n\n- [B](acdc://b): Doc B\n\n", textContent.Text)
+	})
+
+	t.Run("Inbound", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, LinksToolArgument{URI: "acdc://a", Inbound: true})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Resources linked from 'acdc://a':\n\n- [B](acdc://b): Doc B\n\nResources linking to 'acdc://a':\n\n- [B](acdc://b): Doc B\n\n", textContent.Text)
+	})
+
+	t.Run("No Links", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, LinksToolArgument{URI: "acdc://c", Inbound: true})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "No outbound links found for 'acdc://c'\n\nNo inbound links found for 'acdc://c'", textContent.Text)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, LinksToolArgument{URI: "acdc://missing"})
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestDescribeToolHandler(t *testing.T) {
+	handler := NewDescribeToolHandler(ServerDescription{
+		Name:      "test-server",
+		Version:   "1.0.0",
+		Transport: "sse",
+		Resources: 3,
+		Prompts:   2,
+		Tools:     []string{"search", "read"},
+	}, nil)
+
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, DescribeToolArgument{})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+
+	var got ServerDescription
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &got))
+	assert.Equal(t, "test-server", got.Name)
+	assert.Equal(t, "1.0.0", got.Version)
+	assert.Equal(t, "sse", got.Transport)
+	assert.Equal(t, 3, got.Resources)
+	assert.Equal(t, 2, got.Prompts)
+	assert.Equal(t, []string{"search", "read"}, got.Tools)
+}
+
+func TestDescribeToolHandler_CountsAccessibleResources(t *testing.T) {
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://public", Name: "public"},
+		{URI: "acdc://hidden", Name: "hidden", Hidden: true},
+		{URI: "acdc://internal", Name: "internal", Roles: []string{"internal"}},
+		{URI: "acdc://expired", Name: "expired", ExpireAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	handler := NewDescribeToolHandler(ServerDescription{Name: "test-server", Resources: 99}, resourceProvider.CountListed)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want int
+	}{
+		{name: "Anonymous", ctx: context.Background(), want: 1},
+		{name: "With Role", ctx: auth.WithIdentity(context.Background(), auth.Identity{Subject: "alice", Roles: []string{"internal"}}), want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := handler(tt.ctx, &mcp.CallToolRequest{}, DescribeToolArgument{})
+			require.NoError(t, err)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok)
+			var got ServerDescription
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &got))
+			assert.Equal(t, tt.want, got.Resources)
+		})
+	}
+}
+
+func TestStatToolHandler(t *testing.T) {
+	modified := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	defs := []resources.ResourceDefinition{
+		{URI: "acdc://guides/setup", Name: "Setup", Title: "Setup Guide", Description: "How to set up", MIMEType: "text/markdown", FilePath: "/nonexistent/setup.md", Size: 1024, LastModified: modified},
+		{URI: "acdc://a/dup", Name: "dup"},
+		{URI: "acdc://b/dup", Name: "dup"},
+	}
+	handler := NewStatToolHandler(resources.NewResourceProvider(defs))
+
+	statOf := func(t *testing.T, uri string) ResourceStat {
+		t.Helper()
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, StatToolArgument{URI: uri})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		var stat ResourceStat
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &stat))
+		return stat
+	}
+
+	t.Run("Existing", func(t *testing.T) {
+		stat := statOf(t, "acdc://guides/setup")
+		assert.Equal(t, ResourceStat{
+			URI:          "acdc://guides/setup",
+			Exists:       true,
+			Name:         "Setup",
+			Title:        "Setup Guide",
+			Description:  "How to set up",
+			MIMEType:     "text/markdown",
+			Size:         1024,
+			LastModified: &modified,
+		}, stat)
+	})
+
+	t.Run("By Name", func(t *testing.T) {
+		stat := statOf(t, "Setup")
+		assert.True(t, stat.Exists)
+		assert.Equal(t, "acdc://guides/setup", stat.URI)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		assert.Equal(t, ResourceStat{URI: "acdc://missing"}, statOf(t, "acdc://missing"))
+	})
+
+	t.Run("Ambiguous Name", func(t *testing.T) {
+		_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, StatToolArgument{URI: "dup"})
+		assertErrorCode(t, err, jsonrpc.CodeInvalidParams)
+	})
+}
+
+// assertErrorCode asserts that err is a JSON-RPC error with the given code
+func assertErrorCode(t *testing.T, err error, code int64) {
+	t.Helper()
+	var wireErr *jsonrpc.Error
+	require.ErrorAs(t, err, &wireErr)
+	assert.Equal(t, code, wireErr.Code, "unexpected error code for %q", wireErr.Message)
+}
+
+func TestListToolHandler(t *testing.T) {
+	handler := NewListToolHandler(resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://onboarding", Name: "Onboarding", Description: "First steps", Tags: []string{"onboarding", "backend"}},
+		{URI: "acdc://frontend", Name: "Frontend", Description: "UI setup", Tags: []string{"onboarding"}},
+		{URI: "acdc://internal", Name: "Internal", Description: "Private", Tags: []string{"onboarding"}, Roles: []string{"internal"}},
+	}))
+	list := func(t *testing.T, tags ...string) string {
+		t.Helper()
+		result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, ListToolArgument{Tags: tags})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		return textContent.Text
+	}
+
+	t.Run("Single Tag", func(t *testing.T) {
+		assert.Equal(t, "Resources tagged 'onboarding':\n\n"+
+			"- [Onboarding](acdc://onboarding): First steps\n  Tags: onboarding, backend\n\n"+
+			"- [Frontend](acdc://frontend): UI setup\n  Tags: onboarding\n\n", list(t, "onboarding"))
+	})
+
+	t.Run("All Tags Required", func(t *testing.T) {
+		assert.Equal(t, "Resources tagged 'Onboarding, backend':\n\n"+
+			"- [Onboarding](acdc://onboarding): First steps\n  Tags: onboarding, backend\n\n", list(t, "Onboarding", "backend"))
+	})
+
+	t.Run("No Match", func(t *testing.T) {
+		assert.Equal(t, "No resources tagged 'billing'", list(t, "billing"))
+	})
+
+	t.Run("Untagged Lists All Accessible", func(t *testing.T) {
+		text := list(t)
+		assert.Contains(t, text, "acdc://onboarding")
+		assert.Contains(t, text, "acdc://frontend")
+		assert.NotContains(t, text, "acdc://internal")
+	})
+}
+
+func TestReadToolHandler_ErrorCodes(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "doc.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("---\nname: Doc\n---\nContent"), 0644))
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://doc", Name: "Doc", FilePath: filePath},
+		{URI: "acdc://a/dup", Name: "Dup", FilePath: filePath},
+		{URI: "acdc://b/dup", Name: "Dup", FilePath: filePath},
+		{URI: "acdc://gone", Name: "Gone", FilePath: filepath.Join(t.TempDir(), "missing.md")},
+	})
+	handler := NewReadToolHandler(resourceProvider, ReadToolOptions{})
+
+	tests := []struct {
+		name string
+		uri  string
+		code int64
+	}{
+		{name: "Unknown URI", uri: "acdc://nonexistent", code: jsonrpc.CodeInvalidParams},
+		{name: "Ambiguous Name", uri: "Dup", code: jsonrpc.CodeInvalidParams},
+		{name: "Disk Failure", uri: "acdc://gone", code: jsonrpc.CodeInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, ReadToolArgument{URI: tt.uri})
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestSearchToolHandler_UnknownSnippetSourceCode(t *testing.T) {
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			return nil, fmt.Errorf("%w %q", search.ErrUnknownSnippetSource, opts.SnippetSource)
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q", SnippetSource: "title"})
+	assertErrorCode(t, err, jsonrpc.CodeInvalidParams)
+}
+
+func TestSearchToolHandler_UnknownFilterFieldCode(t *testing.T) {
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+			return nil, fmt.Errorf("%w: %s", search.ErrUnknownFilterField, "owner")
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher, SearchToolOptions{})
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "q", Filters: map[string]string{"owner": "me"}})
+	assertErrorCode(t, err, jsonrpc.CodeInvalidParams)
+}
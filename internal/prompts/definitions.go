@@ -2,6 +2,25 @@ package prompts
 
 import (
 	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Supported values of the `content_type` prompt frontmatter field
+const (
+	// ContentTypeText emits the rendered prompt as text content
+	ContentTypeText = "text"
+	// ContentTypeResource emits the rendered prompt as an embedded resource
+	ContentTypeResource = "resource"
+)
+
+// Supported values of the `type` prompt argument frontmatter field
+const (
+	// ArgumentTypeString passes the argument value to the template as is
+	ArgumentTypeString = "string"
+	// ArgumentTypeObject decodes the argument value as a JSON object, so the
+	// template can reference nested values (e.g. {{.params.foo}})
+	ArgumentTypeObject = "object"
 )
 
 // PromptDefinition definition of an MCP prompt
@@ -11,6 +30,11 @@ type PromptDefinition struct {
 	Arguments   []PromptArgument
 	FilePath    string
 	Template    *template.Template
+	Role        mcp.Role // Role of the rendered message, "user" or "assistant"
+	ContentType string   // ContentTypeText or ContentTypeResource
+	MIMEType    string   // MIME type of embedded resource content
+	Trim        bool     // Trim surrounding whitespace and collapse blank lines in rendered output
+	Locale      string   // Normalized locale of a localized variant, e.g. "de"; empty if not localized
 }
 
 // PromptArgument definition of an MCP prompt argument
@@ -18,4 +42,18 @@ type PromptArgument struct {
 	Name        string
 	Description string
 	Required    bool
+	RequiredIf  *ArgumentCondition // Requires the argument only when the condition holds
+	Type        string             // ArgumentTypeString or ArgumentTypeObject
+	Enum        []string           // Allowed values; empty allows any value
+}
+
+// ArgumentCondition holds when the named argument has the given value
+type ArgumentCondition struct {
+	Argument string
+	Value    string
+}
+
+// String returns the condition in its frontmatter form, e.g. "mode=advanced"
+func (c ArgumentCondition) String() string {
+	return c.Argument + "=" + c.Value
 }
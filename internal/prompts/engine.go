@@ -0,0 +1,54 @@
+package prompts
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Supported values of the `engine` prompt frontmatter field
+const (
+	EngineGo       = "go"       // Go text/template syntax, e.g. {{.topic}}
+	EngineSimple   = "simple"   // single-brace placeholders, e.g. {topic}
+	EngineMustache = "mustache" // mustache-style placeholders, e.g. {{topic}}
+)
+
+var (
+	simplePlaceholderRe   = regexp.MustCompile(`\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}`)
+	mustachePlaceholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+// isEngine reports whether engine names a supported template engine
+func isEngine(engine string) bool {
+	return engine == EngineGo || engine == EngineSimple || engine == EngineMustache
+}
+
+// toGoTemplate rewrites a template body written with the placeholder syntax of
+// engine into Go template syntax, so that rendering and argument validation
+// share one code path. Every other brace is escaped and renders literally.
+func toGoTemplate(body, engine string) string {
+	var re *regexp.Regexp
+	switch engine {
+	case EngineSimple:
+		re = simplePlaceholderRe
+	case EngineMustache:
+		re = mustachePlaceholderRe
+	default:
+		return body
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringSubmatchIndex(body, -1) {
+		sb.WriteString(escapeBraces(body[last:m[0]]))
+		sb.WriteString("{{." + body[m[2]:m[3]] + "}}")
+		last = m[1]
+	}
+	sb.WriteString(escapeBraces(body[last:]))
+	return sb.String()
+}
+
+// escapeBraces turns every opening brace into an action that prints it, so
+// literal text can never form a Go template delimiter
+func escapeBraces(text string) string {
+	return strings.ReplaceAll(text, "{", `{{"{"}}`)
+}
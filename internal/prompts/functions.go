@@ -0,0 +1,100 @@
+package prompts
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"text/template"
+	"text/template/parse"
+)
+
+// builtinFunctions are the functions text/template predefines for every
+// template. Prompt templates have no other functions.
+var builtinFunctions = []string{
+	"and", "call", "eq", "ge", "gt", "html", "index", "js", "le", "len", "lt",
+	"ne", "not", "or", "print", "printf", "println", "slice", "urlquery",
+}
+
+// checkFunctionNames returns an error naming the first entry that is not a
+// template function
+func checkFunctionNames(names []string) error {
+	for _, name := range names {
+		if !slices.Contains(builtinFunctions, name) {
+			return fmt.Errorf("unknown prompt template function: %s", name)
+		}
+	}
+	return nil
+}
+
+// templateFunctions returns the sorted, de-duplicated names of the functions
+// a template calls (e.g. {{printf "%q" .topic}} yields "printf")
+func templateFunctions(tmpl *template.Template) []string {
+	funcs := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Root != nil {
+			collectFunctions(t.Root, funcs)
+		}
+	}
+
+	names := make([]string, 0, len(funcs))
+	for f := range funcs {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectFunctions walks a parse tree node, recording the names of called functions
+func collectFunctions(node parse.Node, funcs map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			collectFunctions(c, funcs)
+		}
+	case *parse.ActionNode:
+		collectFunctions(n.Pipe, funcs)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFunctions(cmd, funcs)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFunctions(arg, funcs)
+		}
+	case *parse.IdentifierNode:
+		funcs[n.Ident] = true
+	case *parse.ChainNode:
+		collectFunctions(n.Node, funcs)
+	case *parse.IfNode:
+		collectFunctions(n.Pipe, funcs)
+		collectFunctions(n.List, funcs)
+		collectFunctions(n.ElseList, funcs)
+	case *parse.RangeNode:
+		collectFunctions(n.Pipe, funcs)
+		collectFunctions(n.List, funcs)
+		collectFunctions(n.ElseList, funcs)
+	case *parse.WithNode:
+		collectFunctions(n.Pipe, funcs)
+		collectFunctions(n.List, funcs)
+		collectFunctions(n.ElseList, funcs)
+	case *parse.TemplateNode:
+		collectFunctions(n.Pipe, funcs)
+	}
+}
+
+// disabledFunctions returns the functions a template calls that are not in allowed
+func disabledFunctions(tmpl *template.Template, allowed []string) []string {
+	var disabled []string
+	for _, f := range templateFunctions(tmpl) {
+		if !slices.Contains(allowed, f) {
+			disabled = append(disabled, f)
+		}
+	}
+	return disabled
+}
@@ -0,0 +1,78 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "None", body: "Hello {{.name}}", want: []string{}},
+		{name: "Action", body: `{{printf "%q" .name}}`, want: []string{"printf"}},
+		{name: "Pipeline", body: `{{.name | len}}`, want: []string{"len"}},
+		{name: "Nested", body: `{{if and (eq .a "x") (not .b)}}{{range .c}}{{index . 0}}{{end}}{{end}}`, want: []string{"and", "eq", "index", "not"}},
+		{name: "Else Branch", body: `{{with .a}}x{{else}}{{println "y"}}{{end}}`, want: []string{"println"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("t").Parse(tt.body))
+			assert.Equal(t, tt.want, templateFunctions(tmpl))
+		})
+	}
+}
+
+func TestDiscoverPrompts_AllowedFunctions(t *testing.T) {
+	tempDir := t.TempDir()
+	promptsDir := filepath.Join(tempDir, "mcp-prompts")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755))
+	files := map[string]string{
+		"allowed.md":  "---\nname: allowed\ndescription: d\narguments:\n  - name: env\n---\n{{if eq .env \"prod\"}}Careful{{end}}",
+		"disabled.md": "---\nname: disabled\ndescription: d\narguments:\n  - name: env\n---\n{{printf \"%s\" .env}}",
+		"plain.md":    "---\nname: plain\ndescription: d\narguments:\n  - name: env\n---\n{{.env}}",
+	}
+	for name, body := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(promptsDir, name), []byte(body), 0644))
+	}
+	cp := content.NewContentProvider(tempDir)
+	names := func(defs []PromptDefinition) []string {
+		var names []string
+		for _, d := range defs {
+			names = append(names, d.Name)
+		}
+		return names
+	}
+
+	t.Run("All Allowed By Default", func(t *testing.T) {
+		defs, err := DiscoverPrompts(cp)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"allowed", "disabled", "plain"}, names(defs))
+	})
+
+	t.Run("Disabled Function Skips Prompt", func(t *testing.T) {
+		defs, err := DiscoverPrompts(cp, WithAllowedFunctions("eq"))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"allowed", "plain"}, names(defs))
+	})
+
+	t.Run("Empty List Disables All", func(t *testing.T) {
+		defs, err := DiscoverPrompts(cp, WithAllowedFunctions())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"plain"}, names(defs))
+	})
+
+	t.Run("Unknown Function Name", func(t *testing.T) {
+		_, err := DiscoverPrompts(cp, WithAllowedFunctions("eq", "env"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown prompt template function: env")
+	})
+}
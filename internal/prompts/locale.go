@@ -0,0 +1,82 @@
+package prompts
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	xlanguage "golang.org/x/text/language"
+)
+
+// LocaleArgument is the prompt argument that selects a localized variant of a
+// prompt, e.g. "de" for the variant defined in setup.de.md
+const LocaleArgument = "locale"
+
+// DefaultLocale is the locale served when a request names none, or names one
+// a prompt has no variant for
+const DefaultLocale = "en"
+
+// localeRegexp matches locale tags such as "en", "de-AT", or "pt_BR"
+var localeRegexp = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+
+// fileLocale returns the locale suffix of a prompt file name, e.g. "de" for
+// setup.de.md, or "" if the name has none. Only known locale tags with a
+// two-letter language count, so suffixes such as review.old.md or
+// notes.tmp.md are not mistaken for locales; other locales are set with the
+// locale frontmatter field.
+func fileLocale(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	suffix := strings.TrimPrefix(filepath.Ext(base), ".")
+	if suffix == "" || !localeRegexp.MatchString(suffix) {
+		return ""
+	}
+	locale := normalizeLocale(suffix)
+	tag, err := xlanguage.Parse(locale)
+	if err != nil {
+		return ""
+	}
+	if lang, _ := tag.Base(); lang.String() != language(locale) || len(lang.String()) != 2 {
+		return ""
+	}
+	return locale
+}
+
+// normalizeLocale lowercases a locale and separates its parts with '-', so
+// "pt_BR" and "pt-br" select the same variant
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// language returns the language part of a locale, e.g. "pt" for "pt-br"
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// selectVariant picks the variant of a prompt for the requested locale. It
+// prefers a variant of the requested locale, then one of the requested
+// language, then the same for the default locale, then a variant without a
+// locale, and finally the first variant.
+func selectVariant(variants []PromptDefinition, requested, defaultLocale string) PromptDefinition {
+	for _, locale := range []string{normalizeLocale(requested), normalizeLocale(defaultLocale)} {
+		if locale == "" {
+			continue
+		}
+		for _, v := range variants {
+			if v.Locale == locale {
+				return v
+			}
+		}
+		for _, v := range variants {
+			if v.Locale != "" && language(v.Locale) == language(locale) {
+				return v
+			}
+		}
+	}
+	for _, v := range variants {
+		if v.Locale == "" {
+			return v
+		}
+	}
+	return variants[0]
+}
@@ -0,0 +1,160 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLocale(t *testing.T) {
+	tests := map[string]string{
+		"/prompts/setup.md":       "",
+		"/prompts/setup.de.md":    "de",
+		"/prompts/setup.pt_BR.md": "pt-br",
+		"/prompts/setup.de-AT.md": "de-at",
+		"/prompts/setup.v2.md":    "",
+		"/prompts/release.1.md":   "",
+		"/prompts/review.old.md":  "",
+		"/prompts/notes.tmp.md":   "",
+		"/prompts/setup.zz.md":    "",
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, fileLocale(path), path)
+	}
+}
+
+func TestSelectVariant(t *testing.T) {
+	variants := []PromptDefinition{
+		{Name: "setup", Locale: "de", Description: "de"},
+		{Name: "setup", Description: "unlocalized"},
+		{Name: "setup", Locale: "en", Description: "en"},
+		{Name: "setup", Locale: "pt-br", Description: "pt-br"},
+	}
+	tests := []struct {
+		name      string
+		requested string
+		fallback  string
+		want      string
+	}{
+		{name: "Exact", requested: "de", fallback: "en", want: "de"},
+		{name: "Normalized", requested: "PT_br", fallback: "en", want: "pt-br"},
+		{name: "Language", requested: "de-AT", fallback: "en", want: "de"},
+		{name: "Region Of Requested Language", requested: "pt", fallback: "en", want: "pt-br"},
+		{name: "Default Locale", requested: "fr", fallback: "en", want: "en"},
+		{name: "No Request", requested: "", fallback: "de", want: "de"},
+		{name: "Unlocalized", requested: "fr", fallback: "it", want: "unlocalized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectVariant(variants, tt.requested, tt.fallback).Description)
+		})
+	}
+
+	t.Run("First Variant", func(t *testing.T) {
+		localized := []PromptDefinition{{Locale: "fr", Description: "fr"}, {Locale: "de", Description: "de"}}
+		assert.Equal(t, "fr", selectVariant(localized, "it", "en").Description)
+	})
+}
+
+func TestPromptProvider_Localized(t *testing.T) {
+	tempDir := t.TempDir()
+	promptsDir := filepath.Join(tempDir, "mcp-prompts")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755))
+	files := map[string]string{
+		"setup.en.md": "---\nname: setup\ndescription: Set up a service\narguments:\n  - name: service\n---\nSet up {{.service}}",
+		"setup.de.md": "---\nname: setup\ndescription: Einen Dienst einrichten\narguments:\n  - name: service\n---\nRichte {{.service}} ein",
+		"setup.fr.md": "---\nname: setup\ndescription: Configurer\nlocale: fr-CA\narguments:\n  - name: service\n---\nConfigure {{.service}}",
+		"review.md":   "---\nname: review\ndescription: Review code\n---\nReview the code",
+	}
+	for name, body := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(promptsDir, name), []byte(body), 0644))
+	}
+	cp := content.NewContentProvider(tempDir)
+	defs, err := DiscoverPrompts(cp)
+	require.NoError(t, err)
+	require.Len(t, defs, 4)
+
+	p := NewPromptProvider(defs, cp)
+	render := func(t *testing.T, name string, arguments map[string]string) string {
+		messages, err := p.GetPrompt(name, arguments)
+		require.NoError(t, err)
+		return messages[0].Content.(*mcp.TextContent).Text
+	}
+
+	t.Run("Matched Locale", func(t *testing.T) {
+		assert.Equal(t, "Richte api ein", render(t, "setup", map[string]string{"service": "api", "locale": "de"}))
+		assert.Equal(t, "Richte api ein", render(t, "setup", map[string]string{"service": "api", "locale": "de-AT"}))
+		assert.Equal(t, "Configure api", render(t, "setup", map[string]string{"service": "api", "locale": "fr-ca"}))
+	})
+
+	t.Run("Fallback To Default Locale", func(t *testing.T) {
+		assert.Equal(t, "Set up api", render(t, "setup", map[string]string{"service": "api"}))
+		assert.Equal(t, "Set up api", render(t, "setup", map[string]string{"service": "api", "locale": "it"}))
+	})
+
+	t.Run("Configured Default Locale", func(t *testing.T) {
+		p := NewPromptProvider(defs, cp, WithDefaultLocale("de"))
+		messages, err := p.GetPrompt("setup", map[string]string{"service": "api"})
+		require.NoError(t, err)
+		assert.Equal(t, "Richte api ein", messages[0].Content.(*mcp.TextContent).Text)
+		for _, prompt := range p.ListPrompts() {
+			if prompt.Name == "setup" {
+				assert.Equal(t, "Einen Dienst einrichten", prompt.Description)
+			}
+		}
+	})
+
+	t.Run("Unlocalized Prompt Ignores Locale", func(t *testing.T) {
+		assert.Equal(t, "Review the code", render(t, "review", map[string]string{"locale": "de"}))
+	})
+
+	t.Run("Missing Prompt", func(t *testing.T) {
+		_, err := p.GetPrompt("deploy", map[string]string{"locale": "de"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown prompt: deploy")
+	})
+
+	t.Run("Listed Once With Locale Argument", func(t *testing.T) {
+		listed := p.ListPrompts()
+		require.Len(t, listed, 2)
+		byName := map[string]mcp.Prompt{}
+		for _, prompt := range listed {
+			byName[prompt.Name] = prompt
+		}
+		setup := byName["setup"]
+		assert.Equal(t, "Set up a service", setup.Description)
+		require.Len(t, setup.Arguments, 2)
+		assert.Equal(t, LocaleArgument, setup.Arguments[1].Name)
+		assert.False(t, setup.Arguments[1].Required)
+		assert.Contains(t, setup.Arguments[1].Description, "available: de, en, fr-ca; default: en")
+		assert.Len(t, byName["review"].Arguments, 0)
+	})
+
+	t.Run("Template Of Variant", func(t *testing.T) {
+		template, err := p.Template("setup", "de")
+		require.NoError(t, err)
+		assert.Equal(t, "Richte {{.service}} ein", template)
+	})
+
+	t.Run("Invalid Locale Skipped", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "bad.md"), []byte("---\nname: bad\ndescription: d\nlocale: [de]\n---\nx"), 0644))
+		defs, err := DiscoverPrompts(cp)
+		require.NoError(t, err)
+		assert.Len(t, defs, 4)
+	})
+}
+
+func TestNewPromptProvider_DuplicateVariant(t *testing.T) {
+	p := NewPromptProvider([]PromptDefinition{
+		{Name: "setup", Description: "first"},
+		{Name: "setup", Description: "second"},
+	}, nil)
+	listed := p.ListPrompts()
+	require.Len(t, listed, 1)
+	assert.Equal(t, "first", listed[0].Description)
+}
@@ -2,46 +2,126 @@ package prompts
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
 )
 
 // PromptProvider provides access to prompts
 type PromptProvider struct {
-	definitions []PromptDefinition
-	nameMap     map[string]PromptDefinition
-	cp          *content.ContentProvider
+	definitions   []PromptDefinition            // One per prompt name, in the default locale
+	variants      map[string][]PromptDefinition // Localized variants by prompt name
+	cp            *content.ContentProvider
+	defaultLocale string
 }
 
-// NewPromptProvider creates a new prompt provider
-func NewPromptProvider(definitions []PromptDefinition, cp *content.ContentProvider) *PromptProvider {
-	nameMap := make(map[string]PromptDefinition)
+// Option configures a PromptProvider
+type Option func(*PromptProvider)
+
+// WithDefaultLocale sets the locale served when a request names none, or
+// names one a prompt has no variant for. Defaults to DefaultLocale.
+func WithDefaultLocale(locale string) Option {
+	return func(p *PromptProvider) {
+		p.defaultLocale = locale
+	}
+}
+
+// NewPromptProvider creates a new prompt provider. Definitions sharing a name
+// are localized variants of one prompt, told apart by their locale.
+func NewPromptProvider(definitions []PromptDefinition, cp *content.ContentProvider, opts ...Option) *PromptProvider {
+	p := &PromptProvider{
+		variants:      make(map[string][]PromptDefinition),
+		cp:            cp,
+		defaultLocale: DefaultLocale,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	var names []string
 	for _, d := range definitions {
-		nameMap[d.Name] = d
+		variants := p.variants[d.Name]
+		if slices.ContainsFunc(variants, func(v PromptDefinition) bool { return v.Locale == d.Locale }) {
+			slog.Warn("Ignoring duplicate prompt", "name", d.Name, "locale", d.Locale, "file", d.FilePath)
+			continue
+		}
+		if len(variants) == 0 {
+			names = append(names, d.Name)
+		}
+		p.variants[d.Name] = append(variants, d)
+	}
+	for _, name := range names {
+		p.definitions = append(p.definitions, selectVariant(p.variants[name], "", p.defaultLocale))
+	}
+	return p
+}
+
+// variant returns the variant of a prompt for the requested locale
+func (p *PromptProvider) variant(name, locale string) (PromptDefinition, bool) {
+	variants, ok := p.variants[name]
+	if !ok {
+		return PromptDefinition{}, false
 	}
-	return &PromptProvider{
-		definitions: definitions,
-		nameMap:     nameMap,
-		cp:          cp,
+	return selectVariant(variants, locale, p.defaultLocale), true
+}
+
+// locales returns the sorted locales a prompt is available in
+func (p *PromptProvider) locales(name string) []string {
+	var locales []string
+	for _, v := range p.variants[name] {
+		if v.Locale != "" {
+			locales = append(locales, v.Locale)
+		}
 	}
+	slices.Sort(locales)
+	return locales
+}
+
+// listedArguments returns the arguments of a prompt as listed to clients.
+// Localized prompts get an optional locale argument unless they declare one.
+func (p *PromptProvider) listedArguments(d PromptDefinition) []PromptArgument {
+	locales := p.locales(d.Name)
+	if len(locales) == 0 || hasArgument(d.Arguments, LocaleArgument) {
+		return d.Arguments
+	}
+	return append(slices.Clone(d.Arguments), PromptArgument{
+		Name:        LocaleArgument,
+		Description: fmt.Sprintf("Language of the prompt (available: %s; default: %s)", strings.Join(locales, ", "), p.defaultLocale),
+	})
 }
 
 // ListPrompts lists all available prompts
 func (p *PromptProvider) ListPrompts() []mcp.Prompt {
 	prompts := make([]mcp.Prompt, len(p.definitions))
 	for i, d := range p.definitions {
-		args := make([]*mcp.PromptArgument, len(d.Arguments))
-		for j, a := range d.Arguments {
+		arguments := p.listedArguments(d)
+		args := make([]*mcp.PromptArgument, len(arguments))
+		for j, a := range arguments {
+			description := a.Description
+			if a.Type == ArgumentTypeObject {
+				description = strings.TrimSpace(description + " (JSON object)")
+			}
+			if len(a.Enum) > 0 {
+				description = strings.TrimSpace(fmt.Sprintf("%s (one of: %s)", description, strings.Join(a.Enum, ", ")))
+			}
+			if a.RequiredIf != nil && !a.Required {
+				description = strings.TrimSpace(fmt.Sprintf("%s (required when %s)", description, a.RequiredIf))
+			}
 			args[j] = &mcp.PromptArgument{
 				Name:        a.Name,
-				Description: a.Description,
+				Description: description,
 				Required:    a.Required,
 			}
 		}
@@ -51,44 +131,318 @@ func (p *PromptProvider) ListPrompts() []mcp.Prompt {
 			Description: d.Description,
 			Arguments:   args,
 		}
+		if len(arguments) > 0 {
+			prompts[i].Meta = mcp.Meta{"argument_schema": argumentSchema(arguments)}
+		}
 	}
 	return prompts
 }
 
-// GetPrompt renders a prompt by name with arguments
+// GetPrompt renders a prompt by name with arguments. The locale argument
+// selects among localized variants of the prompt.
 func (p *PromptProvider) GetPrompt(name string, arguments map[string]string) ([]*mcp.PromptMessage, error) {
-	defn, ok := p.nameMap[name]
+	defn, ok := p.variant(name, arguments[LocaleArgument])
 	if !ok {
 		return nil, fmt.Errorf("unknown prompt: %s", name)
 	}
 
-	// Validate required arguments
+	// Validate required arguments, including those required only under a condition
 	for _, arg := range defn.Arguments {
-		if arg.Required {
-			val, ok := arguments[arg.Name]
-			if !ok || val == "" {
-				return nil, fmt.Errorf("missing required argument: %s", arg.Name)
+		if value := arguments[arg.Name]; value != "" {
+			if len(arg.Enum) > 0 && !slices.Contains(arg.Enum, value) {
+				return nil, fmt.Errorf("argument %s must be one of: %s", arg.Name, strings.Join(arg.Enum, ", "))
 			}
+			continue
+		}
+		if arg.Required {
+			return nil, fmt.Errorf("missing required argument: %s", arg.Name)
+		}
+		if c := arg.RequiredIf; c != nil && arguments[c.Argument] == c.Value {
+			return nil, fmt.Errorf("missing required argument: %s (required when %s)", arg.Name, c)
 		}
 	}
 
-	var buf bytes.Buffer
-	if err := defn.Template.Execute(&buf, arguments); err != nil {
-		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	data, err := templateData(defn.Arguments, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := render(defn, data)
+	if err != nil {
+		return nil, err
+	}
+	if defn.Trim {
+		text = trimOutput(text)
+	}
+
+	role := defn.Role
+	if role == "" {
+		role = roleUser
+	}
+
+	var messageContent mcp.Content = &mcp.TextContent{Text: text}
+	if defn.ContentType == ContentTypeResource {
+		mimeType := defn.MIMEType
+		if mimeType == "" {
+			mimeType = defaultResourceMIMEType
+		}
+		messageContent = &mcp.EmbeddedResource{
+			Resource: &mcp.ResourceContents{
+				URI:      PromptURIScheme + "://" + defn.Name,
+				MIMEType: mimeType,
+				Text:     text,
+			},
+		}
 	}
 
 	return []*mcp.PromptMessage{
 		{
-			Role: "user",
-			Content: &mcp.TextContent{
-				Text: buf.String(),
-			},
+			Role:    role,
+			Content: messageContent,
 		},
 	}, nil
 }
 
+// render executes a prompt template. A panic during execution, e.g. in a
+// template function, is recovered and returned as an error, so one broken
+// prompt cannot take down the request.
+func render(defn PromptDefinition, data interface{}) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Prompt template panicked", "name", defn.Name, "panic", r)
+			err = fmt.Errorf("failed to execute prompt template %s: panic: %v", defn.Name, r)
+		}
+	}()
+
+	var buf bytes.Buffer
+	if err := defn.Template.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// trimOutput removes leading and trailing whitespace from rendered output and
+// collapses runs of blank (or whitespace-only) lines, such as those left by
+// block actions, into a single blank line
+func trimOutput(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	kept := lines[:0]
+	blank := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if blank {
+				continue
+			}
+			blank = true
+			kept = append(kept, "")
+			continue
+		}
+		blank = false
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// templateData builds the data a prompt template is rendered with. Declared
+// arguments are always present, so only undeclared keys can be missing. Plain
+// string arguments are passed as a map[string]string, which renders missing
+// keys as empty strings; prompts with object arguments get a map with their
+// decoded JSON values instead.
+func templateData(declared []PromptArgument, arguments map[string]string) (interface{}, error) {
+	strs := make(map[string]string, len(declared)+len(arguments))
+	for _, arg := range declared {
+		strs[arg.Name] = ""
+	}
+	for k, v := range arguments {
+		strs[k] = v
+	}
+
+	var data map[string]interface{}
+	for _, arg := range declared {
+		if arg.Type != ArgumentTypeObject {
+			continue
+		}
+		if data == nil {
+			data = make(map[string]interface{}, len(strs))
+			for k, v := range strs {
+				data[k] = v
+			}
+		}
+		object, err := decodeObjectArgument(arg.Name, strs[arg.Name])
+		if err != nil {
+			return nil, err
+		}
+		data[arg.Name] = object
+	}
+	if data == nil {
+		return strs, nil
+	}
+	return data, nil
+}
+
+// Template returns the unrendered template body of a prompt by name, in the
+// variant selected for locale
+func (p *PromptProvider) Template(name, locale string) (string, error) {
+	defn, ok := p.variant(name, locale)
+	if !ok {
+		return "", fmt.Errorf("unknown prompt: %s", name)
+	}
+	md, err := p.loader().LoadMarkdownWithFrontmatter(defn.FilePath)
+	if err != nil {
+		return "", err
+	}
+	return md.Content, nil
+}
+
+// decodeObjectArgument decodes the JSON object passed for an object argument.
+// An empty value decodes to an empty object.
+func decodeObjectArgument(name, value string) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	if strings.TrimSpace(value) == "" {
+		return object, nil
+	}
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return nil, fmt.Errorf("argument %s must be a JSON object: %w", name, err)
+	}
+	return object, nil
+}
+
+// loader returns the content provider used to load prompt files
+func (p *PromptProvider) loader() *content.ContentProvider {
+	if p.cp != nil {
+		return p.cp
+	}
+	return content.NewContentProvider("")
+}
+
+// PromptURIScheme is the URI scheme of prompt documents in the search index,
+// distinguishing them from resources (e.g. "prompt://code-review")
+const PromptURIScheme = "prompt"
+
+// StreamPrompts streams the raw template body of every prompt to a channel
+// as a searchable document. The description is prepended to the body so
+// prompts are findable by what they do, not only by their wording.
+func (p *PromptProvider) StreamPrompts(ctx context.Context, ch chan<- domain.Document) error {
+	for _, defn := range p.definitions {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		md, err := p.loader().LoadMarkdownWithFrontmatter(defn.FilePath)
+		if err != nil {
+			slog.Error("Error reading prompt for indexing", "name", defn.Name, "error", err)
+			continue
+		}
+
+		doc := domain.Document{
+			URI:         PromptURIScheme + "://" + defn.Name,
+			Name:        defn.Name,
+			Description: defn.Description,
+			Content:     defn.Description + "\n\n" + md.Content,
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ch <- doc:
+		}
+	}
+	return nil
+}
+
+// discoverConfig holds options for prompt discovery
+type discoverConfig struct {
+	strict          bool
+	missingKeyError bool
+	followSymlinks  bool
+	engine          string
+	trimOutput      bool
+	allowedFuncs    []string // nil allows all functions
+}
+
+// DiscoverOption configures prompt discovery.
+type DiscoverOption func(*discoverConfig)
+
+// WithStrictArguments makes discovery fail when a prompt template references
+// a field that is not declared as an argument. Without it, a warning is logged.
+func WithStrictArguments() DiscoverOption {
+	return func(c *discoverConfig) {
+		c.strict = true
+	}
+}
+
+// WithMissingKeyError makes template execution fail when a referenced key is
+// absent from the arguments, instead of rendering it as an empty value.
+// Individual prompts can override this with the `missingkey` frontmatter field.
+func WithMissingKeyError() DiscoverOption {
+	return func(c *discoverConfig) {
+		c.missingKeyError = true
+	}
+}
+
+// WithTrimOutput makes rendered prompts trimmed of surrounding whitespace, with
+// runs of blank lines collapsed into one. Individual prompts can override this
+// with the `trim` frontmatter field.
+func WithTrimOutput() DiscoverOption {
+	return func(c *discoverConfig) {
+		c.trimOutput = true
+	}
+}
+
+// WithAllowedFunctions restricts the template functions prompts may call to
+// the named ones. Prompts calling any other function are skipped. Discovery
+// fails if a name is not a template function.
+func WithAllowedFunctions(names ...string) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.allowedFuncs = append([]string{}, names...)
+	}
+}
+
+// WithFollowSymlinks makes discovery descend into symlinked directories.
+// Symlink loops are detected and skipped.
+func WithFollowSymlinks() DiscoverOption {
+	return func(c *discoverConfig) {
+		c.followSymlinks = true
+	}
+}
+
+// WithTemplateEngine sets the placeholder syntax of prompt templates to one of
+// EngineGo (the default), EngineSimple, or EngineMustache. Individual prompts
+// can override this with the `engine` frontmatter field.
+func WithTemplateEngine(engine string) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.engine = engine
+	}
+}
+
+// Supported values of the `missingkey` prompt frontmatter field
+const (
+	missingKeyZero  = "zero"
+	missingKeyError = "error"
+)
+
+// Message roles supported by MCP prompts. MCP has no system role; system-like
+// guidance is expressed as a user or assistant message.
+const (
+	roleUser      mcp.Role = "user"
+	roleAssistant mcp.Role = "assistant"
+)
+
+// defaultResourceMIMEType is used for resource content when `mime_type` is not set
+const defaultResourceMIMEType = "text/markdown"
+
 // DiscoverPrompts discovers prompts from markdown files
-func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
+func DiscoverPrompts(cp *content.ContentProvider, opts ...DiscoverOption) ([]PromptDefinition, error) {
+	cfg := discoverConfig{engine: EngineGo}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := checkFunctionNames(cfg.allowedFuncs); err != nil {
+		return nil, err
+	}
+
 	var definitions []PromptDefinition
 	promptsDir := cp.PromptsDir
 
@@ -102,7 +456,7 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 		return nil, err
 	}
 
-	err := filepath.WalkDir(promptsDir, func(path string, d fs.DirEntry, err error) error {
+	err := content.WalkDir(promptsDir, cfg.followSymlinks, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			slog.Error("Error walking prompts directory", "path", path, "error", err)
 			return nil // continue walking
@@ -114,8 +468,12 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 			return nil
 		}
 
-		// Parse frontmatter
-		md, err := cp.LoadMarkdownWithFrontmatter(path)
+		// Parse frontmatter from a consistent snapshot of the file
+		md, _, err := cp.LoadMarkdownSnapshot(path)
+		if errors.Is(err, content.ErrChangedDuringRead) {
+			slog.Warn("Skipping prompt file that changed during discovery", "file", d.Name())
+			return nil
+		}
 		if err != nil {
 			slog.Warn("Skipping invalid prompt file", "file", d.Name(), "error", err)
 			return nil
@@ -137,27 +495,139 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 				if amap, ok := a.(map[string]interface{}); ok {
 					argName, _ := amap["name"].(string)
 					argDesc, _ := amap["description"].(string)
+					requiredIf, err := parseArgumentCondition(amap["required_if"])
+					if err != nil {
+						slog.Warn("Skipping prompt with invalid required_if value", "file", d.Name(), "argument", argName, "error", err)
+						return nil
+					}
+					argType, _ := amap["type"].(string)
+					if argType != "" && argType != ArgumentTypeString && argType != ArgumentTypeObject {
+						slog.Warn("Skipping prompt with invalid argument type", "file", d.Name(), "argument", argName, "type", argType)
+						return nil
+					}
+					enum, err := parseEnum(amap["enum"])
+					if err != nil {
+						slog.Warn("Skipping prompt with invalid enum value", "file", d.Name(), "argument", argName, "error", err)
+						return nil
+					}
+					if len(enum) > 0 && argType == ArgumentTypeObject {
+						slog.Warn("Skipping prompt with enum on an object argument", "file", d.Name(), "argument", argName)
+						return nil
+					}
 					argReq, ok := amap["required"].(bool)
 					if !ok {
-						argReq = true // default to required
+						argReq = requiredIf == nil // default to required unless conditional
 					}
 					if argName != "" {
 						arguments = append(arguments, PromptArgument{
 							Name:        argName,
 							Description: argDesc,
 							Required:    argReq,
+							RequiredIf:  requiredIf,
+							Type:        argType,
+							Enum:        enum,
 						})
 					}
 				}
 			}
 		}
 
+		for _, arg := range arguments {
+			if arg.RequiredIf != nil && !hasArgument(arguments, arg.RequiredIf.Argument) {
+				slog.Warn("Skipping prompt with required_if referencing an undeclared argument", "file", d.Name(), "argument", arg.Name, "condition", arg.RequiredIf.String())
+				return nil
+			}
+		}
+
+		// Resolve missing key behavior, allowing the prompt to override the global default
+		missingKey := missingKeyZero
+		if cfg.missingKeyError {
+			missingKey = missingKeyError
+		}
+		if mk, ok := md.Metadata["missingkey"].(string); ok {
+			if mk != missingKeyZero && mk != missingKeyError {
+				slog.Warn("Skipping prompt with invalid missingkey value", "file", d.Name(), "value", mk)
+				return nil
+			}
+			missingKey = mk
+		}
+
+		// Resolve the message role and content type
+		role := roleUser
+		if r, ok := md.Metadata["role"].(string); ok {
+			if mcp.Role(r) != roleUser && mcp.Role(r) != roleAssistant {
+				slog.Warn("Skipping prompt with invalid role (MCP prompts support user and assistant)", "file", d.Name(), "value", r)
+				return nil
+			}
+			role = mcp.Role(r)
+		}
+		contentType := ContentTypeText
+		if ct, ok := md.Metadata["content_type"].(string); ok {
+			if ct != ContentTypeText && ct != ContentTypeResource {
+				slog.Warn("Skipping prompt with invalid content_type value", "file", d.Name(), "value", ct)
+				return nil
+			}
+			contentType = ct
+		}
+		mimeType, _ := md.Metadata["mime_type"].(string)
+
+		// Resolve the placeholder syntax, allowing the prompt to override the global default
+		engine := cfg.engine
+		if e, ok := md.Metadata["engine"].(string); ok {
+			if !isEngine(e) {
+				slog.Warn("Skipping prompt with invalid engine value", "file", d.Name(), "value", e)
+				return nil
+			}
+			engine = e
+		}
+
+		// Resolve output trimming, allowing the prompt to override the global default
+		trim := cfg.trimOutput
+		if t, ok := md.Metadata["trim"]; ok {
+			b, isBool := t.(bool)
+			if !isBool {
+				slog.Warn("Skipping prompt with invalid trim value", "file", d.Name(), "value", t)
+				return nil
+			}
+			trim = b
+		}
+
+		// Resolve the locale of a localized variant from the file name
+		// (e.g. setup.de.md), allowing the frontmatter to override it
+		locale := fileLocale(path)
+		if l, ok := md.Metadata["locale"]; ok {
+			s, isString := l.(string)
+			if !isString || !localeRegexp.MatchString(s) {
+				slog.Warn("Skipping prompt with invalid locale value", "file", d.Name(), "value", l)
+				return nil
+			}
+			locale = normalizeLocale(s)
+		}
+
 		// Parse and cache template
-		tmpl, err := template.New(name).Option("missingkey=zero").Parse(md.Content)
+		tmpl, err := template.New(name).Option("missingkey=" + missingKey).Parse(toGoTemplate(md.Content, engine))
 		if err != nil {
 			slog.Warn("Skipping prompt with invalid template", "file", d.Name(), "error", err)
 			return nil
 		}
+		if cfg.allowedFuncs != nil {
+			if disabled := disabledFunctions(tmpl, cfg.allowedFuncs); len(disabled) > 0 {
+				slog.Warn("Skipping prompt calling disabled template functions", "file", d.Name(), "functions", disabled)
+				return nil
+			}
+		}
+
+		// Cross-check declared arguments with the fields the template references
+		unused, undeclared := validateArguments(tmpl, arguments)
+		if len(unused) > 0 {
+			slog.Warn("Prompt declares arguments the template never uses", "name", name, "arguments", unused)
+		}
+		if len(undeclared) > 0 {
+			if cfg.strict {
+				return fmt.Errorf("prompt %s references undeclared arguments: %s", name, strings.Join(undeclared, ", "))
+			}
+			slog.Warn("Prompt template references undeclared arguments", "name", name, "arguments", undeclared)
+		}
 
 		definitions = append(definitions, PromptDefinition{
 			Name:        name,
@@ -165,6 +635,11 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 			Arguments:   arguments,
 			FilePath:    path,
 			Template:    tmpl,
+			Role:        role,
+			ContentType: contentType,
+			MIMEType:    mimeType,
+			Trim:        trim,
+			Locale:      locale,
 		})
 
 		slog.Info("Loaded prompt", "name", name)
@@ -174,3 +649,52 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 
 	return definitions, err
 }
+
+// parseArgumentCondition parses a required_if frontmatter value of the form
+// "argument=value". A missing value yields no condition.
+func parseArgumentCondition(value interface{}) (*ArgumentCondition, error) {
+	if value == nil {
+		return nil, nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string of the form argument=value, got %v", value)
+	}
+	name, val, found := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return nil, fmt.Errorf("expected a string of the form argument=value, got %q", s)
+	}
+	return &ArgumentCondition{Argument: name, Value: strings.TrimSpace(val)}, nil
+}
+
+// parseEnum parses the allowed values of an argument, a list of scalars
+func parseEnum(value interface{}) ([]string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of values, got %v", value)
+	}
+	enum := make([]string, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case string, bool, int, int64, uint64, float64:
+			enum = append(enum, fmt.Sprint(v))
+		default:
+			return nil, fmt.Errorf("expected scalar values, got %v", item)
+		}
+	}
+	return enum, nil
+}
+
+// hasArgument reports whether an argument with the given name is declared
+func hasArgument(arguments []PromptArgument, name string) bool {
+	for _, a := range arguments {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
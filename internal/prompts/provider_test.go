@@ -1,13 +1,17 @@
 package prompts
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"text/template"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDiscoverPrompts(t *testing.T) {
@@ -253,3 +257,431 @@ func TestPromptProvider_ListPrompts(t *testing.T) {
 	assert.Equal(t, "p1", list[0].Name)
 	assert.Equal(t, "a1", list[0].Arguments[0].Name)
 }
+
+func TestDiscoverPrompts_ArgumentValidation(t *testing.T) {
+	writePrompt := func(t *testing.T, body string) *content.ContentProvider {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(body), 0644)
+		return content.NewContentProvider(tempDir)
+	}
+
+	undeclared := "---\nname: p\ndescription: d\narguments:\n  - name: declared\n---\n{{.declared}} {{.typo}}"
+	unused := "---\nname: p\ndescription: d\narguments:\n  - name: declared\n  - name: extra\n---\n{{.declared}}"
+
+	t.Run("Undeclared warns by default", func(t *testing.T) {
+		defs, err := DiscoverPrompts(writePrompt(t, undeclared))
+		assert.NoError(t, err)
+		assert.Len(t, defs, 1)
+	})
+
+	t.Run("Undeclared fails in strict mode", func(t *testing.T) {
+		_, err := DiscoverPrompts(writePrompt(t, undeclared), WithStrictArguments())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "typo")
+	})
+
+	t.Run("Unused only warns in strict mode", func(t *testing.T) {
+		defs, err := DiscoverPrompts(writePrompt(t, unused), WithStrictArguments())
+		assert.NoError(t, err)
+		assert.Len(t, defs, 1)
+	})
+}
+
+func TestPromptProvider_GetPrompt_MissingKeyModes(t *testing.T) {
+	load := func(t *testing.T, md string, opts ...DiscoverOption) *PromptProvider {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(md), 0644)
+		cp := content.NewContentProvider(tempDir)
+		defs, err := DiscoverPrompts(cp, opts...)
+		assert.NoError(t, err)
+		assert.Len(t, defs, 1)
+		return NewPromptProvider(defs, cp)
+	}
+
+	undeclared := "---\nname: p\ndescription: d\narguments:\n  - name: opt\n    required: false\n---\nHello {{.opt}}{{.typo}}"
+
+	t.Run("Zero by default", func(t *testing.T) {
+		p := load(t, undeclared)
+		messages, err := p.GetPrompt("p", map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello ", messages[0].Content.(*mcp.TextContent).Text)
+	})
+
+	t.Run("Error globally", func(t *testing.T) {
+		p := load(t, undeclared, WithMissingKeyError())
+		_, err := p.GetPrompt("p", map[string]string{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "typo")
+	})
+
+	t.Run("Error does not affect declared optional arguments", func(t *testing.T) {
+		md := "---\nname: p\ndescription: d\narguments:\n  - name: opt\n    required: false\n---\nHello{{.opt}}"
+		p := load(t, md, WithMissingKeyError())
+		messages, err := p.GetPrompt("p", map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", messages[0].Content.(*mcp.TextContent).Text)
+	})
+
+	t.Run("Error via frontmatter", func(t *testing.T) {
+		md := "---\nname: p\ndescription: d\nmissingkey: error\n---\nHello {{.typo}}"
+		p := load(t, md)
+		_, err := p.GetPrompt("p", map[string]string{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Frontmatter overrides global", func(t *testing.T) {
+		md := "---\nname: p\ndescription: d\nmissingkey: zero\n---\nHello {{.typo}}"
+		p := load(t, md, WithMissingKeyError())
+		_, err := p.GetPrompt("p", map[string]string{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestPromptProvider_GetPrompt_RoleAndContentType(t *testing.T) {
+	discover := func(t *testing.T, md string) []PromptDefinition {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(md), 0644)
+		defs, err := DiscoverPrompts(content.NewContentProvider(tempDir))
+		assert.NoError(t, err)
+		return defs
+	}
+
+	t.Run("User text by default", func(t *testing.T) {
+		defs := discover(t, "---\nname: p\ndescription: d\n---\nHello")
+		assert.Len(t, defs, 1)
+		messages, err := NewPromptProvider(defs, nil).GetPrompt("p", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, mcp.Role("user"), messages[0].Role)
+		assert.Equal(t, "Hello", messages[0].Content.(*mcp.TextContent).Text)
+	})
+
+	t.Run("Assistant role", func(t *testing.T) {
+		defs := discover(t, "---\nname: p\ndescription: d\nrole: assistant\n---\nI will review the change.")
+		assert.Len(t, defs, 1)
+		messages, err := NewPromptProvider(defs, nil).GetPrompt("p", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, mcp.Role("assistant"), messages[0].Role)
+		assert.Equal(t, "I will review the change.", messages[0].Content.(*mcp.TextContent).Text)
+	})
+
+	t.Run("System role is rejected", func(t *testing.T) {
+		// MCP prompt messages only carry user and assistant roles
+		defs := discover(t, "---\nname: p\ndescription: d\nrole: system\n---\nYou are a reviewer.")
+		assert.Empty(t, defs)
+	})
+
+	t.Run("Embedded resource content", func(t *testing.T) {
+		defs := discover(t, "---\nname: p\ndescription: d\ncontent_type: resource\nmime_type: text/plain\n---\nChecklist")
+		assert.Len(t, defs, 1)
+		messages, err := NewPromptProvider(defs, nil).GetPrompt("p", nil)
+		assert.NoError(t, err)
+		resource, ok := messages[0].Content.(*mcp.EmbeddedResource)
+		assert.True(t, ok, "expected embedded resource content, got %T", messages[0].Content)
+		assert.Equal(t, "prompt://p", resource.Resource.URI)
+		assert.Equal(t, "text/plain", resource.Resource.MIMEType)
+		assert.Equal(t, "Checklist", resource.Resource.Text)
+	})
+
+	t.Run("Embedded resource defaults to markdown", func(t *testing.T) {
+		defs := discover(t, "---\nname: p\ndescription: d\ncontent_type: resource\n---\nChecklist")
+		messages, err := NewPromptProvider(defs, nil).GetPrompt("p", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "text/markdown", messages[0].Content.(*mcp.EmbeddedResource).Resource.MIMEType)
+	})
+
+	t.Run("Invalid content type is rejected", func(t *testing.T) {
+		defs := discover(t, "---\nname: p\ndescription: d\ncontent_type: image\n---\nHello")
+		assert.Empty(t, defs)
+	})
+}
+
+func TestPromptProvider_StreamPrompts(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "review.md")
+	err := os.WriteFile(path, []byte("---\nname: review\ndescription: Code review checklist\n---\nReview {{.file}} for error handling."), 0644)
+	assert.NoError(t, err)
+
+	p := NewPromptProvider([]PromptDefinition{
+		{Name: "review", Description: "Code review checklist", FilePath: path},
+	}, nil)
+
+	ch := make(chan domain.Document, 1)
+	assert.NoError(t, p.StreamPrompts(context.Background(), ch))
+	close(ch)
+
+	doc := <-ch
+	assert.Equal(t, "prompt://review", doc.URI)
+	assert.Equal(t, "review", doc.Name)
+	assert.Contains(t, doc.Content, "Code review checklist")
+	assert.Contains(t, doc.Content, "Review {{.file}} for error handling.")
+}
+
+func TestPromptProvider_StreamPrompts_Cancelled(t *testing.T) {
+	p := NewPromptProvider([]PromptDefinition{{Name: "p1"}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := p.StreamPrompts(ctx, make(chan domain.Document))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPromptProvider_GetPrompt_RequiredIf(t *testing.T) {
+	discover := func(t *testing.T, md string) []PromptDefinition {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(md), 0644)
+		defs, err := DiscoverPrompts(content.NewContentProvider(tempDir))
+		assert.NoError(t, err)
+		return defs
+	}
+
+	const prompt = "---\nname: p\ndescription: d\narguments:\n" +
+		"  - name: mode\n    description: Mode\n" +
+		"  - name: depth\n    description: Depth\n    required_if: mode=advanced\n" +
+		"---\n{{.mode}} {{.depth}}"
+
+	defs := discover(t, prompt)
+	assert.Len(t, defs, 1)
+	assert.False(t, defs[0].Arguments[1].Required)
+	assert.Equal(t, &ArgumentCondition{Argument: "mode", Value: "advanced"}, defs[0].Arguments[1].RequiredIf)
+	provider := NewPromptProvider(defs, nil)
+
+	t.Run("Condition triggered", func(t *testing.T) {
+		_, err := provider.GetPrompt("p", map[string]string{"mode": "advanced"})
+		assert.EqualError(t, err, "missing required argument: depth (required when mode=advanced)")
+
+		messages, err := provider.GetPrompt("p", map[string]string{"mode": "advanced", "depth": "3"})
+		assert.NoError(t, err)
+		assert.Equal(t, "advanced 3", messages[0].Content.(*mcp.TextContent).Text)
+	})
+
+	t.Run("Condition not triggered", func(t *testing.T) {
+		messages, err := provider.GetPrompt("p", map[string]string{"mode": "basic"})
+		assert.NoError(t, err)
+		assert.Equal(t, "basic ", messages[0].Content.(*mcp.TextContent).Text)
+	})
+
+	t.Run("Plain required still enforced", func(t *testing.T) {
+		_, err := provider.GetPrompt("p", nil)
+		assert.EqualError(t, err, "missing required argument: mode")
+	})
+
+	t.Run("Listed as optional with the condition", func(t *testing.T) {
+		args := provider.ListPrompts()[0].Arguments
+		assert.False(t, args[1].Required)
+		assert.Equal(t, "Depth (required when mode=advanced)", args[1].Description)
+	})
+
+	t.Run("Undeclared condition argument is rejected", func(t *testing.T) {
+		defs := discover(t, "---\nname: p\ndescription: d\narguments:\n  - name: depth\n    description: Depth\n    required_if: level=high\n---\n{{.depth}}")
+		assert.Empty(t, defs)
+	})
+
+	t.Run("Malformed condition is rejected", func(t *testing.T) {
+		defs := discover(t, "---\nname: p\ndescription: d\narguments:\n  - name: depth\n    description: Depth\n    required_if: advanced\n---\n{{.depth}}")
+		assert.Empty(t, defs)
+	})
+}
+
+func TestPromptProvider_GetPrompt_Engines(t *testing.T) {
+	discover := func(t *testing.T, md string, opts ...DiscoverOption) []PromptDefinition {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(md), 0644)
+		defs, err := DiscoverPrompts(content.NewContentProvider(tempDir), opts...)
+		assert.NoError(t, err)
+		return defs
+	}
+	const header = "---\nname: p\ndescription: d\narguments:\n  - name: topic\n    description: Topic\n"
+
+	tests := []struct {
+		name string
+		md   string
+		opts []DiscoverOption
+		want string
+	}{
+		{
+			name: "Simple via frontmatter",
+			md:   header + "engine: simple\n---\nSummarize {topic} as {\"summary\": \"{{.topic}}\"}",
+			want: "Summarize Go as {\"summary\": \"{{.topic}}\"}",
+		},
+		{
+			name: "Mustache via frontmatter",
+			md:   header + "engine: mustache\n---\nSummarize {{ topic }} in {{#lang}}",
+			want: "Summarize Go in {{#lang}}",
+		},
+		{
+			name: "Global engine",
+			md:   header + "---\nSummarize {topic}",
+			opts: []DiscoverOption{WithTemplateEngine(EngineSimple)},
+			want: "Summarize Go",
+		},
+		{
+			name: "Frontmatter overrides global engine",
+			md:   header + "engine: go\n---\nSummarize {{.topic}}",
+			opts: []DiscoverOption{WithTemplateEngine(EngineSimple)},
+			want: "Summarize Go",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defs := discover(t, tt.md, tt.opts...)
+			require.Len(t, defs, 1)
+			messages, err := NewPromptProvider(defs, nil).GetPrompt("p", map[string]string{"topic": "Go"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, messages[0].Content.(*mcp.TextContent).Text)
+		})
+	}
+
+	t.Run("Required arguments still validated", func(t *testing.T) {
+		defs := discover(t, header+"engine: simple\n---\nSummarize {topic}")
+		require.Len(t, defs, 1)
+		_, err := NewPromptProvider(defs, nil).GetPrompt("p", nil)
+		assert.EqualError(t, err, "missing required argument: topic")
+	})
+
+	t.Run("Undeclared placeholder fails strict discovery", func(t *testing.T) {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(header+"engine: mustache\n---\n{{topic}} {{other}}"), 0644)
+		_, err := DiscoverPrompts(content.NewContentProvider(tempDir), WithStrictArguments())
+		assert.ErrorContains(t, err, "undeclared arguments: other")
+	})
+
+	t.Run("Unknown engine is rejected", func(t *testing.T) {
+		defs := discover(t, header+"engine: jinja\n---\n{{ topic }}")
+		assert.Empty(t, defs)
+	})
+}
+
+func TestPromptProvider_GetPrompt_ObjectArguments(t *testing.T) {
+	load := func(t *testing.T, md string) []PromptDefinition {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(md), 0644)
+		defs, err := DiscoverPrompts(content.NewContentProvider(tempDir))
+		require.NoError(t, err)
+		return defs
+	}
+	md := "---\nname: p\ndescription: d\narguments:\n" +
+		"  - name: title\n    description: Title\n" +
+		"  - name: params\n    description: Parameters\n    type: object\n    required: false\n" +
+		"---\n{{.title}}: {{.params.service.name}} in {{.params.region}}{{range .params.tags}} #{{.}}{{end}}"
+
+	defs := load(t, md)
+	require.Len(t, defs, 1)
+	p := NewPromptProvider(defs, nil)
+
+	t.Run("Nested Value", func(t *testing.T) {
+		messages, err := p.GetPrompt("p", map[string]string{
+			"title":  "Deploy",
+			"params": `{"service": {"name": "api"}, "region": "eu-west-1", "tags": ["prod", "canary"]}`,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Deploy: api in eu-west-1 #prod #canary", messages[0].Content.(*mcp.TextContent).Text)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := p.GetPrompt("p", map[string]string{"title": "Deploy", "params": "region=eu"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "argument params must be a JSON object")
+	})
+
+	t.Run("Listed As JSON Object", func(t *testing.T) {
+		assert.Equal(t, "Parameters (JSON object)", p.ListPrompts()[0].Arguments[1].Description)
+	})
+
+	t.Run("Invalid Type Skipped", func(t *testing.T) {
+		defs := load(t, "---\nname: p\ndescription: d\narguments:\n  - name: n\n    type: number\n---\n{{.n}}")
+		assert.Empty(t, defs)
+	})
+}
+
+func TestGetPrompt_TemplatePanic(t *testing.T) {
+	panicking := template.Must(template.New("panics").Funcs(template.FuncMap{
+		"explode": func() string { panic("boom") },
+	}).Parse("Hello {{explode}}"))
+
+	p := NewPromptProvider([]PromptDefinition{
+		{Name: "func-panic", Template: panicking},
+		{Name: "no-template"}, // Executing a nil template panics
+	}, nil)
+
+	for _, name := range []string{"func-panic", "no-template"} {
+		t.Run(name, func(t *testing.T) {
+			var messages []*mcp.PromptMessage
+			var err error
+			require.NotPanics(t, func() { messages, err = p.GetPrompt(name, nil) })
+			require.Error(t, err)
+			assert.Nil(t, messages)
+			assert.Contains(t, err.Error(), "failed to execute prompt template")
+		})
+	}
+
+	t.Run("Reports Prompt Name", func(t *testing.T) {
+		_, err := p.GetPrompt("no-template", nil)
+		assert.Contains(t, err.Error(), "no-template")
+	})
+}
+
+func TestPromptProvider_GetPrompt_TrimOutput(t *testing.T) {
+	load := func(t *testing.T, md string, opts ...DiscoverOption) []PromptDefinition {
+		tempDir := t.TempDir()
+		promptsDir := filepath.Join(tempDir, "mcp-prompts")
+		_ = os.MkdirAll(promptsDir, 0755)
+		_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte(md), 0644)
+		defs, err := DiscoverPrompts(content.NewContentProvider(tempDir), opts...)
+		require.NoError(t, err)
+		return defs
+	}
+	render := func(t *testing.T, defs []PromptDefinition) string {
+		require.Len(t, defs, 1)
+		messages, err := NewPromptProvider(defs, nil).GetPrompt("p", map[string]string{"topic": "search"})
+		require.NoError(t, err)
+		return messages[0].Content.(*mcp.TextContent).Text
+	}
+
+	body := "{{if .topic}}\nTopic: {{.topic}}\n{{end}}\n\n\n{{range 2}}\n  \n{{end}}\nDone.\n\n"
+	frontmatter := func(extra string) string {
+		return "---\nname: p\ndescription: d\narguments:\n  - name: topic\n    required: false\n" + extra + "---\n" + body
+	}
+	raw := "\nTopic: search\n\n\n\n\n  \n\n  \n\nDone.\n\n"
+	trimmed := "Topic: search\n\nDone."
+
+	t.Run("Raw By Default", func(t *testing.T) {
+		assert.Equal(t, raw, render(t, load(t, frontmatter(""))))
+	})
+
+	t.Run("Trimmed Globally", func(t *testing.T) {
+		assert.Equal(t, trimmed, render(t, load(t, frontmatter(""), WithTrimOutput())))
+	})
+
+	t.Run("Trimmed Via Frontmatter", func(t *testing.T) {
+		assert.Equal(t, trimmed, render(t, load(t, frontmatter("trim: true\n"))))
+	})
+
+	t.Run("Frontmatter Overrides Global", func(t *testing.T) {
+		assert.Equal(t, raw, render(t, load(t, frontmatter("trim: false\n"), WithTrimOutput())))
+	})
+
+	t.Run("Invalid Value Skipped", func(t *testing.T) {
+		assert.Empty(t, load(t, frontmatter("trim: sometimes\n")))
+	})
+}
+
+func TestTrimOutput(t *testing.T) {
+	assert.Equal(t, "", trimOutput(" \n\t\n"))
+	assert.Equal(t, "a\n  b", trimOutput("a\n  b\n"))
+	assert.Equal(t, "a\n\nb\n\nc", trimOutput("a\n\n\t\n\nb\n\nc"))
+}
@@ -0,0 +1,53 @@
+package prompts
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// ArgumentSchema returns a JSON Schema describing the arguments of a prompt
+// by name, so clients can build forms for them. Arguments are properties of
+// an object, with their description and allowed values. Object arguments are
+// JSON-encoded strings on the wire, so they are described as strings with the
+// application/json media type. Conditionally required arguments are expressed
+// with if/then clauses.
+func (p *PromptProvider) ArgumentSchema(name string) (*jsonschema.Schema, error) {
+	defn, ok := p.variant(name, "")
+	if !ok {
+		return nil, fmt.Errorf("unknown prompt: %s", name)
+	}
+	return argumentSchema(p.listedArguments(defn)), nil
+}
+
+// argumentSchema builds the JSON Schema of a list of prompt arguments
+func argumentSchema(arguments []PromptArgument) *jsonschema.Schema {
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: make(map[string]*jsonschema.Schema, len(arguments)),
+	}
+	for _, arg := range arguments {
+		property := &jsonschema.Schema{Type: "string", Description: arg.Description}
+		if arg.Type == ArgumentTypeObject {
+			property.ContentMediaType = "application/json"
+		}
+		for _, v := range arg.Enum {
+			property.Enum = append(property.Enum, v)
+		}
+		schema.Properties[arg.Name] = property
+
+		if arg.Required {
+			schema.Required = append(schema.Required, arg.Name)
+		} else if c := arg.RequiredIf; c != nil {
+			var value any = c.Value
+			schema.AllOf = append(schema.AllOf, &jsonschema.Schema{
+				If: &jsonschema.Schema{
+					Properties: map[string]*jsonschema.Schema{c.Argument: {Const: &value}},
+					Required:   []string{c.Argument},
+				},
+				Then: &jsonschema.Schema{Required: []string{arg.Name}},
+			})
+		}
+	}
+	return schema
+}
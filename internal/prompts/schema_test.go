@@ -0,0 +1,82 @@
+package prompts
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgumentSchema(t *testing.T) {
+	tempDir := t.TempDir()
+	promptsDir := filepath.Join(tempDir, "mcp-prompts")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755))
+	md := "---\nname: deploy\ndescription: Deploy a service\narguments:\n" +
+		"  - name: service\n    description: Service name\n" +
+		"  - name: env\n    description: Target environment\n    enum: [staging, production]\n    required: false\n" +
+		"  - name: mode\n    description: Rollout mode\n    enum: [basic, advanced]\n    required: false\n" +
+		"  - name: params\n    description: Rollout parameters\n    type: object\n    required_if: mode=advanced\n" +
+		"---\n{{.service}} {{.env}} {{.mode}} {{.params}}"
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "deploy.md"), []byte(md), 0644))
+
+	defs, err := DiscoverPrompts(content.NewContentProvider(tempDir))
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	p := NewPromptProvider(defs, nil)
+
+	t.Run("Schema", func(t *testing.T) {
+		schema, err := p.ArgumentSchema("deploy")
+		require.NoError(t, err)
+		data, err := json.Marshal(schema)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"type": "object",
+			"properties": {
+				"service": {"type": "string", "description": "Service name"},
+				"env": {"type": "string", "description": "Target environment", "enum": ["staging", "production"]},
+				"mode": {"type": "string", "description": "Rollout mode", "enum": ["basic", "advanced"]},
+				"params": {"type": "string", "description": "Rollout parameters", "contentMediaType": "application/json"}
+			},
+			"required": ["service"],
+			"allOf": [{
+				"if": {"properties": {"mode": {"const": "advanced"}}, "required": ["mode"]},
+				"then": {"required": ["params"]}
+			}]
+		}`, string(data))
+	})
+
+	t.Run("Unknown Prompt", func(t *testing.T) {
+		_, err := p.ArgumentSchema("missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("Listed In Meta", func(t *testing.T) {
+		listed := p.ListPrompts()[0]
+		assert.NotNil(t, listed.Meta["argument_schema"])
+		assert.Equal(t, "Target environment (one of: staging, production)", listed.Arguments[1].Description)
+	})
+
+	t.Run("Enum Enforced", func(t *testing.T) {
+		_, err := p.GetPrompt("deploy", map[string]string{"service": "api", "env": "qa"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "argument env must be one of: staging, production")
+
+		_, err = p.GetPrompt("deploy", map[string]string{"service": "api", "env": "staging"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Enum On Object Skipped", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "mcp-prompts"), 0755))
+		invalid := "---\nname: p\ndescription: d\narguments:\n  - name: o\n    type: object\n    enum: [a]\n---\n{{.o}}"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "mcp-prompts", "p.md"), []byte(invalid), 0644))
+		defs, err := DiscoverPrompts(content.NewContentProvider(dir))
+		require.NoError(t, err)
+		assert.Empty(t, defs)
+	})
+}
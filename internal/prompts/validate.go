@@ -0,0 +1,103 @@
+package prompts
+
+import (
+	"sort"
+	"text/template"
+	"text/template/parse"
+)
+
+// templateFields returns the sorted, de-duplicated top-level field names a template
+// references on its root data (e.g. {{.foo}} and {{$.foo}} both yield "foo").
+// Fields referenced inside range/with bodies, where dot is rebound, are ignored.
+func templateFields(tmpl *template.Template) []string {
+	fields := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Root != nil {
+			collectFields(t.Root, true, fields)
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for f := range fields {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectFields walks a parse tree node, recording field names referenced on the root data.
+// rootDot reports whether dot refers to the template's root data at this point.
+func collectFields(node parse.Node, rootDot bool, fields map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			collectFields(c, rootDot, fields)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, rootDot, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFields(cmd, rootDot, fields)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, rootDot, fields)
+		}
+	case *parse.FieldNode:
+		if rootDot && len(n.Ident) > 0 {
+			fields[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		// $ always refers to the root data
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			fields[n.Ident[1]] = true
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, rootDot, fields)
+	case *parse.IfNode:
+		collectFields(n.Pipe, rootDot, fields)
+		collectFields(n.List, rootDot, fields)
+		collectFields(n.ElseList, rootDot, fields)
+	case *parse.RangeNode:
+		collectFields(n.Pipe, rootDot, fields)
+		collectFields(n.List, false, fields)
+		collectFields(n.ElseList, rootDot, fields)
+	case *parse.WithNode:
+		collectFields(n.Pipe, rootDot, fields)
+		collectFields(n.List, false, fields)
+		collectFields(n.ElseList, rootDot, fields)
+	case *parse.TemplateNode:
+		collectFields(n.Pipe, rootDot, fields)
+	}
+}
+
+// validateArguments cross-checks declared prompt arguments against the fields the
+// template references. It returns the declared arguments the template never uses
+// and the referenced fields that were never declared.
+func validateArguments(tmpl *template.Template, arguments []PromptArgument) (unused, undeclared []string) {
+	used := templateFields(tmpl)
+
+	usedSet := make(map[string]bool, len(used))
+	for _, f := range used {
+		usedSet[f] = true
+	}
+	declared := make(map[string]bool, len(arguments))
+	for _, a := range arguments {
+		declared[a.Name] = true
+		if !usedSet[a.Name] {
+			unused = append(unused, a.Name)
+		}
+	}
+	for _, f := range used {
+		if !declared[f] {
+			undeclared = append(undeclared, f)
+		}
+	}
+	return unused, undeclared
+}
@@ -0,0 +1,37 @@
+package prompts
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFields(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"Plain", "Hello {{.name}}", []string{"name"}},
+		{"Conditional", "{{if .commit}}{{.commit}}{{else}}{{.other}}{{end}}", []string{"commit", "other"}},
+		{"Root variable", "{{range .items}}{{$.title}} {{.inner}}{{end}}", []string{"items", "title"}},
+		{"With rebinds dot", "{{with .user}}{{.name}}{{else}}{{.fallback}}{{end}}", []string{"fallback", "user"}},
+		{"Pipeline args", `{{printf "%s-%s" .a .b}}`, []string{"a", "b"}},
+		{"None", "static text", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("t").Parse(tt.text))
+			assert.Equal(t, tt.want, templateFields(tmpl))
+		})
+	}
+}
+
+func TestValidateArguments(t *testing.T) {
+	tmpl := template.Must(template.New("t").Parse("{{.a}} {{.b}}"))
+	unused, undeclared := validateArguments(tmpl, []PromptArgument{{Name: "a"}, {Name: "c"}})
+	assert.Equal(t, []string{"c"}, unused)
+	assert.Equal(t, []string{"b"}, undeclared)
+}
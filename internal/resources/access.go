@@ -0,0 +1,80 @@
+package resources
+
+import "strings"
+
+// VisibleTo reports whether a caller holding the given roles may access the
+// resource. Resources without roles are public; otherwise one matching role
+// is enough.
+func (d ResourceDefinition) VisibleTo(roles []string) bool {
+	if len(d.Roles) == 0 {
+		return true
+	}
+	for _, want := range d.Roles {
+		for _, have := range roles {
+			if want == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AccessibleBy reports whether a caller holding the given roles may access
+// the resource identified by URI or unique name. Resources outside their
+// publishing window are not accessible to anyone. Unknown and ambiguous
+// resources are reported as accessible, so callers surface the usual error.
+func (p *ResourceProvider) AccessibleBy(uriOrName string, roles []string) bool {
+	defn, err := p.resolve(uriOrName)
+	if err != nil {
+		return true
+	}
+	return defn.VisibleTo(roles) && defn.PublishedAt(p.now())
+}
+
+// CountListed returns the number of listed resources a caller holding the
+// given roles may currently access
+func (p *ResourceProvider) CountListed(roles []string) int {
+	now := p.now()
+	count := 0
+	for _, d := range p.definitions {
+		if !d.Hidden && d.VisibleTo(roles) && d.PublishedAt(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// HasRestricted reports whether any resource is limited to certain roles or
+// to a publishing window, so that some callers may not access it.
+func (p *ResourceProvider) HasRestricted() bool {
+	for _, d := range p.definitions {
+		if len(d.Roles) > 0 || !d.PublishAt.IsZero() || !d.ExpireAt.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// stringList returns a frontmatter value that is either a single string or a
+// list of strings as a list, trimming blanks. Other values yield nil.
+func stringList(value interface{}) []string {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		values = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	var list []string
+	for _, s := range values {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResourceDefinition_VisibleTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		defRoles []string
+		roles    []string
+		want     bool
+	}{
+		{name: "Public Anonymous", defRoles: nil, roles: nil, want: true},
+		{name: "Public With Roles", defRoles: nil, roles: []string{"dev"}, want: true},
+		{name: "Restricted Anonymous", defRoles: []string{"internal"}, roles: nil, want: false},
+		{name: "Restricted Matching Role", defRoles: []string{"internal", "ops"}, roles: []string{"dev", "ops"}, want: true},
+		{name: "Restricted Other Role", defRoles: []string{"internal"}, roles: []string{"dev"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (ResourceDefinition{Roles: tt.defRoles}).VisibleTo(tt.roles); got != tt.want {
+				t.Errorf("VisibleTo(%v) = %v, want %v", tt.roles, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceProvider_AccessibleBy(t *testing.T) {
+	p := NewResourceProvider([]ResourceDefinition{
+		{URI: "acdc://public", Name: "public"},
+		{URI: "acdc://internal", Name: "internal", Roles: []string{"internal"}},
+	})
+
+	if !p.AccessibleBy("acdc://public", nil) {
+		t.Error("Expected public resource to be accessible")
+	}
+	if p.AccessibleBy("acdc://internal", nil) {
+		t.Error("Expected restricted resource to be denied without roles")
+	}
+	if p.AccessibleBy("internal", []string{"dev"}) {
+		t.Error("Expected restricted resource to be denied by name without a matching role")
+	}
+	if !p.AccessibleBy("internal", []string{"internal"}) {
+		t.Error("Expected restricted resource to be accessible by name with a matching role")
+	}
+	if !p.AccessibleBy("acdc://missing", nil) {
+		t.Error("Expected unknown resource to be reported as accessible")
+	}
+}
+
+func TestResourceProvider_CountListed(t *testing.T) {
+	p := NewResourceProvider([]ResourceDefinition{
+		{URI: "acdc://public"},
+		{URI: "acdc://hidden", Hidden: true},
+		{URI: "acdc://internal", Roles: []string{"internal"}},
+	})
+	if got := p.CountListed(nil); got != 1 {
+		t.Errorf("CountListed(nil) = %d, want 1", got)
+	}
+	if got := p.CountListed([]string{"internal"}); got != 2 {
+		t.Errorf("CountListed(internal) = %d, want 2", got)
+	}
+}
+
+func TestResourceProvider_HasRestricted(t *testing.T) {
+	tests := []struct {
+		name string
+		defn ResourceDefinition
+		want bool
+	}{
+		{name: "Public", defn: ResourceDefinition{URI: "acdc://a"}, want: false},
+		{name: "Roles", defn: ResourceDefinition{URI: "acdc://a", Roles: []string{"internal"}}, want: true},
+		{name: "Publish Window", defn: ResourceDefinition{URI: "acdc://a", ExpireAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewResourceProvider([]ResourceDefinition{tt.defn}).HasRestricted(); got != tt.want {
+				t.Errorf("HasRestricted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverResources_Roles(t *testing.T) {
+	_, defs := discoverFiles(t, map[string]string{
+		"public.md":   "---\nname: public\ndescription: D\n---\nBody",
+		"roles.md":    "---\nname: roles\ndescription: D\nroles:\n  - internal\n  - ops\n---\nBody",
+		"audience.md": "---\nname: audience\ndescription: D\naudience: partners\n---\nBody",
+	})
+
+	roles := make(map[string][]string)
+	for _, d := range defs {
+		roles[d.Name] = d.Roles
+	}
+	if got := roles["public"]; len(got) != 0 {
+		t.Errorf("Expected no roles for public resource, got %v", got)
+	}
+	if got := roles["roles"]; !reflect.DeepEqual(got, []string{"internal", "ops"}) {
+		t.Errorf("Expected roles [internal ops], got %v", got)
+	}
+	if got := roles["audience"]; !reflect.DeepEqual(got, []string{"partners"}) {
+		t.Errorf("Expected audience to be used as roles, got %v", got)
+	}
+}
@@ -0,0 +1,31 @@
+package resources
+
+import "fmt"
+
+// defaultBoost is the search score multiplier of resources that do not set
+// the `boost` frontmatter field
+const defaultBoost = 1.0
+
+// parseBoost parses the `boost` frontmatter field, a positive number that
+// scales the resource's search score. A missing value yields defaultBoost.
+func parseBoost(value interface{}) (float64, error) {
+	var boost float64
+	switch v := value.(type) {
+	case nil:
+		return defaultBoost, nil
+	case int:
+		boost = float64(v)
+	case int64:
+		boost = float64(v)
+	case uint64:
+		boost = float64(v)
+	case float64:
+		boost = v
+	default:
+		return 0, fmt.Errorf("expected a number, got %v", value)
+	}
+	if boost <= 0 {
+		return 0, fmt.Errorf("expected a positive number, got %v", value)
+	}
+	return boost, nil
+}
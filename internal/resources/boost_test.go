@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+func TestParseBoost(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    float64
+		wantErr bool
+	}{
+		{name: "Missing", value: nil, want: 1.0},
+		{name: "Integer", value: 2, want: 2.0},
+		{name: "Float", value: 0.5, want: 0.5},
+		{name: "Zero", value: 0, wantErr: true},
+		{name: "Negative", value: -1.5, wantErr: true},
+		{name: "String", value: "high", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBoost(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBoost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseBoost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverResources_Boost(t *testing.T) {
+	cp, defs := discoverFiles(t, map[string]string{
+		"boosted.md": "---\nname: boosted\ndescription: D\nboost: 1.5\n---\nC",
+		"plain.md":   "---\nname: plain\ndescription: D\n---\nC",
+		"invalid.md": "---\nname: invalid\ndescription: D\nboost: -2\n---\nC",
+	})
+	if len(defs) != 2 {
+		t.Fatalf("Expected the invalid boost to be skipped, got %d resources", len(defs))
+	}
+
+	ch := make(chan domain.Document, len(defs))
+	if err := NewResourceProvider(defs, WithContentProvider(cp)).StreamResources(context.Background(), ch); err != nil {
+		t.Fatalf("StreamResources error = %v", err)
+	}
+	close(ch)
+	boosts := make(map[string]float64)
+	for doc := range ch {
+		boosts[doc.URI] = doc.Boost
+	}
+	if boosts["acdc://boosted"] != 1.5 || boosts["acdc://plain"] != 1.0 {
+		t.Errorf("Unexpected indexed boosts: %v", boosts)
+	}
+}
@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// WithDuplicateDetection makes discovery designate a canonical resource for
+// each group of resources with identical content. The canonical is the member
+// another member declares with `canonical` frontmatter, or else the first one
+// discovered that is listed and unrestricted; the other members are marked as
+// its duplicates.
+func WithDuplicateDetection() DiscoverOption {
+	return func(c *discoverConfig) {
+		c.detectDuplicates = true
+	}
+}
+
+// NewCanonicalTransformer creates a ContentTransformer that prepends a notice
+// naming the canonical resource to the content of duplicate resources
+func NewCanonicalTransformer() ContentTransformer {
+	return func(content string, def ResourceDefinition) string {
+		if def.Canonical == "" {
+			return content
+		}
+		return fmt.Sprintf("> **Note:** This resource duplicates %s, the canonical version.\n\n%s", def.Canonical, content)
+	}
+}
+
+// contentHash returns a digest of resource content for duplicate detection.
+// Leading and trailing whitespace is ignored.
+func contentHash(content string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(strings.TrimSpace(content)))
+}
+
+// resolveCanonicals resolves the Canonical URIs of discovered definitions.
+// Declared canonicals are followed to the end of a chain, so every duplicate
+// points at a resource that is itself canonical. Declarations of an unknown
+// URI or that form a cycle are ignored with a warning. When hashes are given
+// (one per definition), content duplicates without a declaration are marked
+// as well. A canonical that is hidden, restricted or scheduled is dropped, so
+// listings, notices and links never point callers at a resource they may not
+// access, and its duplicates stay indexed in its place.
+func resolveCanonicals(definitions []ResourceDefinition, hashes [][sha256.Size]byte) {
+	byURI := make(map[string]int, len(definitions))
+	for i, d := range definitions {
+		byURI[d.URI] = i
+	}
+	for i, d := range definitions {
+		if d.Canonical == d.URI {
+			definitions[i].Canonical = ""
+		}
+	}
+
+	if hashes != nil {
+		markDuplicates(definitions, hashes, byURI)
+	}
+
+	// Resolve every chain against the declarations before updating any of them
+	resolved := make([]string, len(definitions))
+	for i := range definitions {
+		canonical, err := followCanonical(definitions, byURI, i)
+		if err != nil {
+			slog.Warn("Ignoring invalid canonical", "uri", definitions[i].URI, "error", err)
+		}
+		resolved[i] = canonical
+	}
+	for i, canonical := range resolved {
+		if canonical != "" && !definitions[byURI[canonical]].unrestricted() {
+			slog.Warn("Ignoring canonical that is not accessible to everyone", "uri", definitions[i].URI, "canonical", canonical)
+			canonical = ""
+		}
+		definitions[i].Canonical = canonical
+		if canonical != "" {
+			slog.Info("Resource is a duplicate", "uri", definitions[i].URI, "canonical", canonical)
+		}
+	}
+}
+
+// markDuplicates points the undeclared members of each group of identical
+// content at the group's canonical
+func markDuplicates(definitions []ResourceDefinition, hashes [][sha256.Size]byte, byURI map[string]int) {
+	groups := make(map[[sha256.Size]byte][]int)
+	for i, h := range hashes {
+		groups[h] = append(groups[h], i)
+	}
+
+	for i, h := range hashes {
+		members := groups[h]
+		if len(members) < 2 || members[0] != i {
+			continue // Handle each group once, at its first member
+		}
+
+		canonical := members[0]
+		for _, m := range members {
+			if definitions[m].unrestricted() {
+				canonical = m
+				break
+			}
+		}
+		for _, m := range members {
+			if j, ok := byURI[definitions[m].Canonical]; ok && hashes[j] == h {
+				canonical = j
+				break
+			}
+		}
+		for _, m := range members {
+			if m != canonical && definitions[m].Canonical == "" {
+				definitions[m].Canonical = definitions[canonical].URI
+			}
+		}
+	}
+}
+
+// unrestricted reports whether a resource is listed, indexed and accessible to
+// every caller at any time, so its duplicates can defer to it
+func (d ResourceDefinition) unrestricted() bool {
+	return !d.Hidden && len(d.Roles) == 0 && d.PublishAt.IsZero() && d.ExpireAt.IsZero()
+}
+
+// followCanonical returns the end of the canonical chain starting at the
+// definition at index i, or an empty string when it is canonical itself
+func followCanonical(definitions []ResourceDefinition, byURI map[string]int, i int) (string, error) {
+	visited := map[int]bool{i: true}
+	current := i
+	for definitions[current].Canonical != "" {
+		next, ok := byURI[definitions[current].Canonical]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrUnknownResource, definitions[current].Canonical)
+		}
+		if visited[next] {
+			return "", fmt.Errorf("canonical cycle through %s", definitions[next].URI)
+		}
+		visited[next] = true
+		current = next
+	}
+	if current == i {
+		return "", nil
+	}
+	return definitions[current].URI, nil
+}
@@ -0,0 +1,191 @@
+package resources
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+// canonicals maps resource URIs to their resolved canonical URIs
+func canonicals(defs []ResourceDefinition) map[string]string {
+	m := make(map[string]string, len(defs))
+	for _, d := range defs {
+		m[d.URI] = d.Canonical
+	}
+	return m
+}
+
+func TestCanonical_DeclaredDuplicate(t *testing.T) {
+	body := "Install the tools, then run [the checks](checks.md)."
+	files := map[string]string{
+		"guides/setup.md":  "---\nname: setup\ndescription: Setup\n---\n" + body,
+		"team/setup.md":    "---\nname: team-setup\ndescription: Team setup\ncanonical: acdc://guides/setup\n---\n" + body,
+		"guides/checks.md": "---\nname: checks\ndescription: Checks\n---\nRun [setup](../team/setup.md) first.",
+	}
+	cp, defs := discoverFiles(t, files)
+
+	want := map[string]string{"acdc://guides/setup": "", "acdc://team/setup": "acdc://guides/setup", "acdc://guides/checks": ""}
+	if got := canonicals(defs); !maps.Equal(got, want) {
+		t.Fatalf("Expected canonicals %v, got %v", want, got)
+	}
+
+	provider := NewResourceProvider(defs,
+		WithContentProvider(cp),
+		WithTransformer(NewCrossRefTransformer(defs, "acdc")),
+		WithTransformer(NewCanonicalTransformer()),
+	)
+
+	t.Run("Listing Meta", func(t *testing.T) {
+		for _, r := range provider.ListResources() {
+			got, _ := r.Meta["canonical"].(string)
+			if got != want[r.URI] {
+				t.Errorf("Expected canonical meta %q for %s, got %q", want[r.URI], r.URI, got)
+			}
+		}
+	})
+
+	t.Run("Read Notice", func(t *testing.T) {
+		got, err := provider.ReadResource("acdc://team/setup")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if !strings.HasPrefix(got, "> **Note:** This resource duplicates acdc://guides/setup, the canonical version.\n\n") {
+			t.Errorf("Expected a canonical notice, got %q", got)
+		}
+		if got, _ := provider.ReadResource("acdc://guides/setup"); strings.Contains(got, "**Note:**") {
+			t.Errorf("Expected no notice on the canonical resource, got %q", got)
+		}
+	})
+
+	t.Run("Cross References", func(t *testing.T) {
+		got, err := provider.ReadResource("acdc://guides/checks")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if want := "Run [setup](acdc://guides/setup) first."; got != want {
+			t.Errorf("Expected links to the duplicate to resolve to the canonical, got %q", got)
+		}
+	})
+
+	t.Run("Indexing", func(t *testing.T) {
+		ch := make(chan domain.Document, len(defs))
+		if err := provider.StreamResources(context.Background(), ch); err != nil {
+			t.Fatalf("StreamResources error = %v", err)
+		}
+		close(ch)
+		for doc := range ch {
+			if doc.URI == "acdc://team/setup" {
+				t.Error("Expected the duplicate not to be indexed")
+			}
+		}
+	})
+}
+
+func TestCanonical_DuplicateDetection(t *testing.T) {
+	files := map[string]string{
+		"a.md": "---\nname: a\ndescription: A\n---\nShared body\n",
+		"b.md": "---\nname: b\ndescription: B\n---\nShared body",
+		"c.md": "---\nname: c\ndescription: C\n---\nOther body",
+	}
+
+	_, defs := discoverFiles(t, files)
+	if got := canonicals(defs); !maps.Equal(got, map[string]string{"acdc://a": "", "acdc://b": "", "acdc://c": ""}) {
+		t.Errorf("Expected no duplicates without detection, got %v", got)
+	}
+
+	_, defs = discoverFiles(t, files, WithDuplicateDetection())
+	if got, want := canonicals(defs), map[string]string{"acdc://a": "", "acdc://b": "acdc://a", "acdc://c": ""}; !maps.Equal(got, want) {
+		t.Errorf("Expected the first discovered copy to be canonical %v, got %v", want, got)
+	}
+
+	// A declaration within the group picks its canonical
+	files["a.md"] = "---\nname: a\ndescription: A\ncanonical: acdc://b\n---\nShared body"
+	_, defs = discoverFiles(t, files, WithDuplicateDetection())
+	if got, want := canonicals(defs), map[string]string{"acdc://a": "acdc://b", "acdc://b": "", "acdc://c": ""}; !maps.Equal(got, want) {
+		t.Errorf("Expected the declared copy to be canonical %v, got %v", want, got)
+	}
+}
+
+func TestCanonical_RestrictedCopies(t *testing.T) {
+	files := map[string]string{
+		"a.md": "---\nname: a\ndescription: A\nhidden: true\n---\nShared body",
+		"b.md": "---\nname: b\ndescription: B\nroles: [internal]\n---\nShared body",
+		"c.md": "---\nname: c\ndescription: C\n---\nShared body",
+	}
+	indexed := func(t *testing.T, cp *content.ContentProvider, defs []ResourceDefinition) []string {
+		t.Helper()
+		ch := make(chan domain.Document, len(defs))
+		if err := NewResourceProvider(defs, WithContentProvider(cp)).StreamResources(context.Background(), ch); err != nil {
+			t.Fatalf("StreamResources error = %v", err)
+		}
+		close(ch)
+		var uris []string
+		for doc := range ch {
+			uris = append(uris, doc.URI)
+		}
+		return uris
+	}
+
+	t.Run("Implicit Canonical Is Unrestricted", func(t *testing.T) {
+		cp, defs := discoverFiles(t, files, WithDuplicateDetection())
+		if got, want := canonicals(defs), map[string]string{"acdc://a": "acdc://c", "acdc://b": "acdc://c", "acdc://c": ""}; !maps.Equal(got, want) {
+			t.Errorf("Expected the unrestricted copy to be canonical %v, got %v", want, got)
+		}
+		if got := indexed(t, cp, defs); !slices.Equal(got, []string{"acdc://c"}) {
+			t.Errorf("Expected only the canonical to be indexed, got %v", got)
+		}
+	})
+
+	t.Run("Restricted Canonical Dropped", func(t *testing.T) {
+		restricted := maps.Clone(files)
+		restricted["c.md"] = "---\nname: c\ndescription: C\ncanonical: acdc://b\n---\nShared body"
+		restricted["d.md"] = "---\nname: d\ndescription: D\n---\nSee [the copy](c.md)."
+		cp, defs := discoverFiles(t, restricted, WithDuplicateDetection())
+		if got, want := canonicals(defs), map[string]string{"acdc://a": "", "acdc://b": "", "acdc://c": "", "acdc://d": ""}; !maps.Equal(got, want) {
+			t.Errorf("Expected the restricted canonical to be dropped %v, got %v", want, got)
+		}
+		provider := NewResourceProvider(defs, WithContentProvider(cp), WithTransformer(NewCrossRefTransformer(defs, "acdc")))
+		for _, r := range provider.ListResources() {
+			if _, ok := r.Meta["canonical"]; ok {
+				t.Errorf("Expected no canonical meta on %s, got %v", r.URI, r.Meta)
+			}
+		}
+		if got, _ := provider.ReadResource("acdc://d"); got != "See [the copy](acdc://c)." {
+			t.Errorf("Expected links to keep the duplicate's own URI, got %q", got)
+		}
+		if got := indexed(t, cp, defs); !slices.Equal(got, []string{"acdc://b", "acdc://c", "acdc://d"}) {
+			t.Errorf("Expected the copies of a restricted canonical to stay indexed, got %v", got)
+		}
+	})
+}
+
+func TestCanonical_InvalidDeclarations(t *testing.T) {
+	files := map[string]string{
+		"chain.md":   "---\nname: chain\ndescription: Chain\ncanonical: acdc://middle\n---\nBody",
+		"middle.md":  "---\nname: middle\ndescription: Middle\ncanonical: acdc://end\n---\nBody",
+		"end.md":     "---\nname: end\ndescription: End\n---\nBody",
+		"self.md":    "---\nname: self\ndescription: Self\ncanonical: acdc://self\n---\nBody",
+		"unknown.md": "---\nname: unknown\ndescription: Unknown\ncanonical: acdc://missing\n---\nBody",
+		"x.md":       "---\nname: x\ndescription: X\ncanonical: acdc://y\n---\nBody",
+		"y.md":       "---\nname: y\ndescription: Y\ncanonical: acdc://x\n---\nBody",
+	}
+	_, defs := discoverFiles(t, files)
+
+	want := map[string]string{
+		"acdc://chain":   "acdc://end",
+		"acdc://middle":  "acdc://end",
+		"acdc://end":     "",
+		"acdc://self":    "",
+		"acdc://unknown": "",
+		"acdc://x":       "",
+		"acdc://y":       "",
+	}
+	if got := canonicals(defs); !maps.Equal(got, want) {
+		t.Errorf("Expected canonicals %v, got %v", want, got)
+	}
+}
@@ -14,16 +14,104 @@ import (
 //   - Group 3: optional title with leading space (e.g. ` "Title"`)
 var markdownLinkRe = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)(\s+"[^"]*")?\)`)
 
-// NewCrossRefTransformer creates a ContentTransformer that rewrites relative
-// markdown links to MCP resource URIs. The scheme parameter is used to
-// recognize and skip links that already use the configured URI scheme.
-func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string) ContentTransformer {
+// DefaultIndexFiles are the file names a link to a directory resolves to, in order of preference
+var DefaultIndexFiles = []string{"index.md", "README.md"}
+
+// LinkOption configures how relative links are resolved to resources and rewritten.
+type LinkOption func(*linkResolver)
+
+// WithIndexFiles sets the file names, in order of preference, that a link to
+// a directory (e.g. "guides/") resolves to. No names disables directory links.
+// Defaults to DefaultIndexFiles.
+func WithIndexFiles(names ...string) LinkOption {
+	return func(r *linkResolver) {
+		r.indexFiles = names
+	}
+}
+
+// WithPreserveOriginal makes the cross-ref transformer keep the original link
+// target in the link title, e.g. [text](other.md) becomes
+// [text](acdc://other "other.md") and [text](other.md "Other") becomes
+// [text](acdc://other "Other (other.md)").
+func WithPreserveOriginal() LinkOption {
+	return func(r *linkResolver) {
+		r.preserveOriginal = true
+	}
+}
+
+// linkResolver resolves relative markdown link targets to resource URIs
+type linkResolver struct {
+	filePathToURI    map[string]string
+	schemePrefix     string
+	indexFiles       []string
+	preserveOriginal bool
+}
+
+func newLinkResolver(definitions []ResourceDefinition, scheme string, opts ...LinkOption) linkResolver {
 	filePathToURI := make(map[string]string, len(definitions))
 	for _, d := range definitions {
-		filePathToURI[d.FilePath] = d.URI
+		// Links to a duplicate resolve to its canonical resource
+		if d.Canonical != "" {
+			filePathToURI[d.FilePath] = d.Canonical
+		} else {
+			filePathToURI[d.FilePath] = d.URI
+		}
+	}
+	r := linkResolver{filePathToURI: filePathToURI, schemePrefix: scheme + "://", indexFiles: DefaultIndexFiles}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// resolve returns the URI and fragment of the resource a relative link target
+// points to, resolved against currentDir. A target that names a directory
+// resolves to the first index file found in it. Fragment-only links, links
+// with a scheme, and targets that are not resources do not resolve.
+func (r linkResolver) resolve(target, currentDir string) (uri, fragment string, ok bool) {
+	// Skip fragment-only links
+	if strings.HasPrefix(target, "#") {
+		return "", "", false
+	}
+
+	// Skip links that already use the configured scheme or any other scheme
+	if strings.HasPrefix(target, r.schemePrefix) || strings.Contains(target, "://") {
+		return "", "", false
+	}
+
+	// Skip mailto: and other colon-prefixed schemes
+	if strings.Contains(target, ":") {
+		return "", "", false
 	}
 
-	schemePrefix := scheme + "://"
+	// Separate path from fragment
+	if idx := strings.Index(target, "#"); idx >= 0 {
+		fragment = target[idx:]
+		target = target[:idx]
+	}
+
+	// Resolve relative path against current document's directory
+	resolved := filepath.Clean(filepath.Join(currentDir, target))
+
+	// Look up in the file path to URI map
+	if uri, ok = r.filePathToURI[resolved]; ok {
+		return uri, fragment, true
+	}
+
+	// Fall back to the index file of a directory
+	for _, name := range r.indexFiles {
+		if uri, ok = r.filePathToURI[filepath.Join(resolved, name)]; ok {
+			return uri, fragment, true
+		}
+	}
+	return "", "", false
+}
+
+// NewCrossRefTransformer creates a ContentTransformer that rewrites relative
+// markdown links to MCP resource URIs. The scheme parameter is used to
+// recognize and skip links that already use the configured URI scheme.
+func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string, opts ...LinkOption) ContentTransformer {
+	resolver := newLinkResolver(definitions, scheme, opts...)
 
 	return func(content string, currentDef ResourceDefinition) string {
 		currentDir := filepath.Dir(currentDef.FilePath)
@@ -39,35 +127,13 @@ func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string) Con
 			target := groups[2]
 			title := groups[3] // includes leading space, e.g. ` "Title"`
 
-			// Skip fragment-only links
-			if strings.HasPrefix(target, "#") {
-				return match
-			}
-
-			// Skip links that already use the configured scheme or any other scheme
-			if strings.HasPrefix(target, schemePrefix) || strings.Contains(target, "://") {
-				return match
-			}
-
-			// Skip mailto: and other colon-prefixed schemes
-			if strings.Contains(target, ":") {
+			uri, fragment, ok := resolver.resolve(target, currentDir)
+			if !ok {
 				return match
 			}
 
-			// Separate path from fragment
-			fragment := ""
-			if idx := strings.Index(target, "#"); idx >= 0 {
-				fragment = target[idx:]
-				target = target[:idx]
-			}
-
-			// Resolve relative path against current document's directory
-			resolved := filepath.Clean(filepath.Join(currentDir, target))
-
-			// Look up in the file path to URI map
-			uri, ok := filePathToURI[resolved]
-			if !ok {
-				return match
+			if resolver.preserveOriginal {
+				title = originalTitle(title, target)
 			}
 
 			// Reconstruct: [text](uri#fragment "title")
@@ -84,3 +150,14 @@ func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string) Con
 		})
 	}
 }
+
+// originalTitle returns a link title (with its leading space) that carries the
+// original link target, appended to the existing title if there is one
+func originalTitle(title, target string) string {
+	target = strings.ReplaceAll(target, `"`, `\"`)
+	existing := strings.TrimSpace(title)
+	if existing == "" {
+		return ` "` + target + `"`
+	}
+	return ` "` + strings.Trim(existing, `"`) + " (" + target + `)"`
+}
@@ -409,3 +409,80 @@ func TestCrossRefTransformer_ConsecutiveLinksOnSameLine(t *testing.T) {
 		t.Errorf("got %q, want %q", got, want)
 	}
 }
+
+func TestCrossRefTransformer_DirectoryLinkResolvesToIndex(t *testing.T) {
+	defs := []ResourceDefinition{
+		{URI: "acdc://guides/index", FilePath: "/content/resources/guides/index.md"},
+		{URI: "acdc://api/README", FilePath: "/content/resources/api/README.md"},
+	}
+	transformer := NewCrossRefTransformer(defs, "acdc")
+
+	current := ResourceDefinition{FilePath: "/content/resources/current.md"}
+	input := "See [guides](guides/#start), [api](./api) and [notes](notes/)."
+	got := transformer(input, current)
+	want := "See [guides](acdc://guides/index#start), [api](acdc://api/README) and [notes](notes/)."
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCrossRefTransformer_DirectoryLinkWithoutIndexUnchanged(t *testing.T) {
+	defs := []ResourceDefinition{
+		{URI: "acdc://guides/intro", FilePath: "/content/resources/guides/intro.md"},
+	}
+	transformer := NewCrossRefTransformer(defs, "acdc")
+
+	current := ResourceDefinition{FilePath: "/content/resources/current.md"}
+	input := "See [guides](guides/) first."
+	got := transformer(input, current)
+
+	if got != input {
+		t.Errorf("got %q, want %q (unchanged)", got, input)
+	}
+}
+
+func TestCrossRefTransformer_CustomIndexFiles(t *testing.T) {
+	defs := []ResourceDefinition{
+		{URI: "acdc://guides/index", FilePath: "/content/resources/guides/index.md"},
+		{URI: "acdc://guides/overview", FilePath: "/content/resources/guides/overview.md"},
+	}
+	current := ResourceDefinition{FilePath: "/content/resources/current.md"}
+	input := "See [guides](guides/)."
+
+	got := NewCrossRefTransformer(defs, "acdc", WithIndexFiles("overview.md", "index.md"))(input, current)
+	if want := "See [guides](acdc://guides/overview)."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got = NewCrossRefTransformer(defs, "acdc", WithIndexFiles())(input, current)
+	if got != input {
+		t.Errorf("got %q, want %q (unchanged)", got, input)
+	}
+}
+
+func TestCrossRefTransformer_PreserveOriginal(t *testing.T) {
+	defs := []ResourceDefinition{
+		{URI: "acdc://guides/intro", FilePath: "/content/resources/guides/intro.md"},
+	}
+	transformer := NewCrossRefTransformer(defs, "acdc", WithPreserveOriginal())
+
+	current := ResourceDefinition{FilePath: "/content/resources/current.md"}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "Without Title", input: "[intro](guides/intro.md)", want: `[intro](acdc://guides/intro "guides/intro.md")`},
+		{name: "With Title", input: `[intro](guides/intro.md#setup "Intro")`, want: `[intro](acdc://guides/intro#setup "Intro (guides/intro.md#setup)")`},
+		{name: "Unresolved", input: "[missing](missing.md)", want: "[missing](missing.md)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transformer(tt.input, current); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
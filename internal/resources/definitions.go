@@ -1,19 +1,37 @@
 package resources
 
+import "time"
+
 // Field name constants for resource metadata
 const (
 	FieldURI      = "uri"
 	FieldName     = "name"
+	FieldTitle    = "title"
 	FieldContent  = "content"
 	FieldKeywords = "keywords"
 )
 
 // ResourceDefinition definition of an MCP resource
 type ResourceDefinition struct {
-	URI         string
-	Name        string
-	Description string
-	MIMEType    string
-	FilePath    string
-	Keywords    []string // Optional keywords for search boosting
+	ID               string // Stable identifier from frontmatter, or derived from the content
+	URI              string
+	Name             string
+	Title            string // Human-readable display title, defaults to Name
+	Description      string
+	MIMEType         string
+	FilePath         string
+	Keywords         []string          // Optional keywords for search boosting
+	Tags             []string          // Optional tags for filtering listings, not searched
+	Fields           map[string]string // Scalar frontmatter fields selected for indexing
+	LastModified     time.Time         // Modification time of the file at discovery
+	Size             int64             // Size of the file in bytes at discovery
+	Hidden           bool              // Excluded from listing and indexing, but still readable by URI
+	Deprecated       bool              // Marked as deprecated in favor of newer resources
+	DeprecatedReason string            // Optional explanation of the deprecation
+	SupersededBy     string            // Optional URI of the resource that replaces this one
+	Canonical        string            // URI of the canonical resource this one duplicates; empty when canonical
+	Roles            []string          // Roles allowed to access the resource; empty means public
+	PublishAt        time.Time         // Start of the publishing window; zero means no start
+	ExpireAt         time.Time         // End of the publishing window; zero means no end
+	Boost            float64           // Search score multiplier, 1.0 unless set in frontmatter
 }
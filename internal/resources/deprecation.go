@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// NewDeprecationTransformer creates a ContentTransformer that prepends a
+// deprecation banner to the content of deprecated resources, naming the
+// reason and the superseding resource when the frontmatter provides them.
+func NewDeprecationTransformer() ContentTransformer {
+	return func(content string, def ResourceDefinition) string {
+		if !def.Deprecated {
+			return content
+		}
+		return deprecationBanner(def) + "\n\n" + content
+	}
+}
+
+// deprecationBanner returns a markdown blockquote describing the deprecation
+func deprecationBanner(def ResourceDefinition) string {
+	var b strings.Builder
+	b.WriteString("> **Deprecated:** This resource is deprecated.")
+	if def.DeprecatedReason != "" {
+		fmt.Fprintf(&b, " %s", strings.TrimSpace(def.DeprecatedReason))
+	}
+	if def.SupersededBy != "" {
+		fmt.Fprintf(&b, " Use %s instead.", def.SupersededBy)
+	}
+	return b.String()
+}
+
+// deprecationMeta returns the listing metadata of a deprecated resource, or nil
+func deprecationMeta(def ResourceDefinition) mcp.Meta {
+	if !def.Deprecated {
+		return nil
+	}
+	meta := mcp.Meta{"deprecated": true}
+	if def.DeprecatedReason != "" {
+		meta["deprecated_reason"] = def.DeprecatedReason
+	}
+	if def.SupersededBy != "" {
+		meta["superseded_by"] = def.SupersededBy
+	}
+	return meta
+}
@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestDeprecationTransformer(t *testing.T) {
+	transformer := NewDeprecationTransformer()
+
+	tests := []struct {
+		name string
+		def  ResourceDefinition
+		want string
+	}{
+		{
+			name: "Not Deprecated",
+			def:  ResourceDefinition{},
+			want: "Body",
+		},
+		{
+			name: "Deprecated",
+			def:  ResourceDefinition{Deprecated: true},
+			want: "> **Deprecated:** This resource is deprecated.\n\nBody",
+		},
+		{
+			name: "Reason And Successor",
+			def:  ResourceDefinition{Deprecated: true, DeprecatedReason: "The old pipeline is retired.", SupersededBy: "acdc://guides/deploy"},
+			want: "> **Deprecated:** This resource is deprecated. The old pipeline is retired. Use acdc://guides/deploy instead.\n\nBody",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transformer("Body", tt.def); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecation_DiscoveryAndListing(t *testing.T) {
+	_, defs := discoverFiles(t, map[string]string{
+		"old.md": "---\nname: old\ndescription: Old\ndeprecated: true\ndeprecated_reason: Replaced.\nsuperseded_by: acdc://new\n---\nOld body",
+		"new.md": "---\nname: new\ndescription: New\n---\nNew body",
+	})
+	p := NewResourceProvider(defs, WithTransformer(NewDeprecationTransformer()))
+
+	meta := make(map[string]mcp.Meta)
+	for _, r := range p.ListResources() {
+		delete(r.Meta, "id")
+		meta[r.URI] = r.Meta
+	}
+	want := mcp.Meta{"deprecated": true, "deprecated_reason": "Replaced.", "superseded_by": "acdc://new"}
+	if !reflect.DeepEqual(meta["acdc://old"], want) {
+		t.Errorf("Expected listing meta %v, got %v", want, meta["acdc://old"])
+	}
+	if len(meta["acdc://new"]) != 0 {
+		t.Errorf("Expected no listing meta for a current resource, got %v", meta["acdc://new"])
+	}
+
+	got, err := p.ReadResource("acdc://old")
+	if err != nil {
+		t.Fatalf("ReadResource error = %v", err)
+	}
+	if wantContent := "> **Deprecated:** This resource is deprecated. Replaced. Use acdc://new instead.\n\nOld body"; got != wantContent {
+		t.Errorf("got %q, want %q", got, wantContent)
+	}
+}
@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// deriveName returns the text of the first level-one heading of a markdown
+// document, or the file name without its extension when there is none
+func deriveName(content, path string) string {
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if isFence(trimmed) {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && strings.HasPrefix(trimmed, "# ") {
+			if heading := strings.TrimSpace(strings.TrimRight(trimmed[2:], "#")); heading != "" {
+				return heading
+			}
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// deriveDescription returns the first paragraph of a markdown document, with
+// its lines joined by spaces. Headings and fenced code blocks are skipped.
+func deriveDescription(content string) string {
+	var paragraph []string
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if isFence(trimmed) {
+			inFence = !inFence
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+	return strings.Join(paragraph, " ")
+}
+
+// isFence reports whether a trimmed line opens or closes a fenced code block
+func isFence(line string) bool {
+	return strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~")
+}
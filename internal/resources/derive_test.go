@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+)
+
+func TestDeriveName(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "First H1", content: "Intro\n\n# Setup Guide\n\n# Other", want: "Setup Guide"},
+		{name: "Closing Hashes", content: "# Setup Guide ##\n", want: "Setup Guide"},
+		{name: "Ignores Lower Levels", content: "## Section\n\n# Title", want: "Title"},
+		{name: "Ignores Code Blocks", content: "```sh\n# comment\n```\n# Title", want: "Title"},
+		{name: "Filename Fallback", content: "No heading here", want: "setup-guide"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deriveName(tt.content, "/docs/guides/setup-guide.md"); got != tt.want {
+				t.Errorf("deriveName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeriveDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "Leading Paragraph", content: "# Title\n\nFirst line\nsecond line.\n\nNext paragraph.", want: "First line second line."},
+		{name: "Skips Code Blocks", content: "# Title\n\n```\ncode\n```\n\nText.", want: "Text."},
+		{name: "Stops At Heading", content: "Text.\n## Section", want: "Text."},
+		{name: "None", content: "# Title\n\n## Section\n", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deriveDescription(tt.content); got != tt.want {
+				t.Errorf("deriveDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverResources_DerivedMetadata(t *testing.T) {
+	files := map[string]string{
+		"plain.md":   "# Deploying Services\n\nHow services are rolled out\nto production.\n\n## Steps\n",
+		"partial.md": "---\nname: partial\n---\nThe body paragraph.",
+		"empty.md":   "# Only A Heading\n",
+	}
+
+	cp := content.NewContentProvider(writeFiles(t, files), content.WithOptionalFrontmatter())
+	defs, err := DiscoverResources(cp, "acdc", WithDerivedMetadata())
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	if len(defs) != 2 {
+		t.Fatalf("Expected 2 resources, got %d", len(defs))
+	}
+
+	byURI := make(map[string]ResourceDefinition)
+	for _, d := range defs {
+		byURI[d.URI] = d
+	}
+	plain := byURI["acdc://plain"]
+	if plain.Name != "Deploying Services" || plain.Description != "How services are rolled out to production." {
+		t.Errorf("Unexpected derived metadata: name %q, description %q", plain.Name, plain.Description)
+	}
+	if partial := byURI["acdc://partial"]; partial.Name != "partial" || partial.Description != "The body paragraph." {
+		t.Errorf("Unexpected metadata for partial frontmatter: name %q, description %q", partial.Name, partial.Description)
+	}
+
+	got, err := NewResourceProvider(defs, WithContentProvider(cp)).ReadResource("acdc://plain")
+	if err != nil {
+		t.Fatalf("ReadResource error = %v", err)
+	}
+	if got != files["plain.md"] {
+		t.Errorf("Expected the whole file as content, got %q", got)
+	}
+
+	// Without derivation, files lacking metadata are still skipped
+	defs, err = DiscoverResources(cp, "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	if len(defs) != 0 {
+		t.Errorf("Expected no resources without derived metadata, got %d", len(defs))
+	}
+}
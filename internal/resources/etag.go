@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+)
+
+// computeETag returns a strong ETag for the given content
+func computeETag(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagEntry is a cached ETag along with the file state it was computed from
+type etagEntry struct {
+	modTime time.Time
+	size    int64
+	etag    string
+}
+
+// etagCache caches ETags per resource URI. Entries are invalidated when the
+// underlying file's modification time or size changes.
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]etagEntry)}
+}
+
+func (c *etagCache) get(uri string, info os.FileInfo) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[uri]
+	if !ok || !e.modTime.Equal(info.ModTime()) || e.size != info.Size() {
+		return "", false
+	}
+	return e.etag, true
+}
+
+// fileState returns the modification time and size of a resource file as of
+// its last read, if it has been read
+func (c *etagCache) fileState(uri string) (time.Time, int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[uri]
+	return e.modTime, e.size, ok
+}
+
+func (c *etagCache) put(uri string, info os.FileInfo, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[uri] = etagEntry{modTime: info.ModTime(), size: info.Size(), etag: etag}
+}
+
+// ETag returns the ETag of a resource's current content. The value is
+// computed lazily on first use and cached until the resource file changes,
+// so checking an unchanged resource does not re-read or re-transform it.
+func (p *ResourceProvider) ETag(uri string) (string, error) {
+	defn, err := p.resolveForRead(uri)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(defn.FilePath)
+	if err != nil {
+		return "", err
+	}
+	if etag, ok := p.etags.get(defn.URI, info); ok {
+		return etag, nil
+	}
+
+	_, etag, err := p.read(defn)
+	return etag, err
+}
@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResourceProvider_ETag(t *testing.T) {
+	tmp := t.TempDir()
+	f := filepath.Join(tmp, "doc.md")
+	write := func(body string) {
+		if err := os.WriteFile(f, []byte("---\nname: Doc\ndescription: D\n---\n"+body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("original")
+
+	p := NewResourceProvider([]ResourceDefinition{{URI: "acdc://doc", Name: "Doc", FilePath: f}})
+
+	content, readETag, err := p.ReadResourceWithETag("acdc://doc")
+	if err != nil {
+		t.Fatalf("ReadResourceWithETag error = %v", err)
+	}
+	if content != "original" {
+		t.Errorf("content = %q, want %q", content, "original")
+	}
+	if readETag == "" {
+		t.Fatal("Expected a non-empty ETag")
+	}
+
+	t.Run("Unchanged", func(t *testing.T) {
+		etag, err := p.ETag("acdc://doc")
+		if err != nil {
+			t.Fatalf("ETag error = %v", err)
+		}
+		if etag != readETag {
+			t.Errorf("ETag = %s, want %s", etag, readETag)
+		}
+		// Name resolution yields the same ETag
+		if byName, _ := p.ETag("Doc"); byName != readETag {
+			t.Errorf("ETag by name = %s, want %s", byName, readETag)
+		}
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		write("modified content")
+
+		etag, err := p.ETag("acdc://doc")
+		if err != nil {
+			t.Fatalf("ETag error = %v", err)
+		}
+		if etag == readETag {
+			t.Error("Expected ETag to change after the content changed")
+		}
+
+		_, newReadETag, err := p.ReadResourceWithETag("acdc://doc")
+		if err != nil {
+			t.Fatalf("ReadResourceWithETag error = %v", err)
+		}
+		if newReadETag != etag {
+			t.Errorf("ReadResourceWithETag ETag = %s, want %s", newReadETag, etag)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		if _, err := p.ETag("acdc://missing"); err == nil {
+			t.Error("Expected error for unknown resource")
+		}
+	})
+}
+
+func TestComputeETag(t *testing.T) {
+	if computeETag("a") != computeETag("a") {
+		t.Error("Expected identical content to produce identical ETags")
+	}
+	if computeETag("a") == computeETag("b") {
+		t.Error("Expected different content to produce different ETags")
+	}
+}
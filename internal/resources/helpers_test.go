@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+)
+
+// writeFiles writes resource files, keyed by their path relative to
+// mcp-resources, to a temporary content directory and returns its path
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	for name, body := range files {
+		path := filepath.Join(resDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmp
+}
+
+// discoverFiles writes resource files to a temporary content directory and
+// discovers them
+func discoverFiles(t *testing.T, files map[string]string, opts ...DiscoverOption) (*content.ContentProvider, []ResourceDefinition) {
+	t.Helper()
+	cp := content.NewContentProvider(writeFiles(t, files))
+	defs, err := DiscoverResources(cp, "acdc", opts...)
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	return cp, defs
+}
@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// parseID parses the `id` frontmatter field, a non-empty string that
+// identifies the resource independently of its URI. A missing value yields
+// an ID derived from the resource content, which survives renames and moves
+// but changes when the content is edited. explicit reports whether the ID
+// was set in the frontmatter.
+func parseID(value interface{}, content string) (id string, explicit bool, err error) {
+	if value == nil {
+		return contentID(content), false, nil
+	}
+	id, ok := value.(string)
+	if !ok || strings.TrimSpace(id) == "" {
+		return "", false, fmt.Errorf("expected a non-empty string, got %v", value)
+	}
+	return strings.TrimSpace(id), true, nil
+}
+
+// resolveIDs makes the IDs of discovered definitions unique. An explicit ID
+// repeated by later resources is kept by the first one and ignored with a
+// warning for the others. Those, and resources whose derived IDs collide,
+// e.g. copies with identical content, get IDs qualified by their URI.
+// explicit holds one flag per definition.
+func resolveIDs(definitions []ResourceDefinition, explicit []bool) {
+	owners := make(map[string]int, len(definitions))
+	for i, d := range definitions {
+		if !explicit[i] {
+			continue
+		}
+		if first, taken := owners[d.ID]; taken {
+			slog.Warn("Ignoring duplicate resource id", "uri", d.URI, "id", d.ID, "owner", definitions[first].URI)
+			definitions[i].ID = qualifiedID(d.ID, d.URI)
+			continue
+		}
+		owners[d.ID] = i
+	}
+
+	derived := make(map[string]int, len(definitions))
+	for i, d := range definitions {
+		if !explicit[i] {
+			derived[d.ID]++
+		}
+	}
+	for i, d := range definitions {
+		if _, taken := owners[d.ID]; !explicit[i] && (derived[d.ID] > 1 || taken) {
+			definitions[i].ID = qualifiedID(d.ID, d.URI)
+		}
+	}
+}
+
+// contentID derives a resource ID from a hash of its content
+func contentID(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256-" + hex.EncodeToString(sum[:8])
+}
+
+// qualifiedID derives a resource ID from another ID and the resource URI, to
+// tell apart resources that would otherwise share an ID
+func qualifiedID(id, uri string) string {
+	return contentID(id + "\x00" + uri)
+}
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"testing"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        interface{}
+		want         string
+		wantExplicit bool
+		wantErr      bool
+	}{
+		{name: "Explicit", value: "setup-guide", want: "setup-guide", wantExplicit: true},
+		{name: "Trimmed", value: "  setup-guide ", want: "setup-guide", wantExplicit: true},
+		{name: "Missing", value: nil, want: contentID("Body")},
+		{name: "Empty", value: " ", wantErr: true},
+		{name: "Number", value: 42, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, explicit, err := parseID(tt.value, "Body")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || explicit != tt.wantExplicit {
+				t.Errorf("parseID() = %q, %v, want %q, %v", got, explicit, tt.want, tt.wantExplicit)
+			}
+		})
+	}
+}
+
+func TestDiscoverResources_ID(t *testing.T) {
+	_, defs := discoverFiles(t, map[string]string{
+		"explicit.md":   "---\nname: explicit\ndescription: D\nid: setup-guide\n---\nC",
+		"derived.md":    "---\nname: derived\ndescription: D\n---\nShared body",
+		"moved/copy.md": "---\nname: copy\ndescription: Other\n---\nShared body",
+		"edited.md":     "---\nname: edited\ndescription: D\n---\nEdited body",
+		"invalid.md":    "---\nname: invalid\ndescription: D\nid: 7\n---\nC",
+		"reused.md":     "---\nname: reused\ndescription: D\nid: setup-guide\n---\nR",
+	})
+	if len(defs) != 5 {
+		t.Fatalf("Expected 5 resources, the invalid id skipped, got %d", len(defs))
+	}
+	ids := make(map[string]string)
+	for _, d := range defs {
+		ids[d.URI] = d.ID
+	}
+
+	if ids["acdc://explicit"] != "setup-guide" {
+		t.Errorf("Expected the frontmatter id, got %q", ids["acdc://explicit"])
+	}
+	// The first resource discovered keeps a repeated explicit id
+	if ids["acdc://reused"] == "setup-guide" || ids["acdc://reused"] == "" {
+		t.Errorf("Expected the repeated explicit id to be replaced, got %q", ids["acdc://reused"])
+	}
+	// Copies with equal content do not share a derived ID
+	if ids["acdc://derived"] == "" || ids["acdc://moved/copy"] == "" || ids["acdc://derived"] == ids["acdc://moved/copy"] {
+		t.Errorf("Expected distinct derived IDs for copies, got %q and %q", ids["acdc://derived"], ids["acdc://moved/copy"])
+	}
+	if ids["acdc://edited"] != contentID("Edited body") {
+		t.Errorf("Expected a unique derived ID to depend on the content only, got %q", ids["acdc://edited"])
+	}
+	if ids["acdc://edited"] == ids["acdc://derived"] {
+		t.Errorf("Expected different derived IDs for different content, got %q", ids["acdc://edited"])
+	}
+
+	for _, r := range NewResourceProvider(defs).ListResources() {
+		if r.Meta["id"] != ids[r.URI] {
+			t.Errorf("Expected listing meta id %q for %s, got %v", ids[r.URI], r.URI, r.Meta["id"])
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// NewImageTransformer creates a ContentTransformer that rewrites relative
+// markdown image references to absolute URLs under baseURL, so clients can
+// fetch images that are served alongside the content. Image paths are
+// resolved against the resource's directory and mapped to their path relative
+// to resourcesDir, e.g. ![d](diagram.png) in guides/setup.md becomes
+// ![d](<baseURL>/guides/diagram.png). Absolute URLs, root-relative paths, and
+// images outside resourcesDir are left unchanged.
+func NewImageTransformer(resourcesDir, baseURL string) ContentTransformer {
+	base := strings.TrimSuffix(baseURL, "/")
+
+	return func(content string, currentDef ResourceDefinition) string {
+		currentDir := filepath.Dir(currentDef.FilePath)
+
+		return markdownLinkRe.ReplaceAllStringFunc(content, func(match string) string {
+			// Only images; links are handled by the cross-ref transformer
+			if !strings.HasPrefix(match, "!") {
+				return match
+			}
+
+			groups := markdownLinkRe.FindStringSubmatch(match)
+			altText := groups[1]
+			target := groups[2]
+			title := groups[3]
+
+			// Skip absolute URLs, data URIs, and root-relative or fragment-only targets
+			if strings.Contains(target, ":") || strings.HasPrefix(target, "/") || strings.HasPrefix(target, "#") {
+				return match
+			}
+
+			// Keep any query or fragment as is
+			path, suffix := target, ""
+			if idx := strings.IndexAny(target, "?#"); idx >= 0 {
+				path, suffix = target[:idx], target[idx:]
+			}
+
+			rel, err := filepath.Rel(resourcesDir, filepath.Join(currentDir, path))
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return match
+			}
+
+			return "![" + altText + "](" + base + "/" + filepath.ToSlash(rel) + suffix + title + ")"
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"testing"
+)
+
+func TestImageTransformer(t *testing.T) {
+	transformer := NewImageTransformer("/content/resources", "https://assets.example.com/docs/")
+	current := ResourceDefinition{FilePath: "/content/resources/guides/setup.md"}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "Relative Image",
+			input: "![diagram](diagram.png)",
+			want:  "![diagram](https://assets.example.com/docs/guides/diagram.png)",
+		},
+		{
+			name:  "Parent Directory With Title",
+			input: `![logo](../img/logo.svg "Logo")`,
+			want:  `![logo](https://assets.example.com/docs/img/logo.svg "Logo")`,
+		},
+		{
+			name:  "Query Preserved",
+			input: "![d](./d.png?v=2)",
+			want:  "![d](https://assets.example.com/docs/guides/d.png?v=2)",
+		},
+		{
+			name:  "Absolute URL Unchanged",
+			input: "![d](https://cdn.example.com/d.png)",
+			want:  "![d](https://cdn.example.com/d.png)",
+		},
+		{
+			name:  "Data URI Unchanged",
+			input: "![d](data:image/png;base64,AAAA)",
+			want:  "![d](data:image/png;base64,AAAA)",
+		},
+		{
+			name:  "Root Relative Unchanged",
+			input: "![d](/static/d.png)",
+			want:  "![d](/static/d.png)",
+		},
+		{
+			name:  "Outside Resources Unchanged",
+			input: "![d](../../shared/d.png)",
+			want:  "![d](../../shared/d.png)",
+		},
+		{
+			name:  "Links Unchanged",
+			input: "[doc](other.md) and [file](diagram.png)",
+			want:  "[doc](other.md) and [file](diagram.png)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transformer(tt.input, current); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package resources
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// VerifyIntegrity compares the SHA-256 checksums of the discovered resource
+// files with a manifest in sha256sum format ("<hex digest>  <path>"), where
+// paths are relative to contentDir. Checksum mismatches and manifest entries
+// without a discovered resource are reported as errors; resources missing
+// from the manifest are logged as warnings.
+func VerifyIntegrity(definitions []ResourceDefinition, contentDir, manifestPath string) error {
+	expected, err := readIntegrityManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	seen := make(map[string]bool, len(definitions))
+	for _, d := range definitions {
+		rel, err := filepath.Rel(contentDir, d.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve resource path %s: %w", d.FilePath, err)
+		}
+		rel = filepath.ToSlash(rel)
+		seen[rel] = true
+
+		data, err := os.ReadFile(d.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to read resource %s: %w", rel, err)
+		}
+		sum := sha256.Sum256(data)
+		actual := hex.EncodeToString(sum[:])
+		slog.Debug("Resource checksum", "path", rel, "sha256", actual)
+
+		want, ok := expected[rel]
+		if !ok {
+			slog.Warn("Resource not listed in integrity manifest", "path", rel)
+			continue
+		}
+		if want != actual {
+			errs = append(errs, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", rel, want, actual))
+		}
+	}
+
+	var missing []string
+	for path := range expected {
+		if !seen[path] {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+	for _, path := range missing {
+		errs = append(errs, fmt.Errorf("resource listed in integrity manifest not found: %s", path))
+	}
+
+	return errors.Join(errs...)
+}
+
+// readIntegrityManifest parses a sha256sum-style manifest into a map of
+// slash-separated relative paths to lowercase hex digests. Blank lines and
+// lines starting with '#' are ignored.
+func readIntegrityManifest(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read integrity manifest: %w", err)
+	}
+
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		digest, file, ok := strings.Cut(line, " ")
+		file = strings.TrimPrefix(strings.TrimSpace(file), "*")
+		if _, err := hex.DecodeString(digest); !ok || err != nil || len(digest) != sha256.Size*2 || file == "" {
+			return nil, fmt.Errorf("invalid integrity manifest entry at line %d: %q", lineNum, line)
+		}
+		entries[filepath.ToSlash(filepath.Clean(file))] = strings.ToLower(digest)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read integrity manifest: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+)
+
+const integrityGuide = "---\nname: guide\ndescription: Guide\n---\nGuide content."
+
+// writeIntegrityContent writes a single resource and returns its definitions
+// along with the content directory
+func writeIntegrityContent(t *testing.T) ([]ResourceDefinition, string) {
+	t.Helper()
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources", "docs")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(resDir, "guide.md"), []byte(integrityGuide), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	return defs, tmp
+}
+
+func writeManifest(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "SHA256SUMS")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifyIntegrity_Match(t *testing.T) {
+	defs, contentDir := writeIntegrityContent(t)
+	manifest := writeManifest(t, "# generated", "", sha256Hex(integrityGuide)+"  mcp-resources/docs/guide.md")
+
+	if err := VerifyIntegrity(defs, contentDir, manifest); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyIntegrity_Mismatch(t *testing.T) {
+	defs, contentDir := writeIntegrityContent(t)
+	manifest := writeManifest(t, sha256Hex("tampered")+"  mcp-resources/docs/guide.md")
+
+	err := VerifyIntegrity(defs, contentDir, manifest)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch for mcp-resources/docs/guide.md") {
+		t.Errorf("expected checksum mismatch error, got %v", err)
+	}
+}
+
+func TestVerifyIntegrity_MissingResource(t *testing.T) {
+	defs, contentDir := writeIntegrityContent(t)
+	manifest := writeManifest(t,
+		sha256Hex(integrityGuide)+"  mcp-resources/docs/guide.md",
+		sha256Hex("other")+" *mcp-resources/other.md",
+	)
+
+	err := VerifyIntegrity(defs, contentDir, manifest)
+	if err == nil || !strings.Contains(err.Error(), "not found: mcp-resources/other.md") {
+		t.Errorf("expected missing resource error, got %v", err)
+	}
+}
+
+func TestVerifyIntegrity_UnlistedResource(t *testing.T) {
+	defs, contentDir := writeIntegrityContent(t)
+	manifest := writeManifest(t, "# empty")
+
+	if err := VerifyIntegrity(defs, contentDir, manifest); err != nil {
+		t.Errorf("expected unlisted resources to be tolerated, got %v", err)
+	}
+}
+
+func TestVerifyIntegrity_InvalidManifest(t *testing.T) {
+	defs, contentDir := writeIntegrityContent(t)
+
+	tests := []struct {
+		name     string
+		manifest string
+	}{
+		{name: "Malformed Digest", manifest: writeManifest(t, "not-a-digest  mcp-resources/docs/guide.md")},
+		{name: "Missing Path", manifest: writeManifest(t, sha256Hex(integrityGuide))},
+		{name: "Missing File", manifest: filepath.Join(t.TempDir(), "missing")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyIntegrity(defs, contentDir, tt.manifest); err == nil {
+				t.Error("expected error for invalid manifest")
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package resources
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+)
+
+// LinkedResource is a resource at the other end of a link
+type LinkedResource struct {
+	URI         string
+	Name        string
+	Description string
+}
+
+// LinkGraph records the links between resources
+type LinkGraph struct {
+	from map[string][]string // source URI -> target URIs
+	to   map[string][]string // target URI -> source URIs
+}
+
+// BuildLinkGraph parses the markdown links of every resource and records those
+// that point to other resources. Relative links are resolved the same way the
+// cross-ref transformer resolves them; links already using the scheme count
+// when they name a known resource. Images, self-links, and links to unknown
+// targets are ignored, and unreadable resources are logged and skipped.
+func BuildLinkGraph(definitions []ResourceDefinition, scheme string, cp *content.ContentProvider, opts ...LinkOption) *LinkGraph {
+	resolver := newLinkResolver(definitions, scheme, opts...)
+	knownURIs := make(map[string]bool, len(definitions))
+	for _, d := range definitions {
+		knownURIs[d.URI] = true
+	}
+
+	g := &LinkGraph{
+		from: make(map[string][]string),
+		to:   make(map[string][]string),
+	}
+	for _, d := range definitions {
+		md, err := cp.LoadMarkdownWithFrontmatter(d.FilePath)
+		if err != nil {
+			slog.Warn("Skipping resource in link graph", "uri", d.URI, "error", err)
+			continue
+		}
+
+		currentDir := filepath.Dir(d.FilePath)
+		seen := make(map[string]bool)
+		for _, groups := range markdownLinkRe.FindAllStringSubmatch(md.Content, -1) {
+			if strings.HasPrefix(groups[0], "!") {
+				continue
+			}
+
+			target := groups[2]
+			uri, _, ok := resolver.resolve(target, currentDir)
+			if !ok && strings.HasPrefix(target, resolver.schemePrefix) {
+				uri, _, _ = strings.Cut(target, "#")
+				ok = knownURIs[uri]
+			}
+			if !ok || uri == d.URI || seen[uri] {
+				continue
+			}
+
+			seen[uri] = true
+			g.from[d.URI] = append(g.from[d.URI], uri)
+			g.to[uri] = append(g.to[uri], d.URI)
+		}
+	}
+
+	for _, m := range []map[string][]string{g.from, g.to} {
+		for _, uris := range m {
+			sort.Strings(uris)
+		}
+	}
+	return g
+}
+
+// WithLinkGraph sets the link graph used by LinksFrom and LinksTo.
+func WithLinkGraph(g *LinkGraph) Option {
+	return func(p *ResourceProvider) {
+		p.links = g
+	}
+}
+
+// LinksFrom returns the resources that the resource identified by uri links to.
+// Hidden resources are omitted. Without a link graph, no links are returned.
+func (p *ResourceProvider) LinksFrom(uri string) ([]LinkedResource, error) {
+	source, err := p.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+	if p.links == nil {
+		return nil, nil
+	}
+	return p.linkedResources(p.links.from[source.URI]), nil
+}
+
+// LinksTo returns the resources that link to the resource identified by uri.
+// Hidden resources are omitted. Without a link graph, no links are returned.
+func (p *ResourceProvider) LinksTo(uri string) ([]LinkedResource, error) {
+	target, err := p.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+	if p.links == nil {
+		return nil, nil
+	}
+	return p.linkedResources(p.links.to[target.URI]), nil
+}
+
+// linkedResources maps URIs to their non-hidden resources
+func (p *ResourceProvider) linkedResources(uris []string) []LinkedResource {
+	var linked []LinkedResource
+	for _, uri := range uris {
+		d, ok := p.uriMap[uri]
+		if !ok || d.Hidden {
+			continue
+		}
+		linked = append(linked, LinkedResource{
+			URI:         d.URI,
+			Name:        d.Name,
+			Description: d.Description,
+		})
+	}
+	return linked
+}
@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+)
+
+// writeLinkedResources writes a small graph: a and b link to each other,
+// a also links to a hidden resource, an image, itself, and an external page.
+func writeLinkedResources(t *testing.T) []ResourceDefinition {
+	t.Helper()
+	_, defs := discoverFiles(t, map[string]string{
+		"a.md":        "---\nname: a\ndescription: Doc A\n---\nSee [b](guides/b.md#setup), [again](acdc://guides/b), [secret](secret.md), [self](a.md), ![img](guides/b.md) and [web](https://example.com).",
+		"guides/b.md": "---\nname: b\ndescription: Doc B\n---\nBack to [a](../a.md).",
+		"secret.md":   "---\nname: secret\ndescription: Hidden\nhidden: true\n---\nLinks to [a](a.md).",
+	})
+	return defs
+}
+
+func linkedURIs(linked []LinkedResource) []string {
+	uris := make([]string, len(linked))
+	for i, l := range linked {
+		uris[i] = l.URI
+	}
+	return uris
+}
+
+func TestLinkGraph_Bidirectional(t *testing.T) {
+	defs := writeLinkedResources(t)
+	graph := BuildLinkGraph(defs, "acdc", content.NewContentProvider(""))
+	p := NewResourceProvider(defs, WithLinkGraph(graph))
+
+	tests := []struct {
+		name  string
+		links func(string) ([]LinkedResource, error)
+		uri   string
+		want  []string
+	}{
+		{name: "From A", links: p.LinksFrom, uri: "acdc://a", want: []string{"acdc://guides/b"}},
+		{name: "From B", links: p.LinksFrom, uri: "acdc://guides/b", want: []string{"acdc://a"}},
+		{name: "To A", links: p.LinksTo, uri: "acdc://a", want: []string{"acdc://guides/b"}},
+		{name: "To B", links: p.LinksTo, uri: "acdc://guides/b", want: []string{"acdc://a"}},
+		{name: "By Name", links: p.LinksTo, uri: "b", want: []string{"acdc://a"}},
+		{name: "Hidden Source Readable", links: p.LinksFrom, uri: "acdc://secret", want: []string{"acdc://a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linked, err := tt.links(tt.uri)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := linkedURIs(linked); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	linked, _ := p.LinksFrom("acdc://a")
+	if linked[0].Name != "b" || linked[0].Description != "Doc B" {
+		t.Errorf("Expected linked resource details, got %+v", linked[0])
+	}
+}
+
+func TestLinkGraph_UnknownResource(t *testing.T) {
+	defs := writeLinkedResources(t)
+	p := NewResourceProvider(defs, WithLinkGraph(BuildLinkGraph(defs, "acdc", content.NewContentProvider(""))))
+
+	if _, err := p.LinksFrom("acdc://missing"); err == nil {
+		t.Error("Expected error for unknown resource")
+	}
+	if _, err := p.LinksTo("acdc://missing"); err == nil {
+		t.Error("Expected error for unknown resource")
+	}
+}
+
+func TestLinkGraph_NoGraph(t *testing.T) {
+	defs := writeLinkedResources(t)
+	p := NewResourceProvider(defs)
+
+	linked, err := p.LinksFrom("acdc://a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(linked) != 0 {
+		t.Errorf("Expected no links without a link graph, got %v", linked)
+	}
+}
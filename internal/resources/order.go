@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+)
+
+// WithListOrder sets the order ListResources returns resources in, one of the
+// config.ListOrder* constants. Ties, and unknown orders, fall back to
+// discovery order.
+func WithListOrder(order string) Option {
+	return func(p *ResourceProvider) {
+		p.listOrder = order
+	}
+}
+
+// sortDefinitions returns a copy of the definitions sorted in the given
+// listing order. The sort is stable, so equal resources keep their
+// discovery order.
+func sortDefinitions(definitions []ResourceDefinition, order string) []ResourceDefinition {
+	sorted := make([]ResourceDefinition, len(definitions))
+	copy(sorted, definitions)
+
+	switch order {
+	case config.ListOrderName:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+		})
+	case config.ListOrderModified:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].LastModified.After(sorted[j].LastModified)
+		})
+	}
+	return sorted
+}
@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+)
+
+func TestListResources_Order(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	defs := []ResourceDefinition{
+		{URI: "acdc://c", Name: "charlie", LastModified: base.Add(time.Hour)},
+		{URI: "acdc://a", Name: "Alpha", LastModified: base},
+		{URI: "acdc://h", Name: "hidden", LastModified: base.Add(3 * time.Hour), Hidden: true},
+		{URI: "acdc://b", Name: "bravo", LastModified: base.Add(2 * time.Hour)},
+		{URI: "acdc://b2", Name: "Bravo", LastModified: base.Add(2 * time.Hour)},
+	}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{order: "", want: []string{"acdc://c", "acdc://a", "acdc://b", "acdc://b2"}},
+		{order: config.ListOrderDiscovery, want: []string{"acdc://c", "acdc://a", "acdc://b", "acdc://b2"}},
+		{order: config.ListOrderName, want: []string{"acdc://a", "acdc://b", "acdc://b2", "acdc://c"}},
+		{order: config.ListOrderModified, want: []string{"acdc://b", "acdc://b2", "acdc://c", "acdc://a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			p := NewResourceProvider(defs, WithListOrder(tt.order))
+			var got []string
+			for _, r := range p.ListResources() {
+				got = append(got, r.URI)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListResources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Sorting the listing leaves the definitions untouched
+	if defs[0].URI != "acdc://c" || defs[1].URI != "acdc://a" {
+		t.Errorf("Expected definitions to keep their order, got %v", defs)
+	}
+}
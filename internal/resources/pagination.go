@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed or
+// refers to an unknown resource
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ListResourcesPage returns up to limit resources a caller holding the given
+// roles may access, following the position identified by cursor, in
+// ListResources order. Hidden and inaccessible resources are skipped before
+// the page is cut, so every page but the last is full. An empty cursor starts
+// from the beginning and a non-positive limit returns all remaining
+// resources. The returned cursor is empty when there are no more resources.
+func (p *ResourceProvider) ListResourcesPage(cursor string, limit int, roles []string) ([]mcp.Resource, string, error) {
+	start := 0
+	if cursor != "" {
+		last, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+		}
+		// Cursors are positions in the full listing, so they stay valid when
+		// the resource they name is hidden from the caller
+		start = -1
+		for i, d := range p.listing {
+			if d.URI == string(last) {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+		}
+	}
+
+	now := p.now()
+	var page []mcp.Resource
+	for _, d := range p.listing[start:] {
+		if d.Hidden || !d.VisibleTo(roles) || !d.PublishedAt(now) {
+			continue
+		}
+		if limit > 0 && len(page) == limit {
+			// Another resource follows the full page
+			return page, base64.RawURLEncoding.EncodeToString([]byte(page[len(page)-1].URI)), nil
+		}
+		page = append(page, toResource(d))
+	}
+	return page, "", nil
+}
@@ -0,0 +1,126 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestListResourcesPage(t *testing.T) {
+	const total = 2500
+	defs := make([]ResourceDefinition, 0, total+1)
+	for i := 0; i < total; i++ {
+		defs = append(defs, ResourceDefinition{URI: fmt.Sprintf("acdc://doc-%04d", i), Name: fmt.Sprintf("doc-%04d", i)})
+	}
+	defs = append(defs, ResourceDefinition{URI: "acdc://hidden", Name: "hidden", Hidden: true})
+	p := NewResourceProvider(defs)
+
+	var uris []string
+	cursor := ""
+	pages := 0
+	for {
+		page, next, err := p.ListResourcesPage(cursor, 1000, nil)
+		if err != nil {
+			t.Fatalf("ListResourcesPage(%q) error = %v", cursor, err)
+		}
+		pages++
+		for _, r := range page {
+			uris = append(uris, r.URI)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+	if len(uris) != total {
+		t.Fatalf("Expected %d resources, got %d", total, len(uris))
+	}
+	for i, uri := range uris {
+		if want := fmt.Sprintf("acdc://doc-%04d", i); uri != want {
+			t.Fatalf("Resource %d: expected %s, got %s", i, want, uri)
+		}
+	}
+}
+
+func TestListResourcesPage_NoLimit(t *testing.T) {
+	p := NewResourceProvider([]ResourceDefinition{{URI: "acdc://a"}, {URI: "acdc://b"}})
+
+	page, next, err := p.ListResourcesPage("", 0, nil)
+	if err != nil {
+		t.Fatalf("ListResourcesPage error = %v", err)
+	}
+	if len(page) != 2 || next != "" {
+		t.Errorf("Expected all resources and no cursor, got %d resources and cursor %q", len(page), next)
+	}
+}
+
+func TestListResourcesPage_ExactFit(t *testing.T) {
+	p := NewResourceProvider([]ResourceDefinition{{URI: "acdc://a"}, {URI: "acdc://b"}})
+
+	page, next, err := p.ListResourcesPage("", 2, nil)
+	if err != nil {
+		t.Fatalf("ListResourcesPage error = %v", err)
+	}
+	if len(page) != 2 || next != "" {
+		t.Errorf("Expected a single full page without cursor, got %d resources and cursor %q", len(page), next)
+	}
+}
+
+func TestListResourcesPage_InvalidCursor(t *testing.T) {
+	p := NewResourceProvider([]ResourceDefinition{{URI: "acdc://a"}, {URI: "acdc://b"}})
+
+	for _, cursor := range []string{"not base64!", "YWNkYzovL21pc3Npbmc"} {
+		if _, _, err := p.ListResourcesPage(cursor, 1, nil); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("ListResourcesPage(%q): expected ErrInvalidCursor, got %v", cursor, err)
+		}
+	}
+}
+
+func TestListResourcesPage_Access(t *testing.T) {
+	p := NewResourceProvider([]ResourceDefinition{
+		{URI: "acdc://a"},
+		{URI: "acdc://internal-1", Roles: []string{"internal"}},
+		{URI: "acdc://internal-2", Roles: []string{"internal"}},
+		{URI: "acdc://b"},
+		{URI: "acdc://hidden", Hidden: true},
+		{URI: "acdc://c"},
+	})
+
+	tests := []struct {
+		name  string
+		roles []string
+		want  [][]string
+	}{
+		{name: "Anonymous", want: [][]string{{"acdc://a", "acdc://b"}, {"acdc://c"}}},
+		{name: "With Role", roles: []string{"internal"}, want: [][]string{{"acdc://a", "acdc://internal-1"}, {"acdc://internal-2", "acdc://b"}, {"acdc://c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			cursor := ""
+			for {
+				page, next, err := p.ListResourcesPage(cursor, 2, tt.roles)
+				if err != nil {
+					t.Fatalf("ListResourcesPage(%q) error = %v", cursor, err)
+				}
+				var uris []string
+				for _, r := range page {
+					uris = append(uris, r.URI)
+				}
+				got = append(got, uris)
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected pages %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
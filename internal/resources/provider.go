@@ -2,17 +2,28 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
 )
 
+// ErrUnknownResource is returned when a URI or name does not match any resource
+var ErrUnknownResource = errors.New("unknown resource")
+
+// ErrAmbiguousResource is returned when a name matches more than one resource
+var ErrAmbiguousResource = errors.New("ambiguous resource name")
+
 // ContentTransformer transforms resource content before it is returned.
 // It receives the raw content and the definition of the resource being read.
 type ContentTransformer func(content string, def ResourceDefinition) string
@@ -28,65 +39,259 @@ func WithTransformer(t ContentTransformer) Option {
 	}
 }
 
+// WithNotFoundFallback makes ReadResource return the content of the resource
+// at uri whenever the requested resource is unknown, instead of an error.
+// Ambiguous names still fail, and an unknown fallback URI is ignored, as is a
+// fallback that is restricted to roles or not currently published.
+func WithNotFoundFallback(uri string) Option {
+	return func(p *ResourceProvider) {
+		p.fallbackURI = uri
+	}
+}
+
+// WithContentProvider sets the content provider used to load resource files,
+// so loading options such as encoding detection apply to reads.
+func WithContentProvider(cp *content.ContentProvider) Option {
+	return func(p *ResourceProvider) {
+		p.loader = cp
+	}
+}
+
+// WithIndexMIMETypes restricts which resources StreamResources emits for
+// indexing. When include is non-empty, only resources of those MIME types are
+// indexed; resources of an excluded type are never indexed. MIME types are
+// matched case-insensitively. Filtered resources remain readable.
+func WithIndexMIMETypes(include, exclude []string) Option {
+	return func(p *ResourceProvider) {
+		p.indexInclude = include
+		p.indexExclude = exclude
+	}
+}
+
 // ResourceProvider provides access to resources
 type ResourceProvider struct {
 	definitions  []ResourceDefinition
+	listing      []ResourceDefinition // definitions in listing order
+	listOrder    string
 	uriMap       map[string]ResourceDefinition
+	nameMap      map[string][]ResourceDefinition
 	transformers []ContentTransformer
+	fallbackURI  string
+	etags        *etagCache
+	loader       *content.ContentProvider
+	links        *LinkGraph
+	indexInclude []string
+	indexExclude []string
+	now          func() time.Time
 }
 
 // NewResourceProvider creates a new resource provider
 func NewResourceProvider(definitions []ResourceDefinition, opts ...Option) *ResourceProvider {
 	uriMap := make(map[string]ResourceDefinition)
+	nameMap := make(map[string][]ResourceDefinition)
 	for _, d := range definitions {
 		uriMap[d.URI] = d
+		nameMap[d.Name] = append(nameMap[d.Name], d)
 	}
 	p := &ResourceProvider{
 		definitions: definitions,
 		uriMap:      uriMap,
+		nameMap:     nameMap,
+		etags:       newETagCache(),
+		loader:      content.NewContentProvider(""),
+		now:         time.Now,
 	}
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.listing = sortDefinitions(definitions, p.listOrder)
 	return p
 }
 
-// ListResources lists all available resources
-func (p *ResourceProvider) ListResources() []mcp.Resource {
-	resources := make([]mcp.Resource, len(p.definitions))
-	for i, d := range p.definitions {
-		resources[i] = mcp.Resource{
-			URI:         d.URI,
-			Name:        d.Name,
-			Description: d.Description,
-			MIMEType:    d.MIMEType,
+// ListResources lists all available resources, excluding hidden ones, in the
+// order set by WithListOrder. When tags are given, only resources carrying
+// all of them are listed.
+func (p *ResourceProvider) ListResources(tags ...string) []mcp.Resource {
+	resources := make([]mcp.Resource, 0, len(p.listing))
+	for _, d := range p.listing {
+		if d.Hidden || !d.HasTags(tags) {
+			continue
+		}
+		resources = append(resources, toResource(d))
+	}
+	return resources
+}
+
+// HiddenResources lists the hidden resources, which are excluded from
+// ListResources but still readable by URI, e.g. through cross-references.
+func (p *ResourceProvider) HiddenResources() []mcp.Resource {
+	var resources []mcp.Resource
+	for _, d := range p.listing {
+		if d.Hidden {
+			resources = append(resources, toResource(d))
 		}
 	}
 	return resources
 }
 
-// ReadResource reads a resource by URI
+// toResource converts a definition into its MCP resource listing
+func toResource(d ResourceDefinition) mcp.Resource {
+	return mcp.Resource{
+		URI:         d.URI,
+		Name:        d.Name,
+		Title:       d.Title,
+		Description: d.Description,
+		MIMEType:    d.MIMEType,
+		Meta:        listingMeta(d),
+	}
+}
+
+// listingMeta returns the listing metadata of a resource: its ID, tags and
+// canonical, if any, along with any deprecation details
+func listingMeta(def ResourceDefinition) mcp.Meta {
+	meta := deprecationMeta(def)
+	if def.ID == "" && len(def.Tags) == 0 && def.Canonical == "" {
+		return meta
+	}
+	if meta == nil {
+		meta = mcp.Meta{}
+	}
+	if def.ID != "" {
+		meta["id"] = def.ID
+	}
+	if len(def.Tags) > 0 {
+		meta["tags"] = def.Tags
+	}
+	if def.Canonical != "" {
+		meta["canonical"] = def.Canonical
+	}
+	return meta
+}
+
+// StatResource returns the definition of a resource by URI or unique name
+// without reading its content. Hidden resources are included, since they are
+// readable by URI. Unknown resources return ErrUnknownResource. The
+// modification time and size are those seen when the resource was last read,
+// e.g. by a re-index, and otherwise those recorded at discovery.
+func (p *ResourceProvider) StatResource(uri string) (ResourceDefinition, error) {
+	defn, err := p.resolve(uri)
+	if err != nil {
+		return defn, err
+	}
+	return p.withFileState(defn), nil
+}
+
+// withFileState returns defn with the modification time and size of its file
+// as of the last read, when it has been read since discovery
+func (p *ResourceProvider) withFileState(defn ResourceDefinition) ResourceDefinition {
+	if modTime, size, ok := p.etags.fileState(defn.URI); ok {
+		defn.LastModified = modTime
+		defn.Size = size
+	}
+	return defn
+}
+
+// ReadResource reads a resource by URI.
+// If the value does not match any URI, it is resolved against resource names.
+// Unknown resources are served from the not-found fallback when one is configured.
 func (p *ResourceProvider) ReadResource(uri string) (string, error) {
+	result, _, err := p.ReadResourceWithETag(uri)
+	return result, err
+}
+
+// ReadResourceByURI reads a resource by exact URI. Unlike ReadResource, it
+// neither resolves names nor serves the not-found fallback, so unknown URIs
+// always return ErrUnknownResource.
+func (p *ResourceProvider) ReadResourceByURI(uri string) (string, error) {
 	defn, ok := p.uriMap[uri]
 	if !ok {
-		return "", fmt.Errorf("unknown resource: %s", uri)
+		return "", fmt.Errorf("%w: %s", ErrUnknownResource, uri)
+	}
+	result, _, err := p.read(defn)
+	return result, err
+}
+
+// ReadResourceWithETag reads a resource like ReadResource and also returns
+// the ETag of the returned content.
+func (p *ResourceProvider) ReadResourceWithETag(uri string) (string, string, error) {
+	defn, err := p.resolveForRead(uri)
+	if err != nil {
+		return "", "", err
+	}
+	return p.read(defn)
+}
+
+// read loads and transforms a resource, caching the ETag of the result
+func (p *ResourceProvider) read(defn ResourceDefinition) (string, string, error) {
+	// Stat before reading so a concurrent write invalidates the cached ETag on the next check
+	info, err := os.Stat(defn.FilePath)
+	if err != nil {
+		return "", "", err
 	}
 
-	c, err := content.NewContentProvider("").LoadMarkdownWithFrontmatter(defn.FilePath)
+	c, err := p.loader.LoadMarkdownWithFrontmatter(defn.FilePath)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	result := c.Content
 	for _, t := range p.transformers {
 		result = t(result, defn)
 	}
-	return result, nil
+
+	etag := computeETag(result)
+	p.etags.put(defn.URI, info, etag)
+	return result, etag, nil
+}
+
+// resolveForRead resolves a URI or name, serving unknown resources from the
+// not-found fallback when one is configured. Unknown resources pass access
+// checks, so a fallback restricted to roles or outside its publishing window
+// is never served.
+func (p *ResourceProvider) resolveForRead(uri string) (ResourceDefinition, error) {
+	defn, err := p.resolve(uri)
+	if err != nil {
+		fallback, ok := p.uriMap[p.fallbackURI]
+		if !ok || !errors.Is(err, ErrUnknownResource) || !fallback.VisibleTo(nil) || !fallback.PublishedAt(p.now()) {
+			return ResourceDefinition{}, err
+		}
+		slog.Debug("Serving not-found fallback resource", "uri", uri, "fallback", p.fallbackURI)
+		return fallback, nil
+	}
+	return defn, nil
+}
+
+// resolve looks up a resource definition by URI, falling back to a unique name match
+func (p *ResourceProvider) resolve(uriOrName string) (ResourceDefinition, error) {
+	if defn, ok := p.uriMap[uriOrName]; ok {
+		return defn, nil
+	}
+
+	candidates := p.nameMap[uriOrName]
+	switch len(candidates) {
+	case 0:
+		return ResourceDefinition{}, fmt.Errorf("%w: %s", ErrUnknownResource, uriOrName)
+	case 1:
+		return candidates[0], nil
+	default:
+		uris := make([]string, len(candidates))
+		for i, c := range candidates {
+			uris[i] = c.URI
+		}
+		sort.Strings(uris)
+		return ResourceDefinition{}, fmt.Errorf("%w %q matches: %s", ErrAmbiguousResource, uriOrName, strings.Join(uris, ", "))
+	}
 }
 
-// StreamResources streams all resource contents to a channel
+// StreamResources streams all non-hidden resource contents to a channel.
+// Duplicates of a canonical resource are skipped, so search surfaces the
+// canonical only.
 func (p *ResourceProvider) StreamResources(ctx context.Context, ch chan<- domain.Document) error {
 	for _, defn := range p.definitions {
+		if defn.Hidden || defn.Canonical != "" || !p.indexable(defn.MIMEType) {
+			continue
+		}
+
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -98,12 +303,23 @@ func (p *ResourceProvider) StreamResources(ctx context.Context, ch chan<- domain
 			slog.Error("Error reading resource for indexing", "uri", defn.URI, "error", err)
 			continue
 		}
+		// Reading refreshed the file state, so re-indexing picks up new
+		// modification times
+		defn = p.withFileState(defn)
 
 		doc := domain.Document{
-			URI:      defn.URI,
-			Name:     defn.Name,
-			Content:  content,
-			Keywords: defn.Keywords,
+			URI:         defn.URI,
+			Name:        defn.Name,
+			Title:       defn.Title,
+			Description: defn.Description,
+			Content:     content,
+			Keywords:    defn.Keywords,
+			Fields:      defn.Fields,
+			Boost:       defn.Boost,
+		}
+		if !defn.LastModified.IsZero() {
+			lastModified := defn.LastModified
+			doc.LastModified = &lastModified
 		}
 
 		select {
@@ -115,13 +331,106 @@ func (p *ResourceProvider) StreamResources(ctx context.Context, ch chan<- domain
 	return nil
 }
 
+// indexable reports whether resources of the given MIME type are indexed
+func (p *ResourceProvider) indexable(mimeType string) bool {
+	matches := func(types []string) bool {
+		for _, t := range types {
+			if strings.EqualFold(t, mimeType) {
+				return true
+			}
+		}
+		return false
+	}
+	if matches(p.indexExclude) {
+		return false
+	}
+	return len(p.indexInclude) == 0 || matches(p.indexInclude)
+}
+
+// discoverConfig holds options for resource discovery
+type discoverConfig struct {
+	followSymlinks   bool
+	fields           []string
+	uriTemplate      string
+	deriveMetadata   bool
+	defaultMIME      string
+	detectDuplicates bool
+}
+
+// defaultURITemplate is the URI template used when none is configured
+const defaultURITemplate = "{scheme}://{path}"
+
+// defaultMIMEType is the MIME type of resources that neither set `mime_type`
+// nor are discovered with WithDefaultMIMEType
+const defaultMIMEType = "text/markdown"
+
+// DiscoverOption configures resource discovery.
+type DiscoverOption func(*discoverConfig)
+
+// WithFollowSymlinks makes discovery descend into symlinked directories,
+// including a symlinked resources directory. Symlink loops are detected and skipped.
+func WithFollowSymlinks() DiscoverOption {
+	return func(c *discoverConfig) {
+		c.followSymlinks = true
+	}
+}
+
+// WithIndexedFields extracts the named scalar frontmatter fields (strings,
+// numbers and booleans) into each definition's Fields for search indexing.
+func WithIndexedFields(names ...string) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.fields = append(c.fields, names...)
+	}
+}
+
+// WithURITemplate sets the template resource URIs are built from. The {scheme}
+// placeholder is replaced with the URI scheme and {path} with the file path
+// relative to the resources directory, without its extension.
+// The default template is "{scheme}://{path}".
+func WithURITemplate(template string) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.uriTemplate = template
+	}
+}
+
+// WithDerivedMetadata derives a missing name from the first level-one heading
+// (or the file name) and a missing description from the first paragraph,
+// instead of skipping the resource. Combine it with
+// content.WithOptionalFrontmatter to serve markdown without frontmatter.
+func WithDerivedMetadata() DiscoverOption {
+	return func(c *discoverConfig) {
+		c.deriveMetadata = true
+	}
+}
+
+// WithDefaultMIMEType sets the MIME type of resources discovered in the content
+// location, e.g. text/plain for reStructuredText served as is. A `mime_type`
+// frontmatter field still takes precedence. The default is text/markdown.
+func WithDefaultMIMEType(mimeType string) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.defaultMIME = mimeType
+	}
+}
+
 // DiscoverResources discovers resources from markdown files.
 // The scheme parameter specifies the URI scheme (e.g. "acdc" produces "acdc://...").
-func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDefinition, error) {
+func DiscoverResources(cp *content.ContentProvider, scheme string, opts ...DiscoverOption) ([]ResourceDefinition, error) {
+	cfg := discoverConfig{uriTemplate: defaultURITemplate, defaultMIME: defaultMIMEType}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	var definitions []ResourceDefinition
+	var hashes [][sha256.Size]byte
+	var explicitIDs []bool
 	resourcesDir := cp.ResourcesDir
 
-	err := filepath.WalkDir(resourcesDir, func(path string, d fs.DirEntry, err error) error {
+	// An empty resources directory is valid, but a missing one is a layout error
+	if _, err := os.Stat(resourcesDir); errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("resources directory not found: %s (create it, even if empty, to serve no resources)", resourcesDir)
+	}
+
+	err := content.WalkDir(resourcesDir, cfg.followSymlinks, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -132,8 +441,13 @@ func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDe
 			return nil
 		}
 
-		// Parse frontmatter
-		md, err := cp.LoadMarkdownWithFrontmatter(path)
+		// Parse frontmatter from a consistent snapshot of the file, so that an
+		// edit during discovery cannot mix old metadata with new content
+		md, info, err := cp.LoadMarkdownSnapshot(path)
+		if errors.Is(err, content.ErrChangedDuringRead) {
+			slog.Warn("Skipping resource file that changed during discovery", "file", d.Name())
+			return nil
+		}
 		if err != nil {
 			slog.Warn("Skipping invalid resource file", "file", d.Name(), "error", err)
 			return nil
@@ -142,6 +456,14 @@ func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDe
 		// Extract metadata
 		name, _ := md.Metadata["name"].(string)
 		description, _ := md.Metadata["description"].(string)
+		if cfg.deriveMetadata {
+			if name == "" {
+				name = deriveName(md.Content, path)
+			}
+			if description == "" {
+				description = deriveDescription(md.Content)
+			}
+		}
 
 		if name == "" || description == "" {
 			slog.Warn("Skipping resource with missing metadata", "file", d.Name())
@@ -158,6 +480,53 @@ func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDe
 			}
 		}
 
+		title, _ := md.Metadata["title"].(string)
+		if title == "" {
+			title = name
+		}
+
+		mimeType, _ := md.Metadata["mime_type"].(string)
+		if mimeType == "" {
+			mimeType = cfg.defaultMIME
+		}
+
+		hidden, _ := md.Metadata["hidden"].(bool)
+		deprecated, _ := md.Metadata["deprecated"].(bool)
+		deprecatedReason, _ := md.Metadata["deprecated_reason"].(string)
+		supersededBy, _ := md.Metadata["superseded_by"].(string)
+		canonical, _ := md.Metadata["canonical"].(string)
+		publishAt, err := parseTimestamp(md.Metadata["publish_at"])
+		if err != nil {
+			slog.Warn("Skipping resource with invalid publish_at value", "file", d.Name(), "error", err)
+			return nil
+		}
+		expireAt, err := parseTimestamp(md.Metadata["expire_at"])
+		if err != nil {
+			slog.Warn("Skipping resource with invalid expire_at value", "file", d.Name(), "error", err)
+			return nil
+		}
+		if !publishAt.IsZero() && !expireAt.IsZero() && !expireAt.After(publishAt) {
+			slog.Warn("Skipping resource with expire_at not after publish_at", "file", d.Name())
+			return nil
+		}
+		id, explicitID, err := parseID(md.Metadata["id"], md.Content)
+		if err != nil {
+			slog.Warn("Skipping resource with invalid id value", "file", d.Name(), "error", err)
+			return nil
+		}
+		boost, err := parseBoost(md.Metadata["boost"])
+		if err != nil {
+			slog.Warn("Skipping resource with invalid boost value", "file", d.Name(), "error", err)
+			return nil
+		}
+		tags := stringList(md.Metadata["tags"])
+		roles := stringList(md.Metadata["roles"])
+		if len(roles) == 0 {
+			roles = stringList(md.Metadata["audience"])
+		}
+
+		fields := scalarFields(md.Metadata, cfg.fields)
+
 		// Derive URI
 		relPath, err := filepath.Rel(resourcesDir, path)
 		if err != nil {
@@ -167,16 +536,35 @@ func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDe
 		relPathNoExt := strings.TrimSuffix(relPath, filepath.Ext(relPath))
 		// normalized for URI (slashes)
 		uriPath := filepath.ToSlash(relPathNoExt)
-		uri := fmt.Sprintf("%s://%s", scheme, uriPath)
+		uri := strings.NewReplacer("{scheme}", scheme, "{path}", uriPath).Replace(cfg.uriTemplate)
 
 		definitions = append(definitions, ResourceDefinition{
-			URI:         uri,
-			Name:        name,
-			Description: description,
-			MIMEType:    "text/markdown",
-			FilePath:    path,
-			Keywords:    keywords,
+			ID:               id,
+			URI:              uri,
+			Name:             name,
+			Title:            title,
+			Description:      description,
+			MIMEType:         mimeType,
+			FilePath:         path,
+			Keywords:         keywords,
+			Tags:             tags,
+			Fields:           fields,
+			LastModified:     info.ModTime(),
+			Size:             info.Size(),
+			Hidden:           hidden,
+			Deprecated:       deprecated,
+			DeprecatedReason: deprecatedReason,
+			SupersededBy:     supersededBy,
+			Canonical:        strings.TrimSpace(canonical),
+			Roles:            roles,
+			PublishAt:        publishAt,
+			ExpireAt:         expireAt,
+			Boost:            boost,
 		})
+		explicitIDs = append(explicitIDs, explicitID)
+		if cfg.detectDuplicates {
+			hashes = append(hashes, contentHash(md.Content))
+		}
 
 		slog.Info("Loaded resource", "uri", uri, "name", name)
 
@@ -187,5 +575,29 @@ func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDe
 		return nil, err
 	}
 
+	resolveIDs(definitions, explicitIDs)
+	resolveCanonicals(definitions, hashes)
 	return definitions, nil
 }
+
+// scalarFields returns the string form of the named scalar metadata values.
+// Missing and non-scalar values (lists, maps) are skipped.
+func scalarFields(metadata map[string]interface{}, names []string) map[string]string {
+	var fields map[string]string
+	for _, name := range names {
+		var value string
+		switch v := metadata[name].(type) {
+		case string:
+			value = v
+		case bool, int, int64, uint64, float64:
+			value = fmt.Sprint(v)
+		default:
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]string, len(names))
+		}
+		fields[name] = value
+	}
+	return fields
+}
@@ -2,8 +2,10 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -83,6 +85,174 @@ func TestResourceProvider_Methods(t *testing.T) {
 	})
 }
 
+func TestResourceProvider_ReadResource_ByName(t *testing.T) {
+	tmp := t.TempDir()
+	write := func(name, body string) string {
+		f := filepath.Join(tmp, name)
+		if err := os.WriteFile(f, []byte("---\nname: x\ndescription: y\n---\n"+body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	defs := []ResourceDefinition{
+		{URI: "acdc://guide", Name: "Guide", FilePath: write("guide.md", "Guide body")},
+		{URI: "acdc://a/dup", Name: "Dup", FilePath: write("dup-a.md", "A")},
+		{URI: "acdc://b/dup", Name: "Dup", FilePath: write("dup-b.md", "B")},
+	}
+	p := NewResourceProvider(defs)
+
+	t.Run("URI", func(t *testing.T) {
+		got, err := p.ReadResource("acdc://guide")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if got != "Guide body" {
+			t.Errorf("ReadResource content = %q, want %q", got, "Guide body")
+		}
+	})
+
+	t.Run("Unique Name", func(t *testing.T) {
+		got, err := p.ReadResource("Guide")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if got != "Guide body" {
+			t.Errorf("ReadResource content = %q, want %q", got, "Guide body")
+		}
+	})
+
+	t.Run("Ambiguous Name", func(t *testing.T) {
+		_, err := p.ReadResource("Dup")
+		if err == nil {
+			t.Fatal("ReadResource expected error for ambiguous name")
+		}
+		if !strings.Contains(err.Error(), "ambiguous") ||
+			!strings.Contains(err.Error(), "acdc://a/dup") ||
+			!strings.Contains(err.Error(), "acdc://b/dup") {
+			t.Errorf("Expected ambiguity error listing candidates, got %v", err)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := p.ReadResource("Nope")
+		if err == nil || !strings.Contains(err.Error(), "unknown resource") {
+			t.Errorf("Expected unknown resource error, got %v", err)
+		}
+	})
+}
+
+func TestResourceProvider_NotFoundFallback(t *testing.T) {
+	tmp := t.TempDir()
+	write := func(name, body string) string {
+		f := filepath.Join(tmp, name)
+		if err := os.WriteFile(f, []byte("---\nname: x\ndescription: y\n---\n"+body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	defs := []ResourceDefinition{
+		{URI: "acdc://index", Name: "Index", FilePath: write("index.md", "Not found. Available: acdc://guide")},
+		{URI: "acdc://guide", Name: "Guide", FilePath: write("guide.md", "Guide body")},
+		{URI: "acdc://a/dup", Name: "Dup", FilePath: write("dup-a.md", "A")},
+		{URI: "acdc://b/dup", Name: "Dup", FilePath: write("dup-b.md", "B")},
+	}
+
+	t.Run("Default Errors", func(t *testing.T) {
+		p := NewResourceProvider(defs)
+		_, err := p.ReadResource("acdc://missing")
+		if !errors.Is(err, ErrUnknownResource) {
+			t.Errorf("Expected ErrUnknownResource, got %v", err)
+		}
+	})
+
+	t.Run("Unknown Returns Fallback", func(t *testing.T) {
+		p := NewResourceProvider(defs, WithNotFoundFallback("acdc://index"))
+		got, err := p.ReadResource("acdc://missing")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if got != "Not found. Available: acdc://guide" {
+			t.Errorf("ReadResource = %q, want fallback content", got)
+		}
+	})
+
+	t.Run("Known Unaffected", func(t *testing.T) {
+		p := NewResourceProvider(defs, WithNotFoundFallback("acdc://index"))
+		got, err := p.ReadResource("acdc://guide")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if got != "Guide body" {
+			t.Errorf("ReadResource = %q, want %q", got, "Guide body")
+		}
+	})
+
+	t.Run("Ambiguous Still Errors", func(t *testing.T) {
+		p := NewResourceProvider(defs, WithNotFoundFallback("acdc://index"))
+		if _, err := p.ReadResource("Dup"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+			t.Errorf("Expected ambiguity error, got %v", err)
+		}
+	})
+
+	t.Run("Unknown Fallback Ignored", func(t *testing.T) {
+		p := NewResourceProvider(defs, WithNotFoundFallback("acdc://nope"))
+		if _, err := p.ReadResource("acdc://missing"); !errors.Is(err, ErrUnknownResource) {
+			t.Errorf("Expected ErrUnknownResource, got %v", err)
+		}
+	})
+
+	t.Run("By URI Skips Fallback And Names", func(t *testing.T) {
+		p := NewResourceProvider(defs, WithNotFoundFallback("acdc://index"))
+		for _, uri := range []string{"acdc://missing", "Guide"} {
+			if _, err := p.ReadResourceByURI(uri); !errors.Is(err, ErrUnknownResource) {
+				t.Errorf("ReadResourceByURI(%q): expected ErrUnknownResource, got %v", uri, err)
+			}
+		}
+		if got, err := p.ReadResourceByURI("acdc://guide"); err != nil || got != "Guide body" {
+			t.Errorf("ReadResourceByURI = %q, %v; want %q", got, err, "Guide body")
+		}
+	})
+
+	t.Run("Restricted Fallback Ignored", func(t *testing.T) {
+		restricted := append([]ResourceDefinition{}, defs...)
+		restricted[0].Roles = []string{"internal"}
+		p := NewResourceProvider(restricted, WithNotFoundFallback("acdc://index"))
+		if _, err := p.ReadResource("acdc://missing"); !errors.Is(err, ErrUnknownResource) {
+			t.Errorf("Expected ErrUnknownResource, got %v", err)
+		}
+	})
+
+	t.Run("Unpublished Fallback Ignored", func(t *testing.T) {
+		unpublished := append([]ResourceDefinition{}, defs...)
+		unpublished[0].PublishAt = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		now := func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+		p := NewResourceProvider(unpublished, WithNotFoundFallback("acdc://index"), WithClock(now))
+		if _, err := p.ReadResource("acdc://missing"); !errors.Is(err, ErrUnknownResource) {
+			t.Errorf("Expected ErrUnknownResource, got %v", err)
+		}
+	})
+}
+
+func TestResourceProvider_WithContentProvider(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "latin1.md")
+	if err := os.WriteFile(f, []byte("---\nname: N\ndescription: D\n---\nCaf\xe9"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defs := []ResourceDefinition{{URI: "acdc://latin1", Name: "N", FilePath: f}}
+	p := NewResourceProvider(defs, WithContentProvider(content.NewContentProvider("", content.WithEncodingDetection())))
+
+	got, err := p.ReadResource("acdc://latin1")
+	if err != nil {
+		t.Fatalf("ReadResource error = %v", err)
+	}
+	if got != "Café" {
+		t.Errorf("ReadResource = %q, want %q", got, "Café")
+	}
+}
+
 func TestResourceProvider_StreamResources_ErrorHandling(t *testing.T) {
 	defs := []ResourceDefinition{
 		{
@@ -376,6 +546,284 @@ func TestDiscoverResources(t *testing.T) {
 	}
 }
 
+func TestHiddenResources(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"visible.md": "---\nname: Visible\ndescription: D\nkeywords: [deep]\n---\nVisible content",
+		"deep.md":    "---\nname: Deep\ndescription: D\nkeywords: [deep]\nhidden: true\n---\nDeep content",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(resDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	p := NewResourceProvider(defs)
+
+	t.Run("Unlisted", func(t *testing.T) {
+		list := p.ListResources()
+		if len(list) != 1 || list[0].URI != "acdc://visible" {
+			t.Errorf("Expected only acdc://visible to be listed, got %+v", list)
+		}
+	})
+
+	t.Run("Hidden Listing", func(t *testing.T) {
+		hidden := p.HiddenResources()
+		if len(hidden) != 1 || hidden[0].URI != "acdc://deep" {
+			t.Errorf("Expected only acdc://deep to be hidden, got %+v", hidden)
+		}
+	})
+
+	t.Run("Unindexed", func(t *testing.T) {
+		ch := make(chan domain.Document, len(defs))
+		if err := p.StreamResources(context.Background(), ch); err != nil {
+			t.Fatalf("StreamResources error = %v", err)
+		}
+		close(ch)
+		for doc := range ch {
+			if doc.URI == "acdc://deep" {
+				t.Error("Hidden resource should not be streamed for indexing")
+			}
+		}
+	})
+
+	t.Run("Not Related", func(t *testing.T) {
+		related, err := p.RelatedByKeywords("acdc://visible", 0, nil)
+		if err != nil {
+			t.Fatalf("RelatedByKeywords error = %v", err)
+		}
+		if len(related) != 0 {
+			t.Errorf("Expected no related resources, got %+v", related)
+		}
+	})
+
+	t.Run("Readable", func(t *testing.T) {
+		got, err := p.ReadResource("acdc://deep")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if got != "Deep content" {
+			t.Errorf("ReadResource = %q, want %q", got, "Deep content")
+		}
+	})
+}
+
+func TestDiscoverResources_Title(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"titled.md":   "---\nname: deploy\ntitle: Deploying Services to Production\ndescription: D\n---\nBody",
+		"untitled.md": "---\nname: style\ndescription: D\n---\nBody",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(resDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+
+	titles := make(map[string]string)
+	for _, r := range NewResourceProvider(defs).ListResources() {
+		titles[r.URI] = r.Title
+	}
+	if got := titles["acdc://titled"]; got != "Deploying Services to Production" {
+		t.Errorf("Expected explicit title, got %q", got)
+	}
+	if got := titles["acdc://untitled"]; got != "style" {
+		t.Errorf("Expected title to fall back to name, got %q", got)
+	}
+}
+
+func TestDiscoverResources_IndexedFields(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	body := "---\nname: restart\ndescription: D\ncategory: runbook\npriority: 2\ntags: [a, b]\nowner: sre\n---\nBody"
+	if err := os.WriteFile(filepath.Join(resDir, "restart.md"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc", WithIndexedFields("category", "priority", "tags", "audience"))
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	if len(defs) != 1 {
+		t.Fatalf("Expected 1 resource, got %d", len(defs))
+	}
+
+	want := map[string]string{"category": "runbook", "priority": "2"}
+	if !reflect.DeepEqual(defs[0].Fields, want) {
+		t.Errorf("Expected fields %v, got %v", want, defs[0].Fields)
+	}
+
+	ch := make(chan domain.Document, 1)
+	if err := NewResourceProvider(defs).StreamResources(context.Background(), ch); err != nil {
+		t.Fatalf("StreamResources error = %v", err)
+	}
+	if doc := <-ch; !reflect.DeepEqual(doc.Fields, want) {
+		t.Errorf("Expected streamed fields %v, got %v", want, doc.Fields)
+	}
+
+	defs, err = DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	if defs[0].Fields != nil {
+		t.Errorf("Expected no fields without WithIndexedFields, got %v", defs[0].Fields)
+	}
+}
+
+func TestDiscoverResources_LastModified(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(resDir, "doc.md")
+	if err := os.WriteFile(path, []byte("---\nname: doc\ndescription: D\n---\nBody"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	if len(defs) != 1 || !defs[0].LastModified.Equal(mtime) {
+		t.Fatalf("Expected last modified %s, got %+v", mtime, defs)
+	}
+	if want := int64(len("---\nname: doc\ndescription: D\n---\nBody")); defs[0].Size != want {
+		t.Errorf("Expected size %d, got %d", want, defs[0].Size)
+	}
+
+	ch := make(chan domain.Document, 1)
+	if err := NewResourceProvider(defs).StreamResources(context.Background(), ch); err != nil {
+		t.Fatalf("StreamResources error = %v", err)
+	}
+	if doc := <-ch; doc.LastModified == nil || !doc.LastModified.Equal(mtime) {
+		t.Errorf("Expected streamed last modified %s, got %v", mtime, doc.LastModified)
+	}
+}
+
+func TestResourceProvider_RefreshedFileState(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(resDir, "doc.md")
+	if err := os.WriteFile(path, []byte("---\nname: doc\ndescription: D\n---\nBody"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC), time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	p := NewResourceProvider(defs)
+
+	updated := "---\nname: doc\ndescription: D\n---\nUpdated body"
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan domain.Document, 1)
+	if err := p.StreamResources(context.Background(), ch); err != nil {
+		t.Fatalf("StreamResources error = %v", err)
+	}
+	if doc := <-ch; doc.LastModified == nil || !doc.LastModified.Equal(mtime) {
+		t.Errorf("Expected re-streamed last modified %s, got %v", mtime, doc.LastModified)
+	}
+
+	defn, err := p.StatResource("acdc://doc")
+	if err != nil {
+		t.Fatalf("StatResource error = %v", err)
+	}
+	if !defn.LastModified.Equal(mtime) {
+		t.Errorf("Expected stat last modified %s, got %s", mtime, defn.LastModified)
+	}
+	if defn.Size != int64(len(updated)) {
+		t.Errorf("Expected stat size %d, got %d", len(updated), defn.Size)
+	}
+}
+
+func TestDiscoverResources_FollowSymlinks(t *testing.T) {
+	tmp := t.TempDir()
+	external := filepath.Join(tmp, "external")
+	if err := os.MkdirAll(external, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(external, "guide.md"), []byte("---\nname: Guide\ndescription: D\n---\nGuide"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	contentDir := filepath.Join(tmp, "content")
+	resDir := filepath.Join(contentDir, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(external, filepath.Join(resDir, "shared")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	// Loop back to the resources directory
+	if err := os.Symlink(resDir, filepath.Join(external, "back")); err != nil {
+		t.Fatal(err)
+	}
+
+	cp := content.NewContentProvider(contentDir)
+
+	defs, err := DiscoverResources(cp, "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	if len(defs) != 0 {
+		t.Errorf("Expected no resources without following symlinks, got %d", len(defs))
+	}
+
+	defs, err = DiscoverResources(cp, "acdc", WithFollowSymlinks())
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	if len(defs) != 1 || defs[0].URI != "acdc://shared/guide" {
+		t.Fatalf("Expected only acdc://shared/guide, got %+v", defs)
+	}
+
+	got, err := NewResourceProvider(defs).ReadResource("acdc://shared/guide")
+	if err != nil {
+		t.Fatalf("ReadResource error = %v", err)
+	}
+	if got != "Guide" {
+		t.Errorf("ReadResource = %q, want %q", got, "Guide")
+	}
+}
+
 func TestDiscoverResources_CustomScheme(t *testing.T) {
 	tmp := t.TempDir()
 	resDir := filepath.Join(tmp, "mcp-resources")
@@ -403,3 +851,130 @@ func TestDiscoverResources_CustomScheme(t *testing.T) {
 		t.Errorf("Expected URI 'my-custom://doc', got '%s'", defs[0].URI)
 	}
 }
+
+func TestDiscoverResources_DefaultMIMEType(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"guide.md": "---\nname: Guide\ndescription: D\n---\nGuide\n=====",
+		"data.md":  "---\nname: Data\ndescription: D\nmime_type: application/json\n---\n{}",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(resDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		opts      []DiscoverOption
+		wantGuide string
+	}{
+		{name: "Markdown By Default", wantGuide: "text/markdown"},
+		{name: "Location Default", opts: []DiscoverOption{WithDefaultMIMEType("text/plain")}, wantGuide: "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc", tt.opts...)
+			if err != nil {
+				t.Fatalf("DiscoverResources error = %v", err)
+			}
+			mimeTypes := make(map[string]string)
+			for _, d := range defs {
+				mimeTypes[d.URI] = d.MIMEType
+			}
+			if mimeTypes["acdc://guide"] != tt.wantGuide {
+				t.Errorf("Expected guide MIME type %q, got %q", tt.wantGuide, mimeTypes["acdc://guide"])
+			}
+			// Frontmatter overrides the location default
+			if mimeTypes["acdc://data"] != "application/json" {
+				t.Errorf("Expected data MIME type application/json, got %q", mimeTypes["acdc://data"])
+			}
+		})
+	}
+}
+
+func TestDiscoverResources_URITemplate(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(filepath.Join(resDir, "guides"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(resDir, "guides", "setup.md"), []byte("---\nname: Setup\ndescription: D\n---\nContent"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		scheme   string
+		template string
+		want     string
+	}{
+		{name: "Source-less Template", scheme: "acdc", template: "{scheme}://{path}", want: "acdc://guides/setup"},
+		{name: "Custom Scheme Template", scheme: "kb", template: "{scheme}://handbook/{path}", want: "kb://handbook/guides/setup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defs, err := DiscoverResources(content.NewContentProvider(tmp), tt.scheme, WithURITemplate(tt.template))
+			if err != nil {
+				t.Fatalf("DiscoverResources error = %v", err)
+			}
+			if len(defs) != 1 || defs[0].URI != tt.want {
+				t.Errorf("Expected URI %q, got %+v", tt.want, defs)
+			}
+		})
+	}
+}
+
+func TestResourceProvider_StreamResources_IndexMIMETypes(t *testing.T) {
+	tempDir := t.TempDir()
+	guide := filepath.Join(tempDir, "guide.md")
+	data := filepath.Join(tempDir, "data.json")
+	_ = os.WriteFile(guide, []byte("---\nname: guide\n---\nGuide"), 0644)
+	_ = os.WriteFile(data, []byte("---\nname: data\n---\n{\"key\": \"value\"}"), 0644)
+
+	defs := []ResourceDefinition{
+		{URI: "acdc://guide", Name: "guide", MIMEType: "text/markdown", FilePath: guide},
+		{URI: "acdc://data", Name: "data", MIMEType: "application/json", FilePath: data},
+	}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{
+		{name: "No Filter", want: []string{"acdc://guide", "acdc://data"}},
+		{name: "Excluded", exclude: []string{"Application/JSON"}, want: []string{"acdc://guide"}},
+		{name: "Included", include: []string{"application/json"}, want: []string{"acdc://data"}},
+		{name: "Exclude Wins", include: []string{"application/json"}, exclude: []string{"application/json"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewResourceProvider(defs, WithIndexMIMETypes(tt.include, tt.exclude))
+
+			ch := make(chan domain.Document, len(defs))
+			if err := p.StreamResources(context.Background(), ch); err != nil {
+				t.Fatalf("StreamResources error = %v", err)
+			}
+			close(ch)
+			var got []string
+			for d := range ch {
+				got = append(got, d.URI)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected indexed %v, got %v", tt.want, got)
+			}
+
+			if content, err := p.ReadResource("acdc://data"); err != nil || content != "{\"key\": \"value\"}" {
+				t.Errorf("Expected filtered resource to remain readable, got %q, %v", content, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RedactedPlaceholder replaces every match of a redaction pattern.
+const RedactedPlaceholder = "[REDACTED]"
+
+// CompileRedactionPatterns compiles the given regular expressions, returning
+// an error that names the first invalid pattern.
+func CompileRedactionPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// NewRedactionTransformer creates a ContentTransformer that masks every match
+// of the given patterns with RedactedPlaceholder. Because indexing reads content
+// through the provider, redacted text never reaches the search index either.
+func NewRedactionTransformer(patterns []*regexp.Regexp) ContentTransformer {
+	return func(content string, _ ResourceDefinition) string {
+		for _, re := range patterns {
+			content = re.ReplaceAllLiteralString(content, RedactedPlaceholder)
+		}
+		return content
+	}
+}
@@ -0,0 +1,93 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+func TestRedactionTransformer_MasksAPIKey(t *testing.T) {
+	patterns, err := CompileRedactionPatterns([]string{`sk-[A-Za-z0-9]{20,}`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transformer := NewRedactionTransformer(patterns)
+
+	input := "Use key sk-abcdefghijklmnopqrstuvwx to call the API. Keep sk-short as is."
+	got := transformer(input, ResourceDefinition{})
+	want := "Use key [REDACTED] to call the API. Keep sk-short as is."
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRedactionTransformer_MultiplePatterns(t *testing.T) {
+	patterns, err := CompileRedactionPatterns([]string{
+		`https://internal\.example\.com\S*`,
+		`(?i)token:\s*\S+`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transformer := NewRedactionTransformer(patterns)
+
+	input := "See https://internal.example.com/wiki/page and TOKEN: abc123 for access."
+	got := transformer(input, ResourceDefinition{})
+	want := "See [REDACTED] and [REDACTED] for access."
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRedactionTransformer_NoPatterns(t *testing.T) {
+	transformer := NewRedactionTransformer(nil)
+
+	input := "Nothing to hide here."
+	if got := transformer(input, ResourceDefinition{}); got != input {
+		t.Errorf("got %q, want %q", got, input)
+	}
+}
+
+func TestCompileRedactionPatterns_Invalid(t *testing.T) {
+	if _, err := CompileRedactionPatterns([]string{`valid`, `[unclosed`}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestRedactionTransformer_AppliedToReadAndStream(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "doc.md")
+	if err := os.WriteFile(path, []byte("---\nname: Doc\ndescription: D\n---\napi_key = sk-abcdefghijklmnopqrstuvwx\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	patterns, err := CompileRedactionPatterns([]string{`sk-[A-Za-z0-9]{20,}`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defs := []ResourceDefinition{{URI: "acdc://doc", Name: "Doc", FilePath: path}}
+	p := NewResourceProvider(defs, WithTransformer(NewRedactionTransformer(patterns)))
+
+	content, err := p.ReadResource("acdc://doc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "api_key = [REDACTED]\n" {
+		t.Errorf("ReadResource content = %q", content)
+	}
+
+	ch := make(chan domain.Document, 1)
+	if err := p.StreamResources(context.Background(), ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(ch)
+	doc := <-ch
+	if doc.Content != "api_key = [REDACTED]\n" {
+		t.Errorf("streamed content = %q", doc.Content)
+	}
+}
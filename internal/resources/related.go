@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"sort"
+	"strings"
+)
+
+// RelatedResource is a resource that shares keywords with another resource
+type RelatedResource struct {
+	URI            string
+	Name           string
+	Description    string
+	SharedKeywords []string
+}
+
+// RelatedByKeywords returns up to n resources sharing the most keywords with the
+// resource identified by uri, ranked by overlap count. Keywords are compared
+// case-insensitively. Hidden resources, resources a caller holding the given
+// roles may not access, and resources with no shared keywords are omitted
+// before the result is cut, so up to n accessible resources are returned.
+// A non-positive n returns all related resources.
+func (p *ResourceProvider) RelatedByKeywords(uri string, n int, roles []string) ([]RelatedResource, error) {
+	target, err := p.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	targetKeywords := make(map[string]bool, len(target.Keywords))
+	for _, k := range target.Keywords {
+		targetKeywords[strings.ToLower(k)] = true
+	}
+
+	now := p.now()
+	var related []RelatedResource
+	for _, d := range p.definitions {
+		if d.URI == target.URI || d.Hidden || !d.VisibleTo(roles) || !d.PublishedAt(now) {
+			continue
+		}
+
+		var shared []string
+		seen := make(map[string]bool)
+		for _, k := range d.Keywords {
+			lk := strings.ToLower(k)
+			if targetKeywords[lk] && !seen[lk] {
+				seen[lk] = true
+				shared = append(shared, lk)
+			}
+		}
+		if len(shared) == 0 {
+			continue
+		}
+
+		related = append(related, RelatedResource{
+			URI:            d.URI,
+			Name:           d.Name,
+			Description:    d.Description,
+			SharedKeywords: shared,
+		})
+	}
+
+	// Highest overlap first, URI as a stable tie-breaker
+	sort.SliceStable(related, func(i, j int) bool {
+		if len(related[i].SharedKeywords) != len(related[j].SharedKeywords) {
+			return len(related[i].SharedKeywords) > len(related[j].SharedKeywords)
+		}
+		return related[i].URI < related[j].URI
+	})
+
+	if n > 0 && len(related) > n {
+		related = related[:n]
+	}
+	return related, nil
+}
@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResourceProvider_RelatedByKeywords(t *testing.T) {
+	defs := []ResourceDefinition{
+		{URI: "acdc://auth", Name: "Auth", Keywords: []string{"security", "auth", "tokens"}},
+		{URI: "acdc://oauth", Name: "OAuth", Keywords: []string{"Auth", "Tokens", "oauth"}},
+		{URI: "acdc://secrets", Name: "Secrets", Keywords: []string{"security"}},
+		{URI: "acdc://style", Name: "Style", Keywords: []string{"formatting", "lint"}},
+		{URI: "acdc://none", Name: "None"},
+	}
+	p := NewResourceProvider(defs)
+
+	t.Run("Overlapping ranked by count", func(t *testing.T) {
+		got, err := p.RelatedByKeywords("acdc://auth", 0, nil)
+		if err != nil {
+			t.Fatalf("RelatedByKeywords error = %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("Expected 2 related resources, got %d: %+v", len(got), got)
+		}
+		if got[0].URI != "acdc://oauth" || len(got[0].SharedKeywords) != 2 {
+			t.Errorf("Expected oauth first with 2 shared keywords, got %+v", got[0])
+		}
+		if got[1].URI != "acdc://secrets" || len(got[1].SharedKeywords) != 1 {
+			t.Errorf("Expected secrets second with 1 shared keyword, got %+v", got[1])
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		got, err := p.RelatedByKeywords("acdc://auth", 1, nil)
+		if err != nil {
+			t.Fatalf("RelatedByKeywords error = %v", err)
+		}
+		if len(got) != 1 || got[0].URI != "acdc://oauth" {
+			t.Errorf("Expected only oauth, got %+v", got)
+		}
+	})
+
+	t.Run("Disjoint", func(t *testing.T) {
+		got, err := p.RelatedByKeywords("acdc://style", 0, nil)
+		if err != nil {
+			t.Fatalf("RelatedByKeywords error = %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("Expected no related resources, got %+v", got)
+		}
+	})
+
+	t.Run("No keywords", func(t *testing.T) {
+		got, err := p.RelatedByKeywords("acdc://none", 0, nil)
+		if err != nil {
+			t.Fatalf("RelatedByKeywords error = %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("Expected no related resources, got %+v", got)
+		}
+	})
+
+	t.Run("Inaccessible skipped before limit", func(t *testing.T) {
+		restricted := NewResourceProvider([]ResourceDefinition{
+			{URI: "acdc://auth", Name: "Auth", Keywords: []string{"security", "auth"}},
+			{URI: "acdc://admin", Name: "Admin", Keywords: []string{"security", "auth"}, Roles: []string{"admin"}},
+			{URI: "acdc://future", Name: "Future", Keywords: []string{"security", "auth"}, PublishAt: time.Now().Add(time.Hour)},
+			{URI: "acdc://secrets", Name: "Secrets", Keywords: []string{"security"}},
+		})
+
+		got, err := restricted.RelatedByKeywords("acdc://auth", 1, nil)
+		if err != nil {
+			t.Fatalf("RelatedByKeywords error = %v", err)
+		}
+		if len(got) != 1 || got[0].URI != "acdc://secrets" {
+			t.Errorf("Expected only secrets, got %+v", got)
+		}
+
+		got, err = restricted.RelatedByKeywords("acdc://auth", 1, []string{"admin"})
+		if err != nil {
+			t.Fatalf("RelatedByKeywords error = %v", err)
+		}
+		if len(got) != 1 || got[0].URI != "acdc://admin" {
+			t.Errorf("Expected only admin for the admin role, got %+v", got)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		if _, err := p.RelatedByKeywords("acdc://missing", 0, nil); err == nil {
+			t.Error("Expected error for unknown URI")
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+)
+
+// PublishedAt reports whether t falls within the resource's publishing
+// window: at or after PublishAt and before ExpireAt. Unset bounds are open.
+func (d ResourceDefinition) PublishedAt(t time.Time) bool {
+	if !d.PublishAt.IsZero() && t.Before(d.PublishAt) {
+		return false
+	}
+	return d.ExpireAt.IsZero() || t.Before(d.ExpireAt)
+}
+
+// WithClock sets the clock publishing windows are evaluated against.
+// The default is time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(p *ResourceProvider) {
+		p.now = now
+	}
+}
+
+// parseTimestamp reads a frontmatter timestamp. YAML timestamps are decoded
+// as time.Time, while quoted values are parsed as RFC 3339 timestamps or
+// dates (midnight UTC). A missing value yields the zero time.
+func parseTimestamp(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(time.DateOnly, v); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: expected an RFC 3339 timestamp or a date", v)
+	default:
+		return time.Time{}, fmt.Errorf("invalid timestamp %v: expected an RFC 3339 timestamp or a date", v)
+	}
+}
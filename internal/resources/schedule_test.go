@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccessibleBy_PublishingWindow(t *testing.T) {
+	publishAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expireAt := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	defs := []ResourceDefinition{
+		{URI: "acdc://window", Name: "window", PublishAt: publishAt, ExpireAt: expireAt},
+		{URI: "acdc://embargo", Name: "embargo", PublishAt: publishAt},
+		{URI: "acdc://sunset", Name: "sunset", ExpireAt: expireAt},
+		{URI: "acdc://always", Name: "always"},
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want map[string]bool
+	}{
+		{
+			name: "Before",
+			now:  publishAt.Add(-time.Second),
+			want: map[string]bool{"acdc://window": false, "acdc://embargo": false, "acdc://sunset": true, "acdc://always": true},
+		},
+		{
+			name: "Within",
+			now:  publishAt,
+			want: map[string]bool{"acdc://window": true, "acdc://embargo": true, "acdc://sunset": true, "acdc://always": true},
+		},
+		{
+			name: "After",
+			now:  expireAt,
+			want: map[string]bool{"acdc://window": false, "acdc://embargo": true, "acdc://sunset": false, "acdc://always": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewResourceProvider(defs, WithClock(func() time.Time { return tt.now }))
+			for uri, want := range tt.want {
+				if got := p.AccessibleBy(uri, nil); got != want {
+					t.Errorf("AccessibleBy(%s) = %v, want %v", uri, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscoverResources_PublishingWindow(t *testing.T) {
+	_, defs := discoverFiles(t, map[string]string{
+		"launch.md":   "---\nname: launch\ndescription: D\npublish_at: 2026-03-01T09:00:00Z\nexpire_at: \"2026-04-01\"\n---\nC",
+		"invalid.md":  "---\nname: invalid\ndescription: D\npublish_at: next week\n---\nC",
+		"reversed.md": "---\nname: reversed\ndescription: D\npublish_at: 2026-04-01\nexpire_at: 2026-03-01\n---\nC",
+	})
+	if len(defs) != 1 {
+		t.Fatalf("Expected invalid windows to be skipped, got %d resources", len(defs))
+	}
+	if want := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC); !defs[0].PublishAt.Equal(want) {
+		t.Errorf("Expected publish_at %v, got %v", want, defs[0].PublishAt)
+	}
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC); !defs[0].ExpireAt.Equal(want) {
+		t.Errorf("Expected expire_at %v, got %v", want, defs[0].ExpireAt)
+	}
+}
@@ -0,0 +1,21 @@
+package resources
+
+import "strings"
+
+// HasTags reports whether the resource carries all of the given tags,
+// compared case-insensitively. Any resource has an empty set of tags.
+func (d ResourceDefinition) HasTags(tags []string) bool {
+	for _, want := range tags {
+		found := false
+		for _, have := range d.Tags {
+			if strings.EqualFold(want, have) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListResources_Tags(t *testing.T) {
+	_, defs := discoverFiles(t, map[string]string{
+		"backend.md":  "---\nname: backend\ndescription: D\ntags: [Onboarding, backend]\n---\nC",
+		"frontend.md": "---\nname: frontend\ndescription: D\ntags: onboarding\n---\nC",
+		"hidden.md":   "---\nname: hidden\ndescription: D\ntags: [onboarding]\nhidden: true\n---\nC",
+		"untagged.md": "---\nname: untagged\ndescription: D\n---\nC",
+	})
+	p := NewResourceProvider(defs)
+
+	listed := func(tags ...string) []string {
+		var uris []string
+		for _, r := range p.ListResources(tags...) {
+			uris = append(uris, r.URI)
+		}
+		return uris
+	}
+
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{name: "No Tags", want: []string{"acdc://backend", "acdc://frontend", "acdc://untagged"}},
+		{name: "Single Tag", tags: []string{"onboarding"}, want: []string{"acdc://backend", "acdc://frontend"}},
+		{name: "Case Insensitive", tags: []string{"BACKEND"}, want: []string{"acdc://backend"}},
+		{name: "All Tags Required", tags: []string{"onboarding", "backend"}, want: []string{"acdc://backend"}},
+		{name: "No Match", tags: []string{"onboarding", "billing"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listed(tt.tags...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListResources(%v) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+
+	if tags := p.ListResources("backend")[0].Meta["tags"]; !reflect.DeepEqual(tags, []string{"Onboarding", "backend"}) {
+		t.Errorf("Expected listing meta tags, got %v", tags)
+	}
+}
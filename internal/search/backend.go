@@ -0,0 +1,80 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+)
+
+// DefaultBackend is the name of the built-in Bleve search backend
+const DefaultBackend = "bleve"
+
+// BackendFactory creates a Searcher from the search settings
+type BackendFactory func(settings config.SearchSettings) (Searcher, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]BackendFactory{
+		DefaultBackend: func(settings config.SearchSettings) (Searcher, error) {
+			return NewService(settings), nil
+		},
+	}
+)
+
+// RegisterBackend makes a search backend available by name, so it can be
+// selected with the search backend setting. It is intended to be called from
+// an init function and panics if the name is empty, the factory is nil, or
+// the name is already registered.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	if name == "" {
+		panic("search: backend name is empty")
+	}
+	if factory == nil {
+		panic("search: backend factory is nil for " + name)
+	}
+	if _, exists := backends[name]; exists {
+		panic("search: backend already registered: " + name)
+	}
+	backends[name] = factory
+}
+
+// Backends returns the sorted names of the registered search backends
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSearcher creates a Searcher using the backend selected in the settings.
+// An empty backend selects DefaultBackend.
+func NewSearcher(settings config.SearchSettings) (Searcher, error) {
+	name := settings.Backend
+	if name == "" {
+		name = DefaultBackend
+	}
+
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown search backend %q (available: %s)", name, strings.Join(Backends(), ", "))
+	}
+
+	searcher, err := factory(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search backend %q: %w", name, err)
+	}
+	return searcher, nil
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+type dummySearcher struct {
+	settings config.SearchSettings
+}
+
+func (d *dummySearcher) Search(ctx context.Context, queryStr string, opts SearchOptions) (SearchResponse, error) {
+	return SearchResponse{Results: []SearchResult{{URI: "dummy://result", Name: queryStr}}}, nil
+}
+
+func (d *dummySearcher) Index(ctx context.Context, documents <-chan domain.Document) error {
+	for range documents {
+		// drain
+	}
+	return nil
+}
+
+func (d *dummySearcher) Close() {}
+
+func TestNewSearcher_Default(t *testing.T) {
+	for _, backend := range []string{"", DefaultBackend} {
+		searcher, err := NewSearcher(config.SearchSettings{Backend: backend, InMemory: true})
+		if err != nil {
+			t.Fatalf("NewSearcher(%q) failed: %v", backend, err)
+		}
+		if _, ok := searcher.(*Service); !ok {
+			t.Errorf("Expected *Service for backend %q, got %T", backend, searcher)
+		}
+		searcher.Close()
+	}
+}
+
+func TestNewSearcher_RegisteredBackend(t *testing.T) {
+	RegisterBackend("dummy", func(settings config.SearchSettings) (Searcher, error) {
+		return &dummySearcher{settings: settings}, nil
+	})
+
+	if !slices.Contains(Backends(), "dummy") {
+		t.Fatalf("Expected dummy in registered backends, got %v", Backends())
+	}
+
+	searcher, err := NewSearcher(config.SearchSettings{Backend: "dummy", MaxResults: 7})
+	if err != nil {
+		t.Fatalf("NewSearcher failed: %v", err)
+	}
+	dummy, ok := searcher.(*dummySearcher)
+	if !ok {
+		t.Fatalf("Expected *dummySearcher, got %T", searcher)
+	}
+	if dummy.settings.MaxResults != 7 {
+		t.Errorf("Expected settings to be passed to the backend factory, got %+v", dummy.settings)
+	}
+
+	response, err := searcher.Search(context.Background(), "q", SearchOptions{})
+	if err != nil || len(response.Results) != 1 || response.Results[0].URI != "dummy://result" {
+		t.Errorf("Expected the dummy backend to serve searches, got %+v, %v", response, err)
+	}
+}
+
+func TestNewSearcher_UnknownBackend(t *testing.T) {
+	_, err := NewSearcher(config.SearchSettings{Backend: "missing"})
+	if err == nil || !contains(err.Error(), `unknown search backend "missing"`) {
+		t.Errorf("Expected unknown backend error, got %v", err)
+	}
+}
+
+func TestNewSearcher_FactoryError(t *testing.T) {
+	RegisterBackend("failing", func(settings config.SearchSettings) (Searcher, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	_, err := NewSearcher(config.SearchSettings{Backend: "failing"})
+	if err == nil || !contains(err.Error(), "connection refused") {
+		t.Errorf("Expected factory error, got %v", err)
+	}
+}
+
+func TestRegisterBackend_Panics(t *testing.T) {
+	factory := func(settings config.SearchSettings) (Searcher, error) { return &dummySearcher{}, nil }
+
+	tests := []struct {
+		name    string
+		backend string
+		factory BackendFactory
+	}{
+		{name: "Empty Name", backend: "", factory: factory},
+		{name: "Nil Factory", backend: "nil-factory", factory: nil},
+		{name: "Duplicate", backend: DefaultBackend, factory: factory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			RegisterBackend(tt.backend, tt.factory)
+		})
+	}
+}
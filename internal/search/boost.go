@@ -0,0 +1,30 @@
+package search
+
+import (
+	"sort"
+
+	blevesearch "github.com/blevesearch/bleve/v2/search"
+)
+
+// boostWindow is how many times the requested number of hits is fetched when
+// documents carry boosts, so a boosted document ranked just below the
+// unboosted top hits can still move into them
+const boostWindow = 4
+
+// applyBoosts multiplies the score of each hit by the boost of its document,
+// re-sorts the hits by score, and keeps at most limit of them. Hits of equal
+// score keep their relative order.
+func applyBoosts(hits blevesearch.DocumentMatchCollection, boosts map[string]float64, limit int) blevesearch.DocumentMatchCollection {
+	for _, hit := range hits {
+		if boost, ok := boosts[hit.ID]; ok {
+			hit.Score *= boost
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	blevesearch "github.com/blevesearch/bleve/v2/search"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+func TestApplyBoosts(t *testing.T) {
+	hits := blevesearch.DocumentMatchCollection{
+		{ID: "a", Score: 3},
+		{ID: "b", Score: 2},
+		{ID: "c", Score: 2},
+		{ID: "d", Score: 1},
+	}
+	got := applyBoosts(hits, map[string]float64{"c": 2, "a": 0.5}, 3)
+
+	ids := make([]string, len(got))
+	for i, hit := range got {
+		ids[i] = hit.ID
+	}
+	if want := []string{"c", "b", "a"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected order %v, got %v", want, ids)
+	}
+	if got[0].Score != 4 || got[2].Score != 1.5 {
+		t.Errorf("Unexpected boosted scores: %v, %v", got[0].Score, got[2].Score)
+	}
+}
+
+func TestSearch_ResourceBoost(t *testing.T) {
+	// Equally relevant peers, differing only in their boost
+	docs := []domain.Document{
+		{URI: "acdc://a", Name: "Alpha", Content: "How to rotate credentials."},
+		{URI: "acdc://b", Name: "Bravo", Content: "How to rotate credentials."},
+		{URI: "acdc://c", Name: "Charlie", Content: "How to rotate credentials."},
+	}
+	search := func(t *testing.T, maxResults int, boosts map[string]float64) []string {
+		settings := testSettings()
+		settings.InMemory = true
+		settings.MaxResults = maxResults
+		service := NewService(settings)
+		defer service.Close()
+
+		boosted := make([]domain.Document, len(docs))
+		for i, d := range docs {
+			d.Boost = boosts[d.URI]
+			boosted[i] = d
+		}
+		if err := indexDocsHelper(service, boosted); err != nil {
+			t.Fatalf("IndexDocuments failed: %v", err)
+		}
+		results, err := searchResults(service.Search(context.Background(), "credentials", SearchOptions{}))
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		uris := make([]string, len(results))
+		for i, r := range results {
+			uris[i] = r.URI
+		}
+		return uris
+	}
+
+	unboosted := search(t, 10, nil)
+	if len(unboosted) != 3 {
+		t.Fatalf("Expected 3 results, got %v", unboosted)
+	}
+	last := unboosted[2]
+
+	t.Run("Boosted Outranks Peers", func(t *testing.T) {
+		got := search(t, 10, map[string]float64{last: 1.5})
+		if got[0] != last {
+			t.Errorf("Expected boosted %s first, got %v", last, got)
+		}
+	})
+
+	t.Run("Boosted Enters Truncated Results", func(t *testing.T) {
+		got := search(t, 1, map[string]float64{last: 1.5})
+		if len(got) != 1 || got[0] != last {
+			t.Errorf("Expected only boosted %s, got %v", last, got)
+		}
+	})
+
+	t.Run("Demoted Ranks Last", func(t *testing.T) {
+		first := unboosted[0]
+		got := search(t, 10, map[string]float64{first: 0.5})
+		if got[2] != first {
+			t.Errorf("Expected demoted %s last, got %v", first, got)
+		}
+	})
+}
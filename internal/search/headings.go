@@ -0,0 +1,33 @@
+package search
+
+import "strings"
+
+// maxHeadingLevel is the deepest markdown heading level indexed as a heading.
+// Deeper headings mostly label small sections and are left to the content.
+const maxHeadingLevel = 2
+
+// extractHeadings returns the text of the ATX level-one and level-two
+// headings of a markdown document, in order. Lines in fenced code blocks,
+// such as shell comments, are not headings.
+func extractHeadings(content string) []string {
+	var headings []string
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+		if level == 0 || level > maxHeadingLevel || (len(trimmed) > level && trimmed[level] != ' ' && trimmed[level] != '\t') {
+			continue
+		}
+		if text := strings.TrimSpace(strings.TrimRight(trimmed[level:], "#")); text != "" {
+			headings = append(headings, text)
+		}
+	}
+	return headings
+}
@@ -0,0 +1,68 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+func TestExtractHeadings(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "Top Levels", content: "# Title\n\nText\n\n## Section\n\n### Detail", want: []string{"Title", "Section"}},
+		{name: "Closing Hashes", content: "## Section ##\n", want: []string{"Section"}},
+		{name: "Ignores Code Blocks", content: "```sh\n# comment\n```\n# Title", want: []string{"Title"}},
+		{name: "Requires Space", content: "#hashtag\n#\n# Title", want: []string{"Title"}},
+		{name: "None", content: "Plain text", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractHeadings(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractHeadings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch_HeadingsBoost(t *testing.T) {
+	// The term is in a heading of one document and buried in the (shorter)
+	// body of the other, which wins on content relevance alone
+	docs := []domain.Document{
+		{URI: "acdc://heading", Name: "heading", Content: "# Rollback\n\nSteps for reverting a broken release to the previous version safely and quickly."},
+		{URI: "acdc://body", Name: "body", Content: "# Releases\n\nA rollback reverts a broken release."},
+	}
+
+	tests := []struct {
+		name  string
+		boost float64
+		first string
+	}{
+		{name: "Disabled", boost: 0, first: "acdc://body"},
+		{name: "Enabled", boost: 2.0, first: "acdc://heading"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := testSettings()
+			settings.InMemory = true
+			settings.HeadingsBoost = tt.boost
+			service := NewService(settings)
+			defer service.Close()
+
+			if err := indexDocsHelper(service, docs); err != nil {
+				t.Fatalf("IndexDocuments failed: %v", err)
+			}
+			results, err := searchResults(service.Search(context.Background(), "rollback", SearchOptions{}))
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(results) != 2 || results[0].URI != tt.first {
+				t.Errorf("Expected %s to rank first, got %+v", tt.first, results)
+			}
+		})
+	}
+}
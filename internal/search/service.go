@@ -2,12 +2,25 @@ package search
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"log/slog"
 	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
+	"github.com/blevesearch/bleve/v2/index/scorch"
 	"github.com/blevesearch/bleve/v2/mapping"
+	blevesearch "github.com/blevesearch/bleve/v2/search"
 	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/sha1n/mcp-acdc-server/internal/config"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
@@ -18,11 +31,83 @@ type SearchResult struct {
 	URI     string
 	Name    string
 	Snippet string
+	// MatchedKeywords lists the document keywords that matched the query
+	MatchedKeywords []string
 }
 
-// Searcher interface in search package
+// ErrUnknownSnippetSource is returned by Search for an unsupported snippet source
+var ErrUnknownSnippetSource = errors.New("unknown snippet source")
+
+// ErrUnknownFilterField is returned by Search for a filter on a field that is
+// not configured for indexing
+var ErrUnknownFilterField = errors.New("unknown search filter field")
+
+// Snippet sources select what a search result's snippet is built from
+const (
+	// SnippetSourceBody shows an excerpt around the content match (default)
+	SnippetSourceBody = "body"
+	// SnippetSourceDescription shows the document description
+	SnippetSourceDescription = "description"
+	// SnippetSourceAuto shows an excerpt when the content matched and the
+	// description otherwise
+	SnippetSourceAuto = "auto"
+)
+
+// SearchOptions narrows a search
+type SearchOptions struct {
+	// Limit optionally lowers the configured maximum number of results
+	Limit *int
+	// Filters restricts results to documents whose frontmatter fields equal the
+	// given values (case-insensitive). Keys must be configured search fields.
+	Filters map[string]string
+	// Since excludes documents last modified before it, when set
+	Since time.Time
+	// URIs restricts results to the documents with the given URIs, when
+	// non-nil. An empty, non-nil list matches nothing.
+	URIs []string
+	// Suggest requests alternative query terms when nothing matches
+	Suggest bool
+	// SnippetSource selects what result snippets contain, one of the
+	// SnippetSource constants. Empty means SnippetSourceBody.
+	SnippetSource string
+}
+
+// SearchResponse is the aggregate result of a search
+type SearchResponse struct {
+	Results []SearchResult
+	// Suggestions holds alternative query terms, populated only when
+	// suggestions were requested and no results were found
+	Suggestions []string
+	// DroppedTerms is the number of query terms ignored because the query
+	// exceeded the configured maximum number of terms
+	DroppedTerms int
+	// Total is the number of matching documents, which exceeds the number of
+	// results when they were truncated to the result limit. Backends that do
+	// not count matches leave it zero.
+	Total int
+}
+
+// ContentLoader re-reads the content of an indexed document by URI
+type ContentLoader func(uri string) (string, error)
+
+// ContentLoaderSetter is implemented by searchers that can build snippets
+// from re-read content instead of retaining document bodies in the index
+type ContentLoaderSetter interface {
+	SetContentLoader(loader ContentLoader)
+}
+
+// StreamSearcher is implemented by searchers that can yield results as they
+// are consumed instead of returning them all at once
+type StreamSearcher interface {
+	SearchStream(ctx context.Context, queryStr string, opts SearchOptions) iter.Seq2[SearchResult, error]
+}
+
+// Searcher interface in search package. Index replaces everything indexed
+// before with the streamed documents, which are keyed by URI: a URI streamed
+// more than once is indexed once, as its last version. Calling Index again,
+// e.g. on reload, therefore never duplicates documents.
 type Searcher interface {
-	Search(queryStr string, limit *int) ([]SearchResult, error)
+	Search(ctx context.Context, queryStr string, opts SearchOptions) (SearchResponse, error)
 	Index(ctx context.Context, documents <-chan domain.Document) error
 	Close()
 }
@@ -35,23 +120,52 @@ type BatchIndexer interface {
 
 // Service search service using Bleve
 type Service struct {
-	settings config.SearchSettings
-	index    bleve.Index
-	indexDir string
+	// mu guards the index, so content can be re-indexed while serving searches
+	mu         sync.RWMutex
+	settings   config.SearchSettings
+	index      bleve.Index
+	indexDir   string
+	vocabulary *vocabulary
+	loader     ContentLoader
+	synonyms   map[string][]string
+	boosts     map[string]float64 // Score multipliers of boosted documents by URI
 }
 
-// Ensure Service implements Searcher
-var _ Searcher = (*Service)(nil)
+// Ensure Service implements Searcher, ContentLoaderSetter and StreamSearcher
+var (
+	_ Searcher            = (*Service)(nil)
+	_ ContentLoaderSetter = (*Service)(nil)
+	_ StreamSearcher      = (*Service)(nil)
+)
 
 // NewService creates a new search service
 func NewService(settings config.SearchSettings) *Service {
+	synonyms, err := settings.SynonymMap()
+	if err != nil {
+		slog.Warn("Ignoring invalid search synonyms", "error", err)
+	}
 	return &Service{
-		settings: settings,
+		settings:   settings,
+		vocabulary: newVocabulary(),
+		synonyms:   synonyms,
+		boosts:     make(map[string]float64),
 	}
 }
 
-// Index indexes a stream of documents
+// SetContentLoader sets the loader used to build snippets in low memory
+// mode, where document bodies are not stored in the index
+func (s *Service) SetContentLoader(loader ContentLoader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loader = loader
+}
+
+// Index indexes a stream of documents, replacing the current index. A URI
+// streamed more than once is indexed as its last version.
 func (s *Service) Index(ctx context.Context, documents <-chan domain.Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Close existing index if any
 	if s.index != nil {
 		_ = s.index.Close()
@@ -62,13 +176,19 @@ func (s *Service) Index(ctx context.Context, documents <-chan domain.Document) e
 	}
 
 	// Define mapping
-	indexMapping := buildMapping()
+	indexMapping := buildMapping(s.settings.Fields, !s.settings.LowMemory, s.settings.Ranking)
 
 	var index bleve.Index
 	var err error
 
 	if s.settings.InMemory {
-		index, err = bleve.NewMemOnly(indexMapping)
+		if s.settings.Ranking == config.SearchRankingBM25 {
+			// The default in-memory index type does not track the field
+			// lengths BM25 needs, while scorch does when given no path
+			index, err = bleve.NewUsing("", indexMapping, scorch.Name, scorch.Name, nil)
+		} else {
+			index, err = bleve.NewMemOnly(indexMapping)
+		}
 	} else {
 		// Create temp dir
 		var mkErr error
@@ -89,48 +209,88 @@ func (s *Service) Index(ctx context.Context, documents <-chan domain.Document) e
 		return fmt.Errorf("failed to create index: %w", err)
 	}
 	s.index = index
+	s.vocabulary = newVocabulary()
+	s.boosts = make(map[string]float64)
 
 	return s.batchIndex(ctx, s.index, documents)
 }
 
+// batchIndex indexes the streamed documents in batches. The stream is
+// collected first and deduplicated by URI, keeping the last version, so that
+// a repeated URI is indexed and counted in the vocabulary once.
 func (s *Service) batchIndex(ctx context.Context, index BatchIndexer, documents <-chan domain.Document) error {
-	// Batch index
-	batch := index.NewBatch()
-	batchSize := 100 // configurable?
-	count := 0
-
-	for {
+	var docs []domain.Document
+	positions := make(map[string]int)
+	for collecting := true; collecting; {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case doc, ok := <-documents:
 			if !ok {
-				// Channel closed, flush remaining
-				if count > 0 {
-					if err := index.Batch(batch); err != nil {
-						return fmt.Errorf("failed to execute final batch index: %w", err)
-					}
-				}
-				return nil
+				collecting = false
+				break
 			}
-
-			if err := batch.Index(doc.URI, doc); err != nil {
-				return fmt.Errorf("failed to add document to batch: %w", err)
+			if i, seen := positions[doc.URI]; seen {
+				docs[i] = doc
+				continue
 			}
-			count++
-
-			if count >= batchSize {
-				if err := index.Batch(batch); err != nil {
-					return fmt.Errorf("failed to execute batch index: %w", err)
-				}
-				batch = index.NewBatch()
-				count = 0
+			positions[doc.URI] = len(docs)
+			docs = append(docs, doc)
+		}
+	}
+
+	batch := index.NewBatch()
+	batchSize := 100 // configurable?
+	count := 0
+	for _, doc := range docs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		doc.Headings = extractHeadings(doc.Content)
+		if err := batch.Index(doc.URI, doc); err != nil {
+			return fmt.Errorf("failed to add document to batch: %w", err)
+		}
+		s.vocabulary.add(doc)
+		if doc.Boost > 0 && doc.Boost != 1 {
+			s.boosts[doc.URI] = doc.Boost
+		}
+		count++
+
+		if count >= batchSize {
+			if err := index.Batch(batch); err != nil {
+				return fmt.Errorf("failed to execute batch index: %w", err)
 			}
+			batch = index.NewBatch()
+			count = 0
 		}
 	}
+
+	if count > 0 {
+		if err := index.Batch(batch); err != nil {
+			return fmt.Errorf("failed to execute final batch index: %w", err)
+		}
+	}
+	return nil
+}
+
+// exactAnalyzer indexes a whole field value as a single lowercased term
+const exactAnalyzer = "exact"
+
+// fieldTextPath returns the analyzed, searchable path of a frontmatter field
+func fieldTextPath(name string) string {
+	return domain.FieldFields + "." + name
 }
 
-func buildMapping() mapping.IndexMapping {
+// fieldExactPath returns the path of a frontmatter field used for filtering
+func fieldExactPath(name string) string {
+	return domain.FieldFields + "." + name + "_exact"
+}
+
+// buildMapping creates the index mapping. Content bodies are only stored,
+// for highlighting, when storeContent is set. A ranking of
+// config.SearchRankingBM25 selects BM25 scoring; anything else keeps TF-IDF.
+func buildMapping(fields []string, storeContent bool, ranking string) mapping.IndexMapping {
 	// URI field: Stored, Indexed
 	uriMapping := bleve.NewTextFieldMapping()
 	uriMapping.Store = true
@@ -142,39 +302,196 @@ func buildMapping() mapping.IndexMapping {
 	nameMapping.IncludeInAll = true
 	nameMapping.Analyzer = "en"
 
-	// Content field: Indexed, Not Stored, Included in All
+	// Title field: Indexed, Not Stored, Included in All
+	// Scored with the name boost at query time
+	titleMapping := bleve.NewTextFieldMapping()
+	titleMapping.Store = false
+	titleMapping.IncludeInAll = true
+	titleMapping.Analyzer = "en"
+
+	// Content field: Indexed, Stored for highlighting unless in low memory mode, Included in All
 	contentMapping := bleve.NewTextFieldMapping()
-	contentMapping.Store = true // DEBUG: Store content to ensure we can see it
+	contentMapping.Store = storeContent
 	contentMapping.IncludeInAll = true
 	contentMapping.Analyzer = "en"
 
-	// Keywords field: Indexed, Not Stored, Included in All
+	// Description field: Stored for snippets, Not Indexed
+	descriptionMapping := bleve.NewTextFieldMapping()
+	descriptionMapping.Store = true
+	descriptionMapping.Index = false
+	descriptionMapping.IncludeInAll = false
+
+	// Boost field: Neither indexed nor stored, boosts are applied to hits
+	// from the service's own map
+	boostMapping := bleve.NewNumericFieldMapping()
+	boostMapping.Index = false
+	boostMapping.Store = false
+	boostMapping.IncludeInAll = false
+
+	// Keywords field: Indexed, Stored to report matched keywords, Included in All
 	// Boosting is done at query-time via DisjunctionQuery
 	keywordsMapping := bleve.NewTextFieldMapping()
-	keywordsMapping.Store = false
+	keywordsMapping.Store = true
 	keywordsMapping.IncludeInAll = true
 	keywordsMapping.Analyzer = "en"
 
+	// Headings field: Indexed, Not Stored, Included in All
+	// Heading text is also part of the content; matches here add the headings boost
+	headingsMapping := bleve.NewTextFieldMapping()
+	headingsMapping.Store = false
+	headingsMapping.IncludeInAll = true
+	headingsMapping.Analyzer = "en"
+
 	docMapping := bleve.NewDocumentMapping()
 	docMapping.AddFieldMappingsAt(domain.FieldURI, uriMapping)
 	docMapping.AddFieldMappingsAt(domain.FieldName, nameMapping)
+	docMapping.AddFieldMappingsAt(domain.FieldTitle, titleMapping)
+	docMapping.AddFieldMappingsAt(domain.FieldDescription, descriptionMapping)
 	docMapping.AddFieldMappingsAt(domain.FieldContent, contentMapping)
 	docMapping.AddFieldMappingsAt(domain.FieldKeywords, keywordsMapping)
+	docMapping.AddFieldMappingsAt(domain.FieldHeadings, headingsMapping)
+	docMapping.AddFieldMappingsAt(domain.FieldBoost, boostMapping)
+
+	// Frontmatter fields: only configured fields are indexed, each both as
+	// analyzed text for searching and as an exact value for filtering
+	fieldsMapping := bleve.NewDocumentMapping()
+	fieldsMapping.Dynamic = false
+	for _, name := range fields {
+		textMapping := bleve.NewTextFieldMapping()
+		textMapping.Store = false
+		textMapping.IncludeInAll = true
+		textMapping.Analyzer = "en"
+
+		exactMapping := bleve.NewTextFieldMapping()
+		exactMapping.Name = name + "_exact"
+		exactMapping.Store = false
+		exactMapping.IncludeInAll = false
+		exactMapping.Analyzer = exactAnalyzer
+
+		fieldsMapping.AddFieldMappingsAt(name, textMapping, exactMapping)
+	}
+	docMapping.AddSubDocumentMapping(domain.FieldFields, fieldsMapping)
+
+	// Last modified field: Indexed as a date for range filtering
+	lastModifiedMapping := bleve.NewDateTimeFieldMapping()
+	lastModifiedMapping.Store = false
+	lastModifiedMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt(domain.FieldLastModified, lastModifiedMapping)
 
 	mapping := bleve.NewIndexMapping()
+	_ = mapping.AddCustomAnalyzer(exactAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	})
 	mapping.DefaultMapping = docMapping
+	if ranking == config.SearchRankingBM25 {
+		mapping.ScoringModel = config.SearchRankingBM25
+	}
 	return mapping
 }
 
 // Search searches for resources
-func (s *Service) Search(queryStr string, limit *int) ([]SearchResult, error) {
+func (s *Service) Search(ctx context.Context, queryStr string, opts SearchOptions) (SearchResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	run, err := s.run(ctx, queryStr, opts)
+	if err != nil {
+		return SearchResponse{}, err
+	}
+
+	results := make([]SearchResult, 0, len(run.hits))
+	for _, hit := range run.hits {
+		if result, ok := s.result(hit, opts.SnippetSource, s.loader); ok {
+			results = append(results, result)
+		}
+	}
+
+	response := SearchResponse{Results: results, DroppedTerms: run.dropped, Total: run.total}
+	if opts.Suggest && len(results) == 0 && run.query != "" && run.query != "*" {
+		response.Suggestions = s.vocabulary.suggest(run.query)
+	}
+	return response, nil
+}
+
+// SearchStream searches like Search, but yields the results one at a time in
+// rank order instead of collecting them. Snippets are built as results are
+// consumed, so a consumer that stops early does not pay for the rest, which
+// matters in low memory mode where snippets re-read content. An error is
+// yielded once, as the last element. The index is only locked while the query
+// runs, so consumers may search or re-index while iterating.
+func (s *Service) SearchStream(ctx context.Context, queryStr string, opts SearchOptions) iter.Seq2[SearchResult, error] {
+	return func(yield func(SearchResult, error) bool) {
+		s.mu.RLock()
+		run, err := s.run(ctx, queryStr, opts)
+		loader := s.loader
+		s.mu.RUnlock()
+
+		if err != nil {
+			yield(SearchResult{}, err)
+			return
+		}
+		for _, hit := range run.hits {
+			if err := ctx.Err(); err != nil {
+				yield(SearchResult{}, err)
+				return
+			}
+			result, ok := s.result(hit, opts.SnippetSource, loader)
+			if ok && !yield(result, nil) {
+				return
+			}
+		}
+	}
+}
+
+// searchRun is the outcome of running a search query against the index
+type searchRun struct {
+	hits    blevesearch.DocumentMatchCollection // ranked and cut to the result limit
+	total   int                                 // number of matching documents
+	dropped int                                 // query terms dropped by the term limit
+	query   string                              // the query after truncation, empty if nothing was searched
+}
+
+// run validates the options and runs a search. Callers must hold the read lock.
+func (s *Service) run(ctx context.Context, queryStr string, opts SearchOptions) (searchRun, error) {
 	if s.index == nil {
-		return []SearchResult{}, nil
+		return searchRun{}, nil
+	}
+
+	switch opts.SnippetSource {
+	case "", SnippetSourceBody, SnippetSourceDescription, SnippetSourceAuto:
+	default:
+		return searchRun{}, fmt.Errorf("%w %q (expected %s, %s, or %s)",
+			ErrUnknownSnippetSource, opts.SnippetSource, SnippetSourceBody, SnippetSourceDescription, SnippetSourceAuto)
+	}
+
+	queryStr, dropped := truncateTerms(queryStr, s.settings.MaxTerms)
+	if dropped > 0 {
+		slog.Warn("Search query truncated", "max_terms", s.settings.MaxTerms, "dropped", dropped)
 	}
 
+	// A per-request limit may narrow the configured maximum, but never exceed it
 	maxResults := s.settings.MaxResults
-	if limit != nil {
-		maxResults = *limit
+	if opts.Limit != nil && *opts.Limit > 0 && *opts.Limit < maxResults {
+		maxResults = *opts.Limit
+	}
+
+	filters, err := s.buildFilters(opts.Filters)
+	if err != nil {
+		return searchRun{}, err
+	}
+	if !opts.Since.IsZero() {
+		inclusive := true
+		sinceQuery := bleve.NewDateRangeInclusiveQuery(opts.Since, time.Time{}, &inclusive, nil)
+		sinceQuery.SetField(domain.FieldLastModified)
+		filters = append(filters, sinceQuery)
+	}
+	if opts.URIs != nil {
+		if len(opts.URIs) == 0 {
+			return searchRun{dropped: dropped}, nil
+		}
+		filters = append(filters, bleve.NewDocIDQuery(opts.URIs))
 	}
 
 	// Build query with keyword boosting
@@ -183,67 +500,246 @@ func (s *Service) Search(queryStr string, limit *int) ([]SearchResult, error) {
 	if queryStr == "*" {
 		q = bleve.NewMatchAllQuery()
 	} else {
-		// Create field-specific queries with boosting and fuzziness
-		nameQuery := bleve.NewMatchQuery(queryStr)
-		nameQuery.SetField(domain.FieldName)
-		nameQuery.SetFuzziness(1)
-		nameQuery.SetBoost(s.settings.NameBoost)
+		q = bleve.NewDisjunctionQuery(s.fieldQueries(queryStr, 1)...)
 
-		contentQuery := bleve.NewMatchQuery(queryStr)
-		contentQuery.SetField(domain.FieldContent)
-		contentQuery.SetFuzziness(1)
-		contentQuery.SetBoost(s.settings.ContentBoost)
-
-		keywordsQuery := bleve.NewMatchQuery(queryStr)
-		keywordsQuery.SetField(domain.FieldKeywords)
-		keywordsQuery.SetFuzziness(1)
-		keywordsQuery.SetBoost(s.settings.KeywordsBoost)
+		// Synonyms of the query terms are searched alongside them, with
+		// reduced boosts
+		if expanded := expandSynonyms(queryStr, s.synonyms); expanded != "" {
+			q = bleve.NewDisjunctionQuery(q, bleve.NewDisjunctionQuery(s.fieldQueries(expanded, synonymBoost)...))
+		}
+	}
 
-		// DisjunctionQuery combines results, boosted fields will score higher
-		q = bleve.NewDisjunctionQuery(nameQuery, contentQuery, keywordsQuery)
+	if len(filters) > 0 {
+		q = bleve.NewConjunctionQuery(append([]query.Query{q}, filters...)...)
 	}
 
 	searchRequest := bleve.NewSearchRequest(q)
 	searchRequest.Size = maxResults
-	searchRequest.Fields = []string{domain.FieldURI, domain.FieldName, domain.FieldContent}
-	searchRequest.Highlight = bleve.NewHighlight()
+	if len(s.boosts) > 0 {
+		searchRequest.Size = maxResults * boostWindow
+	}
+	searchRequest.Fields = []string{domain.FieldURI, domain.FieldName, domain.FieldDescription, domain.FieldKeywords}
+	searchRequest.IncludeLocations = true
+	if !s.settings.LowMemory {
+		searchRequest.Fields = append(searchRequest.Fields, domain.FieldContent)
+		searchRequest.Highlight = bleve.NewHighlight()
+	}
 
-	searchResult, err := s.index.Search(searchRequest)
+	searchResult, err := s.index.SearchInContext(ctx, searchRequest)
 	if err != nil {
-		return nil, fmt.Errorf("search failed: %w", err)
+		return searchRun{}, fmt.Errorf("search failed: %w", err)
 	}
 
-	results := make([]SearchResult, 0, len(searchResult.Hits))
-	for _, hit := range searchResult.Hits {
-		uri, ok := hit.Fields[domain.FieldURI].(string)
-		if !ok {
-			slog.Warn("Search hit missing URI field", "id", hit.ID)
-			continue
+	hits := searchResult.Hits
+	if len(s.boosts) > 0 {
+		hits = applyBoosts(hits, s.boosts, maxResults)
+	}
+	return searchRun{hits: hits, total: int(searchResult.Total), dropped: dropped, query: queryStr}, nil
+}
+
+// result converts a search hit into a result, re-reading content with loader
+// for snippets when the index does not store it. Hits without a URI are
+// skipped.
+func (s *Service) result(hit *blevesearch.DocumentMatch, snippetSource string, loader ContentLoader) (SearchResult, bool) {
+	uri, ok := hit.Fields[domain.FieldURI].(string)
+	if !ok {
+		slog.Warn("Search hit missing URI field", "id", hit.ID)
+		return SearchResult{}, false
+	}
+
+	name, ok := hit.Fields[domain.FieldName].(string)
+	if !ok || name == "" {
+		name = "Unknown" // Fallback
+	}
+
+	return SearchResult{
+		URI:             uri,
+		Name:            name,
+		Snippet:         s.snippet(uri, name, hit, snippetSource, loader),
+		MatchedKeywords: matchedKeywords(hit),
+	}, true
+}
+
+// fieldQueries creates one fuzzy match query for text per searched field,
+// with the configured field boosts scaled by factor. Combined in a
+// DisjunctionQuery, boosted fields score higher.
+func (s *Service) fieldQueries(text string, factor float64) []query.Query {
+	newQuery := func(field string, boost float64) query.Query {
+		q := bleve.NewMatchQuery(text)
+		q.SetField(field)
+		q.SetFuzziness(1)
+		q.SetBoost(boost * factor)
+		return q
+	}
+
+	queries := []query.Query{
+		newQuery(domain.FieldName, s.settings.NameBoost),
+		newQuery(domain.FieldTitle, s.settings.NameBoost),
+		newQuery(domain.FieldContent, s.settings.ContentBoost),
+		newQuery(domain.FieldKeywords, s.settings.KeywordsBoost),
+	}
+	if s.settings.HeadingsBoost > 0 {
+		queries = append(queries, newQuery(domain.FieldHeadings, s.settings.HeadingsBoost))
+	}
+	for _, name := range s.settings.Fields {
+		queries = append(queries, newQuery(fieldTextPath(name), s.settings.FieldsBoost))
+	}
+	return queries
+}
+
+// snippet describes a hit according to the snippet source: a highlighted
+// content excerpt, the document description, or the name as a last resort
+func (s *Service) snippet(uri, name string, hit *blevesearch.DocumentMatch, source string, loader ContentLoader) string {
+	var excerpt string
+	if source != SnippetSourceDescription {
+		if fragments := hit.Fragments[domain.FieldContent]; len(fragments) > 0 {
+			excerpt = fragments[0]
+		} else if s.settings.LowMemory {
+			excerpt = loadFragment(loader, uri, hit)
 		}
+	}
+	if excerpt != "" {
+		return fmt.Sprintf("%s... (relevance: %.2f)", excerpt, hit.Score)
+	}
 
-		name, ok := hit.Fields[domain.FieldName].(string)
-		if !ok || name == "" {
-			name = "Unknown" // Fallback
+	if source == SnippetSourceDescription || source == SnippetSourceAuto {
+		if description, ok := hit.Fields[domain.FieldDescription].(string); ok && description != "" {
+			return fmt.Sprintf("%s (relevance: %.2f)", description, hit.Score)
 		}
+	}
+	return fmt.Sprintf("%s (relevance: %.2f)", name, hit.Score)
+}
 
-		// Improved snippet generation with highlighting
-		snippet := fmt.Sprintf("%s (relevance: %.2f)", name, hit.Score)
-		if fragments, ok := hit.Fragments[domain.FieldContent]; ok && len(fragments) > 0 {
-			snippet = fmt.Sprintf("%s... (relevance: %.2f)", fragments[0], hit.Score)
+// fragmentContext is the number of bytes of content shown on each side of a
+// match in fragments built from re-read content
+const fragmentContext = 100
+
+// loadFragment builds a highlighted fragment around the first content match
+// of a hit by re-reading the document with loader, for indexes that do not
+// store content. It returns an empty string when there is no content match or
+// no loader.
+func loadFragment(loader ContentLoader, uri string, hit *blevesearch.DocumentMatch) string {
+	if loader == nil {
+		return ""
+	}
+	var first *blevesearch.Location
+	for _, locations := range hit.Locations[domain.FieldContent] {
+		for _, location := range locations {
+			if first == nil || location.Start < first.Start {
+				first = location
+			}
+		}
+	}
+	if first == nil {
+		return ""
+	}
+
+	content, err := loader(uri)
+	if err != nil {
+		// Not every indexed document is a readable resource, e.g. prompts
+		slog.Debug("Failed to load content for snippet", "uri", uri, "error", err)
+		return ""
+	}
+	// The content may have changed since it was indexed
+	start, end := int(first.Start), int(first.End)
+	if end > len(content) || start >= end {
+		return ""
+	}
+
+	from := max(start-fragmentContext, 0)
+	for from > 0 && !utf8.RuneStart(content[from]) {
+		from--
+	}
+	to := min(end+fragmentContext, len(content))
+	for to < len(content) && !utf8.RuneStart(content[to]) {
+		to++
+	}
+	return content[from:start] + "<mark>" + content[start:end] + "</mark>" + content[end:to]
+}
+
+// matchedKeywords returns the stored keywords of a hit that have a match
+// location, in the order they appear in the document
+func matchedKeywords(hit *blevesearch.DocumentMatch) []string {
+	termLocations := hit.Locations[domain.FieldKeywords]
+	if len(termLocations) == 0 {
+		return nil
+	}
+
+	// A single keyword is stored as a string, several as a list
+	var keywords []string
+	switch v := hit.Fields[domain.FieldKeywords].(type) {
+	case string:
+		keywords = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				keywords = append(keywords, s)
+			}
+		}
+	}
+
+	// Locations of array fields carry the position of the matching element
+	matched := make([]bool, len(keywords))
+	for _, locations := range termLocations {
+		for _, location := range locations {
+			position := 0
+			if len(location.ArrayPositions) > 0 {
+				position = int(location.ArrayPositions[0])
+			}
+			if position < len(matched) {
+				matched[position] = true
+			}
 		}
+	}
 
-		results = append(results, SearchResult{
-			URI:     uri,
-			Name:    name,
-			Snippet: snippet,
-		})
+	var result []string
+	for i, keyword := range keywords {
+		if matched[i] {
+			result = append(result, keyword)
+		}
 	}
+	return result
+}
 
-	return results, nil
+// truncateTerms keeps the first maxTerms whitespace-separated terms of
+// queryStr and reports how many were dropped. A maxTerms of 0 disables the limit.
+func truncateTerms(queryStr string, maxTerms int) (string, int) {
+	if maxTerms <= 0 {
+		return queryStr, 0
+	}
+	terms := strings.Fields(queryStr)
+	if len(terms) <= maxTerms {
+		return queryStr, 0
+	}
+	return strings.Join(terms[:maxTerms], " "), len(terms) - maxTerms
+}
+
+// buildFilters converts field filters to exact-match queries, rejecting
+// fields that are not configured for indexing
+func (s *Service) buildFilters(filters map[string]string) ([]query.Query, error) {
+	names := make([]string, 0, len(filters))
+	for name := range filters {
+		if !slices.Contains(s.settings.Fields, name) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFilterField, name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	queries := make([]query.Query, 0, len(names))
+	for _, name := range names {
+		termQuery := bleve.NewTermQuery(strings.ToLower(filters[name]))
+		termQuery.SetField(fieldExactPath(name))
+		queries = append(queries, termQuery)
+	}
+	return queries, nil
 }
 
 // Close cleans up resources
 func (s *Service) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.index != nil {
 		_ = s.index.Close()
 	}
@@ -254,6 +750,9 @@ func (s *Service) Close() {
 
 // DocCount returns number of docs in index
 func (s *Service) DocCount() (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if s.index == nil {
 		return 0, nil
 	}
@@ -5,9 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/blevesearch/bleve/v2"
+	blevesearch "github.com/blevesearch/bleve/v2/search"
 	"github.com/sha1n/mcp-acdc-server/internal/config"
 	"github.com/sha1n/mcp-acdc-server/internal/domain"
 )
@@ -33,7 +39,7 @@ func TestService_BatchIndex_AddToBatchError(t *testing.T) {
 	defer s.Close()
 
 	// Create a real index to pass to batchIndex
-	index, _ := bleve.NewMemOnly(buildMapping())
+	index, _ := bleve.NewMemOnly(buildMapping(nil, true, ""))
 
 	// Document with empty URI should fail batch.Index
 	docs := []domain.Document{
@@ -57,7 +63,7 @@ func TestService_BatchIndex_BatchExecutionError(t *testing.T) {
 	s := NewService(testSettings())
 	defer s.Close()
 
-	realIndex, _ := bleve.NewMemOnly(buildMapping())
+	realIndex, _ := bleve.NewMemOnly(buildMapping(nil, true, ""))
 	mockIndex := &mockBatchIndexer{
 		realIndex: realIndex,
 		batchErr:  errors.New("simulated batch error"),
@@ -83,7 +89,7 @@ func TestService_BatchIndex_FullBatchError(t *testing.T) {
 	s := NewService(testSettings())
 	defer s.Close()
 
-	realIndex, _ := bleve.NewMemOnly(buildMapping())
+	realIndex, _ := bleve.NewMemOnly(buildMapping(nil, true, ""))
 	mockIndex := &mockBatchIndexer{
 		realIndex: realIndex,
 		batchErr:  errors.New("simulated batch error"),
@@ -117,6 +123,11 @@ func testSettings() config.SearchSettings {
 	}
 }
 
+// searchResults unwraps the results of a search response
+func searchResults(response SearchResponse, err error) ([]SearchResult, error) {
+	return response.Results, err
+}
+
 func indexDocsHelper(s *Service, docs []domain.Document) error {
 	ch := make(chan domain.Document, len(docs))
 	for _, d := range docs {
@@ -217,7 +228,7 @@ func TestSearchService(t *testing.T) {
 	}
 
 	// Search for "testing"
-	results, err := service.Search("testing", nil)
+	results, err := searchResults(service.Search(context.Background(), "testing", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -230,7 +241,7 @@ func TestSearchService(t *testing.T) {
 	}
 
 	// Search for "document"
-	results, err = service.Search("document", nil)
+	results, err = searchResults(service.Search(context.Background(), "document", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -270,10 +281,90 @@ func TestSearchService_ReIndex(t *testing.T) {
 	}
 }
 
+func TestSearchService_ReIndexIdempotent(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	corpus := []domain.Document{
+		{URI: "acdc://a", Name: "Deploy Guide", Content: "How to deploy"},
+		{URI: "acdc://b", Name: "Deploy Checklist", Content: "Before you deploy", Boost: 2},
+		{URI: "acdc://c", Name: "Testing", Content: "How to test"},
+	}
+	assertConsistent := func(t *testing.T) {
+		t.Helper()
+		if count, _ := service.DocCount(); count != uint64(len(corpus)) {
+			t.Errorf("Expected %d documents, got %d", len(corpus), count)
+		}
+		response, err := service.Search(context.Background(), "deploy", SearchOptions{})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(response.Results) != 2 || response.Total != 2 {
+			t.Errorf("Expected 2 results, got %d (total %d)", len(response.Results), response.Total)
+		}
+	}
+
+	t.Run("Sequential", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if err := indexDocsHelper(service, corpus); err != nil {
+				t.Fatal(err)
+			}
+		}
+		assertConsistent(t)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 4)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- indexDocsHelper(service, corpus)
+				_, _ = service.Search(context.Background(), "deploy", SearchOptions{})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		assertConsistent(t)
+	})
+
+	t.Run("Repeated URI In One Stream", func(t *testing.T) {
+		updated := domain.Document{URI: "acdc://b", Name: "Release Checklist", Content: "Before you release"}
+		if err := indexDocsHelper(service, append(append([]domain.Document{}, corpus...), updated)); err != nil {
+			t.Fatal(err)
+		}
+		if count, _ := service.DocCount(); count != uint64(len(corpus)) {
+			t.Errorf("Expected %d documents, got %d", len(corpus), count)
+		}
+		// The last version wins, including its (default) boost
+		response, err := service.Search(context.Background(), "deploy", SearchOptions{})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(response.Results) != 1 || response.Results[0].URI != "acdc://a" {
+			t.Errorf("Expected only acdc://a to match, got %+v", response.Results)
+		}
+		if _, boosted := service.boosts["acdc://b"]; boosted {
+			t.Error("Expected the boost of the replaced version to be dropped")
+		}
+		if got := service.vocabulary.terms["deploy"]; got != 2 {
+			t.Errorf("Expected the vocabulary to count only the last version, got deploy=%d", got)
+		}
+	})
+}
+
 func TestSearchService_Empty(t *testing.T) {
 	service := NewService(testSettings())
 	// No index created yet
-	results, err := service.Search("test", nil)
+	results, err := searchResults(service.Search(context.Background(), "test", SearchOptions{}))
 	if err != nil {
 		t.Errorf("Expected no error for empty search, got %v", err)
 	}
@@ -316,6 +407,84 @@ func TestSearchService_DiskLifecycle(t *testing.T) {
 	}
 }
 
+func TestSearchService_LimitClampedToMaxResults(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.MaxResults = 3
+	service := NewService(settings)
+	defer service.Close()
+
+	var docs []domain.Document
+	for i := 0; i < 6; i++ {
+		docs = append(docs, domain.Document{URI: fmt.Sprintf("doc%d", i), Name: fmt.Sprintf("Doc %d", i), Content: "shared content"})
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"Below cap", 2, 2},
+		{"At cap", 3, 3},
+		{"Above cap", 50, 3},
+		{"Non-positive uses cap", 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := tt.limit
+			results, err := searchResults(service.Search(context.Background(), "*", SearchOptions{Limit: &limit}))
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(results) != tt.want {
+				t.Errorf("Expected %d results with limit=%d, got %d", tt.want, tt.limit, len(results))
+			}
+		})
+	}
+}
+
+func TestSearchService_MaxTerms(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.MaxTerms = 3
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{{URI: "1", Name: "Alpha", Content: "Content about kubernetes"}}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Long query truncated", func(t *testing.T) {
+		resp, err := service.Search(context.Background(), "one two three kubernetes five", SearchOptions{})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if resp.DroppedTerms != 2 {
+			t.Errorf("Expected 2 dropped terms, got %d", resp.DroppedTerms)
+		}
+		if len(resp.Results) != 0 {
+			t.Errorf("Expected terms past the limit to be ignored, got %d results", len(resp.Results))
+		}
+	})
+
+	t.Run("Short query unaffected", func(t *testing.T) {
+		resp, err := service.Search(context.Background(), "kubernetes content", SearchOptions{})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if resp.DroppedTerms != 0 {
+			t.Errorf("Expected no dropped terms, got %d", resp.DroppedTerms)
+		}
+		if len(resp.Results) != 1 {
+			t.Errorf("Expected 1 result, got %d", len(resp.Results))
+		}
+	})
+}
+
 func TestSearchService_Extended(t *testing.T) {
 	settings := testSettings()
 	settings.InMemory = true
@@ -333,7 +502,7 @@ func TestSearchService_Extended(t *testing.T) {
 	}
 
 	// 1. Test MatchAll (search with "*")
-	results, err := service.Search("*", nil)
+	results, err := searchResults(service.Search(context.Background(), "*", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -344,7 +513,7 @@ func TestSearchService_Extended(t *testing.T) {
 	// 2. Test MaxResults and Limits
 	// Default from settings is 5, request explicit limit 1
 	limit := 1
-	results, err = service.Search("*", &limit)
+	results, err = searchResults(service.Search(context.Background(), "*", SearchOptions{Limit: &limit}))
 	if err != nil {
 		t.Fatalf("Search with limit failed: %v", err)
 	}
@@ -353,7 +522,7 @@ func TestSearchService_Extended(t *testing.T) {
 	}
 
 	// Test nil limit uses MaxResults (all 3 should return because MaxResults=5)
-	results, err = service.Search("*", nil)
+	results, err = searchResults(service.Search(context.Background(), "*", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search with nil limit failed: %v", err)
 	}
@@ -363,7 +532,7 @@ func TestSearchService_Extended(t *testing.T) {
 
 	// 3. Test Result fields (Snippet, URI, Name)
 	// Searching for "Alpha" should return doc 1
-	results, err = service.Search("Alpha", nil)
+	results, err = searchResults(service.Search(context.Background(), "Alpha", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -417,7 +586,7 @@ func TestSearch_AccuracyFeatures(t *testing.T) {
 	}
 
 	// 1. Test Stemming (search "search" matches "searching")
-	results, err := service.Search("search", nil)
+	results, err := searchResults(service.Search(context.Background(), "search", SearchOptions{}))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -426,7 +595,7 @@ func TestSearch_AccuracyFeatures(t *testing.T) {
 	}
 
 	// 2. Test Fuzzy Match (search "serch" matches "Search")
-	results, err = service.Search("serch", nil)
+	results, err = searchResults(service.Search(context.Background(), "serch", SearchOptions{}))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -450,7 +619,7 @@ func TestSearch_MissingName(t *testing.T) {
 	}
 	defer service.Close()
 
-	results, err := service.Search("fox", nil)
+	results, err := searchResults(service.Search(context.Background(), "fox", SearchOptions{}))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -469,7 +638,7 @@ func TestSearch_MissingURI(t *testing.T) {
 
 	// Since we can't easily produce a hit without a URI using IndexDocuments,
 	// we use a real index and custom indexing logic just for this test.
-	index, _ := bleve.NewMemOnly(buildMapping())
+	index, _ := bleve.NewMemOnly(buildMapping(nil, true, ""))
 	_ = index.Index("1", struct {
 		Name    string `json:"name"`
 		Content string `json:"content"`
@@ -480,7 +649,7 @@ func TestSearch_MissingURI(t *testing.T) {
 	service.index = index
 	defer service.Close()
 
-	results, err := service.Search("test", nil)
+	results, err := searchResults(service.Search(context.Background(), "test", SearchOptions{}))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -494,7 +663,7 @@ func TestSearch_WrongTypeName(t *testing.T) {
 	settings := testSettings()
 	settings.InMemory = true
 	service := NewService(settings)
-	index, _ := bleve.NewMemOnly(buildMapping())
+	index, _ := bleve.NewMemOnly(buildMapping(nil, true, ""))
 	_ = index.Index("acdc://test", struct {
 		URI     string `json:"uri"`
 		Name    int    `json:"name"` // wrong type
@@ -507,7 +676,7 @@ func TestSearch_WrongTypeName(t *testing.T) {
 	service.index = index
 	defer service.Close()
 
-	results, err := service.Search("test", nil)
+	results, err := searchResults(service.Search(context.Background(), "test", SearchOptions{}))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -552,7 +721,7 @@ func TestSearch_KeywordsBoosting(t *testing.T) {
 	}
 
 	// Search for "development" - both docs match in content, but doc2 also matches in keywords
-	results, err := service.Search("development", nil)
+	results, err := searchResults(service.Search(context.Background(), "development", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -598,7 +767,7 @@ func TestSearch_KeywordsEmpty(t *testing.T) {
 	}
 
 	// Search should still work normally
-	results, err := service.Search("fox", nil)
+	results, err := searchResults(service.Search(context.Background(), "fox", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -609,7 +778,7 @@ func TestSearch_KeywordsEmpty(t *testing.T) {
 		t.Errorf("Expected doc1, got %s", results[0].URI)
 	}
 
-	results, err = service.Search("elephant", nil)
+	results, err = searchResults(service.Search(context.Background(), "elephant", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -643,7 +812,7 @@ func TestSearch_MultipleKeywords(t *testing.T) {
 
 	// Each keyword should match
 	for _, kw := range []string{"api", "rest", "http", "json"} {
-		results, err := service.Search(kw, nil)
+		results, err := searchResults(service.Search(context.Background(), kw, SearchOptions{}))
 		if err != nil {
 			t.Fatalf("Search for '%s' failed: %v", kw, err)
 		}
@@ -675,7 +844,7 @@ func TestSearch_KeywordsOnlyMatch(t *testing.T) {
 	}
 
 	// Search for "golang" - only in keywords, not in content or name
-	results, err := service.Search("golang", nil)
+	results, err := searchResults(service.Search(context.Background(), "golang", SearchOptions{}))
 	if err != nil {
 		t.Fatalf("Search failed: %v", err)
 	}
@@ -687,3 +856,646 @@ func TestSearch_KeywordsOnlyMatch(t *testing.T) {
 		t.Errorf("Expected acdc://guide, got %s", results[0].URI)
 	}
 }
+
+func TestSearch_MatchedKeywords(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{
+			URI:      "acdc://guide",
+			Name:     "Programming Guide",
+			Content:  "This document discusses various programming concepts",
+			Keywords: []string{"golang", "concurrency", "channels"},
+		},
+		{
+			URI:      "acdc://single",
+			Name:     "Deployment Guide",
+			Content:  "Shipping services",
+			Keywords: []string{"kubernetes"},
+		},
+	}
+
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "channels golang", want: []string{"golang", "channels"}},
+		{query: "kubernetes", want: []string{"kubernetes"}},
+		{query: "programming", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := searchResults(service.Search(context.Background(), tt.query, SearchOptions{}))
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("Expected 1 result, got %d", len(results))
+			}
+			if !reflect.DeepEqual(results[0].MatchedKeywords, tt.want) {
+				t.Errorf("Expected matched keywords %v, got %v", tt.want, results[0].MatchedKeywords)
+			}
+		})
+	}
+}
+
+func TestSearch_TitleMatch(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://deploy", Name: "deploy", Title: "Kubernetes Rollout Handbook", Content: "Steps for shipping services"},
+		{URI: "acdc://style", Name: "style", Title: "style", Content: "Formatting rules"},
+	}
+
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	results, err := searchResults(service.Search(context.Background(), "handbook", SearchOptions{}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].URI != "acdc://deploy" {
+		t.Fatalf("Expected only acdc://deploy for title-only match, got %+v", results)
+	}
+}
+
+func TestSearch_FieldFilters(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.Fields = []string{"category", "audience"}
+	settings.FieldsBoost = 1.0
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://restart", Name: "restart", Content: "How to restart the service", Fields: map[string]string{"category": "Runbook", "audience": "sre"}},
+		{URI: "acdc://design", Name: "design", Content: "Why the service restarts itself", Fields: map[string]string{"category": "adr", "audience": "platform team"}},
+		{URI: "acdc://notes", Name: "notes", Content: "Service restart notes"},
+	}
+
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	results, err := searchResults(service.Search(context.Background(), "restart", SearchOptions{Filters: map[string]string{"category": "runbook"}}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].URI != "acdc://restart" {
+		t.Fatalf("Expected only acdc://restart for category=runbook, got %+v", results)
+	}
+
+	// Filters match whole values, not individual words
+	results, err = searchResults(service.Search(context.Background(), "*", SearchOptions{Filters: map[string]string{"audience": "platform"}}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("Expected no results for partial field value, got %+v", results)
+	}
+
+	results, err = searchResults(service.Search(context.Background(), "*", SearchOptions{Filters: map[string]string{"audience": "Platform Team"}}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].URI != "acdc://design" {
+		t.Fatalf("Expected only acdc://design for audience filter, got %+v", results)
+	}
+
+	// Configured fields are searchable as text
+	results, err = searchResults(service.Search(context.Background(), "sre", SearchOptions{}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].URI != "acdc://restart" {
+		t.Fatalf("Expected only acdc://restart for field text match, got %+v", results)
+	}
+}
+
+func TestSearch_UnknownFilterField(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.Fields = []string{"category"}
+	service := NewService(settings)
+	defer service.Close()
+
+	if err := indexDocsHelper(service, []domain.Document{{URI: "acdc://a", Name: "a", Content: "alpha"}}); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	_, err := searchResults(service.Search(context.Background(), "alpha", SearchOptions{Filters: map[string]string{"owner": "me"}}))
+	if !errors.Is(err, ErrUnknownFilterField) {
+		t.Fatalf("Expected unknown filter field error, got %v", err)
+	}
+}
+
+func TestSearch_Since(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.Fields = []string{"category"}
+	service := NewService(settings)
+	defer service.Close()
+
+	now := time.Now()
+	recent := now.Add(-24 * time.Hour)
+	old := now.Add(-30 * 24 * time.Hour)
+
+	docs := []domain.Document{
+		{URI: "acdc://recent", Name: "recent", Content: "deploy guide", LastModified: &recent, Fields: map[string]string{"category": "runbook"}},
+		{URI: "acdc://recent-adr", Name: "recent-adr", Content: "deploy decision", LastModified: &recent, Fields: map[string]string{"category": "adr"}},
+		{URI: "acdc://old", Name: "old", Content: "deploy notes", LastModified: &old, Fields: map[string]string{"category": "runbook"}},
+		{URI: "acdc://unknown", Name: "unknown", Content: "deploy draft"},
+	}
+
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	results, err := searchResults(service.Search(context.Background(), "deploy", SearchOptions{Since: now.Add(-7 * 24 * time.Hour)}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if uris := resultURIs(results); !reflect.DeepEqual(uris, []string{"acdc://recent", "acdc://recent-adr"}) {
+		t.Fatalf("Expected only recent resources, got %v", uris)
+	}
+
+	// Since combines with field filters
+	results, err = searchResults(service.Search(context.Background(), "*", SearchOptions{
+		Since:   now.Add(-7 * 24 * time.Hour),
+		Filters: map[string]string{"category": "runbook"},
+	}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if uris := resultURIs(results); !reflect.DeepEqual(uris, []string{"acdc://recent"}) {
+		t.Fatalf("Expected only acdc://recent, got %v", uris)
+	}
+
+	// No cutoff returns everything
+	results, err = searchResults(service.Search(context.Background(), "deploy", SearchOptions{}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results without since, got %d", len(results))
+	}
+}
+
+func TestSearch_URIs(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.Fields = []string{"category"}
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://a", Name: "a", Content: "deploy guide", Fields: map[string]string{"category": "runbook"}},
+		{URI: "acdc://b", Name: "b", Content: "deploy decision", Fields: map[string]string{"category": "adr"}},
+		{URI: "acdc://c", Name: "c", Content: "deploy notes", Fields: map[string]string{"category": "runbook"}},
+		{URI: "acdc://d", Name: "d", Content: "unrelated"},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want []string
+	}{
+		{name: "Subset", opts: SearchOptions{URIs: []string{"acdc://a", "acdc://b"}}, want: []string{"acdc://a", "acdc://b"}},
+		{name: "Intersects Matches", opts: SearchOptions{URIs: []string{"acdc://a", "acdc://d"}}, want: []string{"acdc://a"}},
+		{name: "Unknown URIs Ignored", opts: SearchOptions{URIs: []string{"acdc://c", "acdc://missing"}}, want: []string{"acdc://c"}},
+		{name: "Combines With Filters", opts: SearchOptions{URIs: []string{"acdc://a", "acdc://b"}, Filters: map[string]string{"category": "runbook"}}, want: []string{"acdc://a"}},
+		{name: "Empty Set", opts: SearchOptions{URIs: []string{}}, want: []string{}},
+		{name: "Unrestricted", opts: SearchOptions{}, want: []string{"acdc://a", "acdc://b", "acdc://c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := searchResults(service.Search(context.Background(), "deploy", tt.opts))
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if uris := resultURIs(results); !reflect.DeepEqual(uris, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, uris)
+			}
+		})
+	}
+}
+
+func TestSearch_Total(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.MaxResults = 2
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://a", Name: "a", Content: "deploy guide"},
+		{URI: "acdc://b", Name: "b", Content: "deploy decision"},
+		{URI: "acdc://c", Name: "c", Content: "deploy notes"},
+		{URI: "acdc://d", Name: "d", Content: "unrelated"},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	response, err := service.Search(context.Background(), "deploy", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 2 || response.Total != 3 {
+		t.Errorf("Expected 2 of 3 matches, got %d of %d", len(response.Results), response.Total)
+	}
+
+	response, err = service.Search(context.Background(), "unrelated", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 1 || response.Total != 1 {
+		t.Errorf("Expected 1 of 1 matches, got %d of %d", len(response.Results), response.Total)
+	}
+}
+
+// resultURIs returns the sorted URIs of search results
+func resultURIs(results []SearchResult) []string {
+	uris := make([]string, len(results))
+	for i, r := range results {
+		uris[i] = r.URI
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+func TestSearch_Suggestions(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://k8s", Name: "Kubernetes Guide", Content: "Deploying workloads to clusters", Keywords: []string{"kubernetes"}},
+		{URI: "acdc://style", Name: "Style Guide", Content: "Formatting rules", Keywords: []string{"formatting"}},
+	}
+
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	response, err := service.Search(context.Background(), "kuberentes", SearchOptions{Suggest: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 0 {
+		t.Fatalf("Expected no results for near-miss query, got %+v", response.Results)
+	}
+	if !reflect.DeepEqual(response.Suggestions, []string{"kubernetes"}) {
+		t.Errorf("Expected suggestions [kubernetes], got %v", response.Suggestions)
+	}
+
+	// Suggestions are only computed when requested
+	response, err = service.Search(context.Background(), "kuberentes", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if response.Suggestions != nil {
+		t.Errorf("Expected no suggestions when not requested, got %v", response.Suggestions)
+	}
+
+	// Suggestions are not computed when the search has results
+	response, err = service.Search(context.Background(), "kubernetes", SearchOptions{Suggest: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) == 0 || response.Suggestions != nil {
+		t.Errorf("Expected results without suggestions, got %+v", response)
+	}
+}
+
+func TestSearchService_LowMemory(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	settings.LowMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	body := "Introduction to the platform. Deploy services with the pipeline and watch the rollout."
+	loads := 0
+	service.SetContentLoader(func(uri string) (string, error) {
+		loads++
+		if uri != "acdc://deploy" {
+			return "", errors.New("not found: " + uri)
+		}
+		return body, nil
+	})
+
+	docs := []domain.Document{
+		{URI: "acdc://deploy", Name: "Deploy", Content: body},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	// Content bodies are not retained by the index
+	request := bleve.NewSearchRequest(bleve.NewMatchAllQuery())
+	request.Fields = []string{domain.FieldContent}
+	stored, err := service.index.Search(request)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(stored.Hits) != 1 {
+		t.Fatalf("Expected 1 indexed document, got %d", len(stored.Hits))
+	}
+	if _, ok := stored.Hits[0].Fields[domain.FieldContent]; ok {
+		t.Error("Expected content not to be stored in low memory mode")
+	}
+
+	results, err := searchResults(service.Search(context.Background(), "pipeline", SearchOptions{}))
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if !contains(results[0].Snippet, "with the <mark>pipeline</mark> and") {
+		t.Errorf("Expected snippet built from re-read content, got %q", results[0].Snippet)
+	}
+	if loads != 1 {
+		t.Errorf("Expected content to be loaded once, got %d", loads)
+	}
+}
+
+func TestLoadFragment_Bounds(t *testing.T) {
+	content := "héllo wörld"
+	loader := func(string) (string, error) { return content, nil }
+
+	hit := func(start, end uint64) *blevesearch.DocumentMatch {
+		return &blevesearch.DocumentMatch{Locations: blevesearch.FieldTermLocationMap{
+			domain.FieldContent: {"term": {{Start: start, End: end}}},
+		}}
+	}
+
+	if got := loadFragment(loader, "acdc://doc", hit(7, 13)); got != "héllo <mark>wörld</mark>" {
+		t.Errorf("Unexpected fragment %q", got)
+	}
+	if got := loadFragment(loader, "acdc://doc", hit(7, 100)); got != "" {
+		t.Errorf("Expected no fragment for stale offsets, got %q", got)
+	}
+	if got := loadFragment(loader, "acdc://doc", &blevesearch.DocumentMatch{}); got != "" {
+		t.Errorf("Expected no fragment without a content match, got %q", got)
+	}
+}
+
+func TestSearch_SnippetSource(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://body", Name: "Body", Description: "Body description", Content: "Rolling out the pipeline"},
+		{URI: "acdc://described", Name: "Described", Description: "Describes releases", Content: "Unrelated text", Keywords: []string{"pipeline"}},
+		{URI: "acdc://bare", Name: "Bare", Content: "Unrelated text", Keywords: []string{"pipeline"}},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	tests := []struct {
+		source string
+		want   map[string]string
+	}{
+		{source: "", want: map[string]string{
+			"acdc://body":      "Rolling out the <mark>pipeline</mark>...",
+			"acdc://described": "Described (relevance:",
+			"acdc://bare":      "Bare (relevance:",
+		}},
+		{source: SnippetSourceBody, want: map[string]string{
+			"acdc://body":      "Rolling out the <mark>pipeline</mark>...",
+			"acdc://described": "Described (relevance:",
+		}},
+		{source: SnippetSourceDescription, want: map[string]string{
+			"acdc://body":      "Body description (relevance:",
+			"acdc://described": "Describes releases (relevance:",
+			"acdc://bare":      "Bare (relevance:",
+		}},
+		{source: SnippetSourceAuto, want: map[string]string{
+			"acdc://body":      "Rolling out the <mark>pipeline</mark>...",
+			"acdc://described": "Describes releases (relevance:",
+			"acdc://bare":      "Bare (relevance:",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run("Source "+tt.source, func(t *testing.T) {
+			results, err := searchResults(service.Search(context.Background(), "pipeline", SearchOptions{SnippetSource: tt.source}))
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			snippets := make(map[string]string)
+			for _, r := range results {
+				snippets[r.URI] = r.Snippet
+			}
+			for uri, prefix := range tt.want {
+				if !strings.HasPrefix(snippets[uri], prefix) {
+					t.Errorf("Expected snippet of %s to start with %q, got %q", uri, prefix, snippets[uri])
+				}
+			}
+		})
+	}
+}
+
+func TestSearch_UnknownSnippetSource(t *testing.T) {
+	service := NewService(testSettings())
+	defer service.Close()
+	if err := indexDocsHelper(service, []domain.Document{{URI: "acdc://doc", Name: "Doc", Content: "text"}}); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	if _, err := service.Search(context.Background(), "text", SearchOptions{SnippetSource: "summary"}); err == nil {
+		t.Error("Expected error for unknown snippet source")
+	}
+}
+
+func TestSearch_Ranking(t *testing.T) {
+	// A one-word resource, a long one repeating the term, and a term-free
+	// resource with a large vocabulary that raises the average field length.
+	// TF-IDF normalizes each field by its own length and favors the short
+	// resource. BM25 normalizes by the average length and favors repetition.
+	vocabulary := make([]string, 300)
+	for i := range vocabulary {
+		vocabulary[i] = fmt.Sprintf("w%d", i)
+	}
+	docs := []domain.Document{
+		{URI: "acdc://focused", Name: "Focused", Content: "alpha"},
+		{URI: "acdc://verbose", Name: "Verbose", Content: strings.Repeat("alpha ", 16) + strings.Repeat("lorem ", 48)},
+		{URI: "acdc://glossary", Name: "Glossary", Content: strings.Join(vocabulary, " ")},
+	}
+
+	tests := []struct {
+		name     string
+		ranking  string
+		inMemory bool
+		want     []string
+	}{
+		{name: "Default", ranking: "", inMemory: true, want: []string{"acdc://focused", "acdc://verbose"}},
+		{name: "TF-IDF", ranking: config.SearchRankingTFIDF, inMemory: true, want: []string{"acdc://focused", "acdc://verbose"}},
+		{name: "BM25 In Memory", ranking: config.SearchRankingBM25, inMemory: true, want: []string{"acdc://verbose", "acdc://focused"}},
+		{name: "BM25 On Disk", ranking: config.SearchRankingBM25, inMemory: false, want: []string{"acdc://verbose", "acdc://focused"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := testSettings()
+			settings.Ranking = tt.ranking
+			settings.InMemory = tt.inMemory
+			service := NewService(settings)
+			defer service.Close()
+
+			if err := indexDocsHelper(service, docs); err != nil {
+				t.Fatalf("IndexDocuments failed: %v", err)
+			}
+			results, err := searchResults(service.Search(context.Background(), "alpha", SearchOptions{}))
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			got := make([]string, len(results))
+			for i, r := range results {
+				got[i] = r.URI
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected order %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSearchStream(t *testing.T) {
+	service := NewService(testSettings())
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://notes", Name: "Notes", Content: "deploy notes and other unrelated material about the release"},
+		{URI: "acdc://deploy", Name: "Deploy", Keywords: []string{"deploy"}, Content: "deploy guide"},
+		{URI: "acdc://checklist", Name: "Checklist", Content: "deploy deploy checklist"},
+		{URI: "acdc://other", Name: "Other", Content: "unrelated"},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	response, err := service.Search(context.Background(), "deploy", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 3 || response.Results[0].URI != "acdc://deploy" {
+		t.Fatalf("Unexpected search results: %+v", response.Results)
+	}
+
+	t.Run("Rank Order", func(t *testing.T) {
+		var streamed []SearchResult
+		for result, err := range service.SearchStream(context.Background(), "deploy", SearchOptions{}) {
+			if err != nil {
+				t.Fatalf("SearchStream failed: %v", err)
+			}
+			streamed = append(streamed, result)
+		}
+		if !reflect.DeepEqual(streamed, response.Results) {
+			t.Errorf("Expected the streamed results to match Search\n got: %+v\nwant: %+v", streamed, response.Results)
+		}
+	})
+
+	t.Run("Early Stop", func(t *testing.T) {
+		var uris []string
+		for result, err := range service.SearchStream(context.Background(), "deploy", SearchOptions{}) {
+			if err != nil {
+				t.Fatalf("SearchStream failed: %v", err)
+			}
+			uris = append(uris, result.URI)
+			break
+		}
+		if len(uris) != 1 || uris[0] != "acdc://deploy" {
+			t.Errorf("Expected only the top result, got %v", uris)
+		}
+
+		// The read lock is released, so the index can be rebuilt
+		if err := indexDocsHelper(service, docs); err != nil {
+			t.Fatalf("IndexDocuments failed: %v", err)
+		}
+	})
+
+	t.Run("Reentrant", func(t *testing.T) {
+		var uris []string
+		for result, err := range service.SearchStream(context.Background(), "deploy", SearchOptions{}) {
+			if err != nil {
+				t.Fatalf("SearchStream failed: %v", err)
+			}
+			uris = append(uris, result.URI)
+			// Neither a nested search nor re-indexing blocks on the stream
+			if _, err := service.Search(context.Background(), "deploy", SearchOptions{}); err != nil {
+				t.Fatalf("Nested Search failed: %v", err)
+			}
+			if err := indexDocsHelper(service, docs); err != nil {
+				t.Fatalf("IndexDocuments failed: %v", err)
+			}
+		}
+		if len(uris) != len(response.Results) {
+			t.Errorf("Expected %d results, got %v", len(response.Results), uris)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		limit := 2
+		var uris []string
+		for result, err := range service.SearchStream(context.Background(), "deploy", SearchOptions{Limit: &limit}) {
+			if err != nil {
+				t.Fatalf("SearchStream failed: %v", err)
+			}
+			uris = append(uris, result.URI)
+		}
+		if want := []string{response.Results[0].URI, response.Results[1].URI}; !reflect.DeepEqual(uris, want) {
+			t.Errorf("Expected %v, got %v", want, uris)
+		}
+	})
+
+	t.Run("No Matches", func(t *testing.T) {
+		for result, err := range service.SearchStream(context.Background(), "nonexistent", SearchOptions{}) {
+			t.Errorf("Expected no results, got %+v (err %v)", result, err)
+		}
+	})
+
+	t.Run("Unknown Snippet Source", func(t *testing.T) {
+		var errs []error
+		for _, err := range service.SearchStream(context.Background(), "deploy", SearchOptions{SnippetSource: "summary"}) {
+			errs = append(errs, err)
+		}
+		if len(errs) != 1 || !errors.Is(errs[0], ErrUnknownSnippetSource) {
+			t.Errorf("Expected a single unknown snippet source error, got %v", errs)
+		}
+	})
+
+	t.Run("Cancelled Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var count int
+		var last error
+		for _, err := range service.SearchStream(ctx, "deploy", SearchOptions{}) {
+			count++
+			last = err
+			cancel()
+		}
+		if last == nil || count > 2 {
+			t.Errorf("Expected the stream to end with an error after cancellation, got %d elements, last error %v", count, last)
+		}
+	})
+}
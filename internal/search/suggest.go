@@ -0,0 +1,165 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+// maxSuggestions caps the number of suggested query terms
+const maxSuggestions = 5
+
+// minSuggestionTermLength excludes short, low-signal words from the vocabulary
+const minSuggestionTermLength = 3
+
+// vocabulary tracks the raw, unanalyzed terms of indexed documents so that
+// suggestions are real words rather than stems
+type vocabulary struct {
+	terms    map[string]int // term frequency across names, titles, content, and keywords
+	keywords map[string]int // keyword frequency across documents
+}
+
+func newVocabulary() *vocabulary {
+	return &vocabulary{
+		terms:    make(map[string]int),
+		keywords: make(map[string]int),
+	}
+}
+
+// add records the terms of a document
+func (v *vocabulary) add(doc domain.Document) {
+	for _, text := range []string{doc.Name, doc.Title, doc.Content} {
+		for _, term := range tokenize(text) {
+			v.terms[term]++
+		}
+	}
+	for _, k := range doc.Keywords {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "" {
+			continue
+		}
+		v.keywords[k]++
+		for _, term := range tokenize(k) {
+			v.terms[term]++
+		}
+	}
+}
+
+// suggest returns vocabulary terms close to the terms of queryStr, ranked by
+// edit distance and then frequency. When no term is close enough, the most
+// popular keywords are returned instead.
+func (v *vocabulary) suggest(queryStr string) []string {
+	queryTerms := tokenize(queryStr)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	type candidate struct {
+		term     string
+		distance int
+		count    int
+	}
+	var candidates []candidate
+	for term, count := range v.terms {
+		best := -1
+		for _, q := range queryTerms {
+			if term == q {
+				continue
+			}
+			d := levenshtein(q, term)
+			if d <= maxSuggestionDistance(q) && (best < 0 || d < best) {
+				best = d
+			}
+		}
+		if best >= 0 {
+			candidates = append(candidates, candidate{term: term, distance: best, count: count})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return v.popularKeywords()
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		if candidates[i].count != candidates[j].count {
+			return candidates[i].count > candidates[j].count
+		}
+		return candidates[i].term < candidates[j].term
+	})
+
+	suggestions := make([]string, 0, maxSuggestions)
+	for _, c := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, c.term)
+	}
+	return suggestions
+}
+
+// popularKeywords returns the most frequently used keywords
+func (v *vocabulary) popularKeywords() []string {
+	keywords := make([]string, 0, len(v.keywords))
+	for k := range v.keywords {
+		keywords = append(keywords, k)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if v.keywords[keywords[i]] != v.keywords[keywords[j]] {
+			return v.keywords[keywords[i]] > v.keywords[keywords[j]]
+		}
+		return keywords[i] < keywords[j]
+	})
+	if len(keywords) > maxSuggestions {
+		keywords = keywords[:maxSuggestions]
+	}
+	return keywords
+}
+
+// maxSuggestionDistance scales the tolerated edit distance with term length
+func maxSuggestionDistance(term string) int {
+	if len([]rune(term)) <= 4 {
+		return 1
+	}
+	return 2
+}
+
+// tokenize splits text into lowercase words, dropping short ones
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, w := range words {
+		if len([]rune(w)) >= minSuggestionTermLength {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"deploy", "deploy", 0},
+		{"kubernetes", "kuberentes", 2},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVocabulary_Suggest(t *testing.T) {
+	v := newVocabulary()
+	v.add(domain.Document{URI: "acdc://k8s", Name: "Kubernetes Guide", Content: "Deploying to kubernetes clusters", Keywords: []string{"kubernetes", "k8s"}})
+	v.add(domain.Document{URI: "acdc://kubectl", Name: "Kubectl Cheatsheet", Content: "Common kubectl commands", Keywords: []string{"kubectl", "kubernetes"}})
+
+	got := v.suggest("kuberentes")
+	if !reflect.DeepEqual(got, []string{"kubernetes"}) {
+		t.Errorf("Expected [kubernetes], got %v", got)
+	}
+}
+
+func TestVocabulary_SuggestFallsBackToPopularKeywords(t *testing.T) {
+	v := newVocabulary()
+	v.add(domain.Document{URI: "acdc://a", Name: "a", Keywords: []string{"testing", "Go"}})
+	v.add(domain.Document{URI: "acdc://b", Name: "b", Keywords: []string{"testing", "ci"}})
+
+	got := v.suggest("zzzzzz")
+	if !reflect.DeepEqual(got, []string{"testing", "ci", "go"}) {
+		t.Errorf("Expected popular keywords [testing ci go], got %v", got)
+	}
+}
+
+func TestVocabulary_SuggestEmptyQuery(t *testing.T) {
+	v := newVocabulary()
+	v.add(domain.Document{Name: "a", Keywords: []string{"testing"}})
+
+	if got := v.suggest("a !"); got != nil {
+		t.Errorf("Expected no suggestions for a query without terms, got %v", got)
+	}
+}
@@ -0,0 +1,33 @@
+package search
+
+import (
+	"slices"
+	"strings"
+)
+
+// synonymBoost scales the field boosts of queries for expanded synonyms, so
+// resources matching the searched term itself rank above those matching only
+// one of its synonyms
+const synonymBoost = 0.8
+
+// expandSynonyms returns the synonyms of the terms in queryStr, joined by
+// spaces. Terms are matched case-insensitively against the synonym map, and
+// synonyms that are already query terms are left out. It returns "" when no
+// term has synonyms.
+func expandSynonyms(queryStr string, synonyms map[string][]string) string {
+	if len(synonyms) == 0 {
+		return ""
+	}
+
+	terms := strings.Fields(strings.ToLower(queryStr))
+	var expanded []string
+	for _, term := range terms {
+		for _, synonym := range synonyms[term] {
+			synonym = strings.ToLower(synonym)
+			if !slices.Contains(terms, synonym) && !slices.Contains(expanded, synonym) {
+				expanded = append(expanded, synonym)
+			}
+		}
+	}
+	return strings.Join(expanded, " ")
+}
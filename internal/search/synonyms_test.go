@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+func TestExpandSynonyms(t *testing.T) {
+	synonyms := map[string][]string{
+		"k8s": {"kubernetes", "kube"},
+		"pg":  {"Postgres"},
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "Single Term", query: "k8s", want: "kubernetes kube"},
+		{name: "Case Insensitive", query: "K8S deploy", want: "kubernetes kube"},
+		{name: "Several Terms", query: "pg on k8s", want: "postgres kubernetes kube"},
+		{name: "Skips Query Terms", query: "k8s kubernetes", want: "kube"},
+		{name: "One Way", query: "kubernetes", want: ""},
+		{name: "No Synonyms", query: "deploy", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandSynonyms(tt.query, synonyms); got != tt.want {
+				t.Errorf("expandSynonyms() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := expandSynonyms("k8s", nil); got != "" {
+		t.Errorf("Expected no expansion without synonyms, got %q", got)
+	}
+}
+
+func TestSearch_Synonyms(t *testing.T) {
+	docs := []domain.Document{
+		{URI: "acdc://cluster", Name: "Cluster", Content: "Running services on Kubernetes."},
+		{URI: "acdc://shorthand", Name: "Shorthand", Content: "Running services on k8s."},
+		{URI: "acdc://other", Name: "Other", Content: "Running services on bare metal."},
+	}
+
+	t.Run("Expands To Canonical Term", func(t *testing.T) {
+		settings := testSettings()
+		settings.InMemory = true
+		settings.Synonyms = []string{"k8s=kubernetes"}
+		service := NewService(settings)
+		defer service.Close()
+		if err := indexDocsHelper(service, docs); err != nil {
+			t.Fatalf("IndexDocuments failed: %v", err)
+		}
+
+		results, err := searchResults(service.Search(context.Background(), "k8s", SearchOptions{}))
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 results, got %v", resultURIs(results))
+		}
+		// The exact match outranks the synonym match
+		if results[0].URI != "acdc://shorthand" || results[1].URI != "acdc://cluster" {
+			t.Errorf("Expected the exact match first, got %s, %s", results[0].URI, results[1].URI)
+		}
+	})
+
+	t.Run("Disabled By Default", func(t *testing.T) {
+		settings := testSettings()
+		settings.InMemory = true
+		service := NewService(settings)
+		defer service.Close()
+		if err := indexDocsHelper(service, docs); err != nil {
+			t.Fatalf("IndexDocuments failed: %v", err)
+		}
+
+		results, err := searchResults(service.Search(context.Background(), "k8s", SearchOptions{}))
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 || results[0].URI != "acdc://shorthand" {
+			t.Errorf("Expected only the exact match, got %v", resultURIs(results))
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmptyContentDir verifies that the minimal valid layout (metadata plus an
+// empty resources directory) starts cleanly and serves nothing
+func TestEmptyContentDir(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	resources, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, resources.Resources)
+
+	prompts, err := client.ListPrompts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, prompts.Prompts)
+
+	result, err := client.CallTool(ctx, "search", map[string]any{"query": "anything"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, getTextContent(t, result), "No results")
+}
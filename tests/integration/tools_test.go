@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -135,6 +136,28 @@ This is the API documentation content.`,
 	})
 }
 
+// TestDescribeToolExecution tests describe tool via tools/call
+func TestDescribeToolExecution(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"one.md": "---\nname: One\ndescription: First resource\n---\nOne.",
+			"two.md": "---\nname: Two\ndescription: Second resource\n---\nTwo.",
+		},
+	})
+	defer client.Close()
+
+	result, err := client.CallTool(context.Background(), "describe", map[string]any{})
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	var description map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextContent(t, result)), &description))
+	assert.NotEmpty(t, description["name"])
+	assert.Equal(t, "stdio", description["transport"])
+	assert.EqualValues(t, 2, description["resources"])
+	assert.Contains(t, description["tools"], "describe")
+}
+
 // getTextContent extracts text from the first content item in a tool result
 func getTextContent(t *testing.T, result *mcp.CallToolResult) string {
 	t.Helper()